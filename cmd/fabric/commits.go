@@ -0,0 +1,38 @@
+package fabric
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+// Commit is a transaction that was committed as valid through an organization's gateway.
+type Commit struct {
+	TxID        string
+	BlockNumber uint64
+	Time        time.Time // When the commit status was received
+}
+
+// lastCommits holds the most recent Commit of each organization, by organization name.
+var lastCommits sync.Map
+
+// LastCommit returns the most recent transaction committed as valid through the gateway of an organization since
+// the server started.
+func LastCommit(org string) (Commit, bool) {
+	commit, ok := lastCommits.Load(org)
+	if !ok {
+		return Commit{}, false
+	}
+	return commit.(Commit), true
+}
+
+// recordCommit remembers a commit status reply of an organization's gateway if the transaction is valid.
+func recordCommit(org, txID string, reply any) {
+	status, ok := reply.(*gateway.CommitStatusResponse)
+	if !ok || status.GetResult() != peer.TxValidationCode_VALID {
+		return
+	}
+	lastCommits.Store(org, Commit{TxID: txID, BlockNumber: status.GetBlockNumber(), Time: time.Now()})
+}
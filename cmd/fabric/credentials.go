@@ -0,0 +1,58 @@
+package fabric
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of certificates an organization connects with.
+const (
+	CertIdentity = "identity" // Enrollment certificate of the user that signs transactions
+	CertTLSCA    = "tls_ca"   // CA certificate that the gateway peer's TLS certificate is verified with
+)
+
+// Certificate describes a certificate an organization connects with.
+type Certificate struct {
+	Kind     string
+	Path     string
+	Subject  string
+	NotAfter time.Time
+	Err      error // Why the certificate failed to load; the other fields but Kind and Path are then unset
+}
+
+// Certificates loads the identity certificate and TLS CA certificate of an organization.
+func (setup OrgSetup) Certificates() []Certificate {
+	certificates := []Certificate{
+		{Kind: CertIdentity, Path: setup.CertPath},
+		{Kind: CertTLSCA, Path: setup.TLSCertPath},
+	}
+	for i, cert := range certificates {
+		certificate, err := loadCertificate(cert.Path)
+		if err != nil {
+			certificates[i].Err = err
+			continue
+		}
+		certificates[i].Subject = certificate.Subject.String()
+		certificates[i].NotAfter = certificate.NotAfter
+	}
+	return certificates
+}
+
+// CheckCredentials loads the identity certificate, private key and TLS CA certificate of an organization the way
+// Initialize does and returns the first that fails to load or has expired. It describes the certificates either way.
+func (setup OrgSetup) CheckCredentials() ([]Certificate, error) {
+	certificates := setup.Certificates()
+	now := time.Now()
+	for _, cert := range certificates {
+		if cert.Err != nil {
+			return certificates, fmt.Errorf("%s certificate: %w", cert.Kind, cert.Err)
+		}
+		if now.After(cert.NotAfter) {
+			return certificates, fmt.Errorf("%s certificate %s expired at %s", cert.Kind, cert.Path, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+	if _, err := loadPrivateKey(setup.KeyPath); err != nil {
+		return certificates, err
+	}
+	return certificates, nil
+}
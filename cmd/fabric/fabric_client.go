@@ -1,13 +1,15 @@
 package fabric
 
 import (
+	"crypto"
 	"crypto/x509"
 	"fmt"
-	"log"
 	"os"
 	"path"
 	"time"
 
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
 	"github.com/hyperledger/fabric-gateway/pkg/hash"
 	"github.com/hyperledger/fabric-gateway/pkg/identity"
@@ -16,85 +18,115 @@ import (
 )
 
 func Initialize(setup OrgSetup) (*OrgSetup, error) {
-	log.Printf("Initializing connection for %s...\n", setup.OrgName)
-	clientConnection := setup.newGrpcConnection()
-	id := setup.newIdentity()
-	sign := setup.newSign()
+	logger.Debug("Initializing connection", logging.OrgKey, setup.OrgName)
+	id, err := setup.newIdentity()
+	if err != nil {
+		return nil, err
+	}
+	sign, err := setup.newSign()
+	if err != nil {
+		return nil, err
+	}
+	clientConnection, err := setup.newGrpcConnection()
+	if err != nil {
+		return nil, err
+	}
 
 	gateway, err := client.Connect(
 		id,
 		client.WithSign(sign),
 		client.WithHash(hash.SHA256),
 		client.WithClientConnection(clientConnection),
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
+		client.WithEvaluateTimeout(EvaluateTimeout),
+		client.WithEndorseTimeout(EndorseTimeout),
+		client.WithSubmitTimeout(SubmitTimeout),
+		client.WithCommitStatusTimeout(CommitStatusTimeout),
 	)
 	if err != nil {
-		panic(err)
+		clientConnection.Close()
+		return nil, err
 	}
 	setup.Gateway = *gateway
-	log.Println("Initialization complete")
+	setup.Connection = clientConnection
+	metrics.GatewayOpened(setup.OrgName)
+	logger.Debug("Initialization complete", logging.OrgKey, setup.OrgName)
 	return &setup, nil
 }
 
+// Close closes the gateway and its gRPC connection, which the gateway does not close itself
+// because the connection was passed to it.
+func (setup *OrgSetup) Close() error {
+	metrics.GatewayClosed(setup.OrgName)
+	gatewayErr := setup.Gateway.Close()
+	if err := setup.Connection.Close(); err != nil {
+		return err
+	}
+	return gatewayErr
+}
+
 // newGrpcConnection creates a gRPC connection to the Gateway server.
-func (setup OrgSetup) newGrpcConnection() *grpc.ClientConn {
+func (setup OrgSetup) newGrpcConnection() (*grpc.ClientConn, error) {
 	certificate, err := loadCertificate(setup.TLSCertPath)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to load TLS CA certificate of %s: %w", setup.OrgName, err)
+	}
+	if time.Now().After(certificate.NotAfter) {
+		return nil, fmt.Errorf("TLS CA certificate %s of %s expired at %s", setup.TLSCertPath, setup.OrgName, certificate.NotAfter.Format(time.RFC3339))
 	}
 
 	certPool := x509.NewCertPool()
 	certPool.AddCert(certificate)
 	transportCredentials := credentials.NewClientTLSFromCert(certPool, setup.GatewayPeer)
 
-	connection, err := grpc.NewClient(setup.PeerEndpoint, grpc.WithTransportCredentials(transportCredentials))
+	connection, err := grpc.NewClient(setup.PeerEndpoint,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithChainUnaryInterceptor(instrumentGateway(setup.OrgName)),
+	)
 	if err != nil {
-		panic(fmt.Errorf("failed to create gRPC connection: %w", err))
+		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
 	}
 
-	return connection
+	return connection, nil
 }
 
 // newIdentity creates a client identity for this Gateway connection using an X.509 certificate.
-func (setup OrgSetup) newIdentity() *identity.X509Identity {
+// An expired certificate is reported here rather than as an opaque access denied error from the peer.
+func (setup OrgSetup) newIdentity() (*identity.X509Identity, error) {
 	certificate, err := loadCertificate(setup.CertPath)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to load identity certificate of %s: %w", setup.OrgName, err)
 	}
-
-	id, err := identity.NewX509Identity(setup.MSPID, certificate)
-	if err != nil {
-		panic(err)
+	if time.Now().After(certificate.NotAfter) {
+		return nil, fmt.Errorf("identity certificate %s of %s expired at %s", setup.CertPath, setup.OrgName, certificate.NotAfter.Format(time.RFC3339))
 	}
 
-	return id
+	return identity.NewX509Identity(setup.MSPID, certificate)
 }
 
 // newSign creates a function that generates a digital signature from a message digest using a private key.
-func (setup OrgSetup) newSign() identity.Sign {
-	files, err := os.ReadDir(setup.KeyPath)
-	if err != nil {
-		panic(fmt.Errorf("failed to read private key directory: %w", err))
-	}
-	privateKeyPEM, err := os.ReadFile(path.Join(setup.KeyPath, files[0].Name()))
+func (setup OrgSetup) newSign() (identity.Sign, error) {
+	privateKey, err := loadPrivateKey(setup.KeyPath)
 	if err != nil {
-		panic(fmt.Errorf("failed to read private key file: %w", err))
+		return nil, err
 	}
 
-	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
+	return identity.NewPrivateKeySign(privateKey)
+}
+
+// loadPrivateKey reads the private key from the first file in dir, where the Fabric CA client stores it.
+func loadPrivateKey(dir string) (crypto.PrivateKey, error) {
+	files, err := os.ReadDir(dir)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to read private key directory: %w", err)
 	}
-
-	sign, err := identity.NewPrivateKeySign(privateKey)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no private key file in %s", dir)
+	}
+	privateKeyPEM, err := os.ReadFile(path.Join(dir, files[0].Name()))
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
 	}
-
-	return sign
+	return identity.PrivateKeyFromPEM(privateKeyPEM)
 }
 
 func loadCertificate(filename string) (*x509.Certificate, error) {
@@ -0,0 +1,132 @@
+package fabric
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
+	"github.com/AryaJayadi/MedTrace_api/internal/tracing"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// unknownFunction labels calls whose chaincode function cannot be read from the request.
+const unknownFunction = "unknown"
+
+// functionKey is the context key of the chaincode function of a submitted transaction. The submit and commit
+// status requests that follow the endorsement only carry the transaction ID, so their function is read from the
+// context Submit calls the gateway with.
+type functionKey struct{}
+
+// withFunction returns ctx carrying the chaincode function of the transaction submitted with it.
+func withFunction(ctx context.Context, function string) context.Context {
+	return context.WithValue(ctx, functionKey{}, function)
+}
+
+// gatewayCall describes a call to the Fabric gateway service.
+type gatewayCall struct {
+	phase    string
+	txID     string
+	function string
+}
+
+// describeGatewayCall reads the phase, transaction ID and chaincode function of a gateway request.
+// ok is false for calls other than evaluate, endorse, submit and commit status.
+func describeGatewayCall(ctx context.Context, method string, req any) (call gatewayCall, ok bool) {
+	switch method {
+	case gateway.Gateway_Evaluate_FullMethodName:
+		r, _ := req.(*gateway.EvaluateRequest)
+		return gatewayCall{metrics.PhaseEvaluate, r.GetTransactionId(), proposalFunction(r.GetProposedTransaction())}, true
+	case gateway.Gateway_Endorse_FullMethodName:
+		r, _ := req.(*gateway.EndorseRequest)
+		return gatewayCall{metrics.PhaseEndorse, r.GetTransactionId(), proposalFunction(r.GetProposedTransaction())}, true
+	case gateway.Gateway_Submit_FullMethodName:
+		r, _ := req.(*gateway.SubmitRequest)
+		return gatewayCall{metrics.PhaseSubmit, r.GetTransactionId(), submittedFunction(ctx)}, true
+	case gateway.Gateway_CommitStatus_FullMethodName:
+		r, _ := req.(*gateway.SignedCommitStatusRequest)
+		var statusReq gateway.CommitStatusRequest
+		if err := proto.Unmarshal(r.GetRequest(), &statusReq); err != nil {
+			return gatewayCall{metrics.PhaseCommitStatus, "", submittedFunction(ctx)}, true
+		}
+		return gatewayCall{metrics.PhaseCommitStatus, statusReq.GetTransactionId(), submittedFunction(ctx)}, true
+	}
+	return gatewayCall{}, false
+}
+
+// proposalFunction reads the chaincode function, the first argument, of a signed proposal.
+func proposalFunction(signed *peer.SignedProposal) string {
+	var proposal peer.Proposal
+	if err := proto.Unmarshal(signed.GetProposalBytes(), &proposal); err != nil {
+		return unknownFunction
+	}
+	var payload peer.ChaincodeProposalPayload
+	if err := proto.Unmarshal(proposal.GetPayload(), &payload); err != nil {
+		return unknownFunction
+	}
+	var spec peer.ChaincodeInvocationSpec
+	if err := proto.Unmarshal(payload.GetInput(), &spec); err != nil {
+		return unknownFunction
+	}
+	args := spec.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) == 0 {
+		return unknownFunction
+	}
+	return string(args[0])
+}
+
+func submittedFunction(ctx context.Context) string {
+	if function, ok := ctx.Value(functionKey{}).(string); ok {
+		return function
+	}
+	return unknownFunction
+}
+
+// instrumentGateway records the duration and outcome of every gateway call of an organization as metrics and
+// as a client span in the trace of the call.
+func instrumentGateway(org string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call, ok := describeGatewayCall(ctx, method, req)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, span := tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				tracing.OrgKey.String(org),
+				tracing.FunctionKey.String(call.function),
+				tracing.TxIDKey.String(call.txID),
+				tracing.PhaseKey.String(call.phase),
+			),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		code := ""
+		if err != nil {
+			code = status.Code(err).String()
+			tracing.Fail(span, err)
+		}
+		duration := time.Since(start)
+		metrics.ObserveFabricCall(org, call.function, call.phase, duration, code)
+		if err != nil {
+			logger.WarnContext(ctx, "Gateway call failed", "phase", call.phase, "function", call.function, "duration", duration, "error", err)
+		} else {
+			logger.DebugContext(ctx, "Gateway call", "phase", call.phase, "function", call.function, "duration", duration)
+		}
+
+		if err == nil && call.phase == metrics.PhaseCommitStatus {
+			recordCommit(org, call.txID, reply)
+		}
+		return err
+	}
+}
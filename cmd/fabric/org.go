@@ -2,11 +2,13 @@ package fabric
 
 import (
 	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc"
 )
 
 type OrgSetup struct {
 	OrgName      string
 	MSPID        string
+	User         string // Enrollment ID of the identity that signs transactions, e.g. User1@org1.medtrace.com
 	CryptoPath   string
 	CertPath     string
 	KeyPath      string
@@ -14,4 +16,5 @@ type OrgSetup struct {
 	PeerEndpoint string
 	GatewayPeer  string
 	Gateway      client.Gateway
+	Connection   *grpc.ClientConn // Connection of the gateway, closed by Close
 }
@@ -0,0 +1,107 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/tracing"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Timeouts of the phases of a transaction.
+const (
+	EvaluateTimeout     = 5 * time.Second
+	EndorseTimeout      = 15 * time.Second
+	SubmitTimeout       = 5 * time.Second
+	CommitStatusTimeout = 1 * time.Minute
+)
+
+var (
+	tracer = otel.Tracer("github.com/AryaJayadi/MedTrace_api/cmd/fabric")
+	logger = logging.Logger("fabric")
+)
+
+// Evaluate evaluates a transaction function like Contract.EvaluateTransaction, in a span of the trace of ctx.
+// Only the trace is taken from ctx: the call is not cancelled with it.
+func Evaluate(ctx context.Context, contract *client.Contract, name string, args ...string) ([]byte, error) {
+	ctx, span := startTransaction(ctx, "Evaluate", contract, name)
+	defer span.End()
+
+	proposal, err := contract.NewProposal(name, client.WithArguments(args...))
+	if err != nil {
+		tracing.Fail(span, err)
+		return nil, err
+	}
+	span.SetAttributes(tracing.TxIDKey.String(proposal.TransactionID()))
+	ctx = logging.With(ctx, slog.String(logging.TxIDKey, proposal.TransactionID()))
+
+	ctx, cancel := context.WithTimeout(ctx, EvaluateTimeout)
+	defer cancel()
+	result, err := proposal.EvaluateWithContext(ctx)
+	if err != nil {
+		tracing.Fail(span, err)
+	}
+	return result, err
+}
+
+// Submit submits a transaction function like Contract.SubmitTransaction and waits for it to commit, in a span of
+// the trace of ctx. Only the trace is taken from ctx: the transaction is not abandoned between submit and commit
+// when ctx is cancelled.
+func Submit(ctx context.Context, contract *client.Contract, name string, args ...string) ([]byte, error) {
+	ctx, span := startTransaction(ctx, "Submit", contract, name)
+	defer span.End()
+
+	result, err := submit(ctx, span, contract, name, args)
+	if err != nil {
+		tracing.Fail(span, err)
+	}
+	return result, err
+}
+
+func submit(ctx context.Context, span trace.Span, contract *client.Contract, name string, args []string) ([]byte, error) {
+	proposal, err := contract.NewProposal(name, client.WithArguments(args...))
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(tracing.TxIDKey.String(proposal.TransactionID()))
+	ctx = logging.With(ctx, slog.String(logging.TxIDKey, proposal.TransactionID()))
+	ctx = withFunction(ctx, name)
+
+	endorseCtx, cancel := context.WithTimeout(ctx, EndorseTimeout)
+	transaction, err := proposal.EndorseWithContext(endorseCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, SubmitTimeout)
+	commit, err := transaction.SubmitWithContext(submitCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, CommitStatusTimeout)
+	status, err := commit.StatusWithContext(statusCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	logger.DebugContext(ctx, "Transaction committed", "function", name, "block", status.BlockNumber, "code", status.Code.String())
+	if !status.Successful {
+		return nil, fmt.Errorf("transaction %s failed to commit with status code %d (%s)", status.TransactionID, int32(status.Code), status.Code)
+	}
+	return transaction.Result(), nil
+}
+
+func startTransaction(ctx context.Context, kind string, contract *client.Contract, name string) (context.Context, trace.Span) {
+	return tracer.Start(context.WithoutCancel(ctx), kind+" "+name, trace.WithAttributes(
+		tracing.FunctionKey.String(name),
+		tracing.ChaincodeKey.String(contract.ChaincodeName()),
+	))
+}
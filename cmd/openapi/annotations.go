@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/openapi"
+)
+
+// mimeAliases are the short MIME type names accepted by @Accept and @Produce, as in swag.
+var mimeAliases = map[string]string{
+	"json":         "application/json",
+	"xml":          "application/xml",
+	"yaml":         "application/yaml",
+	"plain":        "text/plain",
+	"html":         "text/html",
+	"octet-stream": "application/octet-stream",
+	"png":          "image/png",
+	"jpeg":         "image/jpeg",
+	"pdf":          "application/pdf",
+}
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\w+)\s+(\S+)\s+(true|false)(?:\s+"(.*)")?$`)
+	responsePattern = regexp.MustCompile(`^(\d{3})(?:\s+\{(\w+)\}\s+(\S+))?(?:\s+"(.*)")?$`)
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+)
+
+// route is a method and path served by the API. Paths use the OpenAPI {param} syntax.
+type route struct {
+	method string
+	path   string
+}
+
+// handlerDoc is the documentation of one handler function.
+type handlerDoc struct {
+	name      string // Qualified function name, e.g. handlers.BatchHandler.CreateBatch
+	routes    []route
+	operation *openapi.Operation
+}
+
+// parseHandlers reads the swag-style annotations of every function in the given packages that declares a @Router.
+func parseHandlers(g *schemaGen, pkgPaths ...string) ([]*handlerDoc, error) {
+	var docs []*handlerDoc
+	for _, p := range pkgPaths {
+		pkg, ok := g.idx.byPath[p]
+		if !ok {
+			return nil, fmt.Errorf("package %s not found", p)
+		}
+		for _, file := range g.idx.files[p] {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Doc == nil {
+					continue
+				}
+				name := pkg.name + "." + fn.Name.Name
+				if fn.Recv != nil && len(fn.Recv.List) == 1 {
+					name = pkg.name + "." + receiverName(fn.Recv.List[0].Type) + "." + fn.Name.Name
+				}
+				doc, err := parseHandlerDoc(g, scope{pkg: pkg, file: file}, fn.Name.Name, fn.Doc)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				if doc == nil {
+					continue
+				}
+				doc.name = name
+				docs = append(docs, doc)
+			}
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].name < docs[j].name })
+	return docs, nil
+}
+
+// parseHandlerDoc builds the operation described by a doc comment, or returns nil when it has no @Router.
+func parseHandlerDoc(g *schemaGen, sc scope, funcName string, comments *ast.CommentGroup) (*handlerDoc, error) {
+	doc := &handlerDoc{operation: &openapi.Operation{OperationID: funcName, Responses: map[string]*openapi.Response{}}}
+	op := doc.operation
+	var accept, produce []string
+	type bodyParam struct {
+		typ         string
+		required    bool
+		description string
+	}
+	var body *bodyParam
+	type responseLine struct {
+		code, kind, typ, description string
+	}
+	var responses []responseLine
+	var descriptions []string
+
+	for _, c := range comments.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		attr, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(attr) {
+		case "@summary":
+			op.Summary = value
+		case "@description":
+			descriptions = append(descriptions, value)
+		case "@tags":
+			for _, tag := range strings.Split(value, ",") {
+				op.Tags = append(op.Tags, strings.TrimSpace(tag))
+			}
+		case "@accept":
+			accept = append(accept, mimeTypes(value)...)
+		case "@produce":
+			produce = append(produce, mimeTypes(value)...)
+		case "@param":
+			m := paramPattern.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("malformed @Param %q", value)
+			}
+			name, in, typ, required, description := m[1], m[2], m[3], m[4] == "true", m[5]
+			if in == "body" {
+				body = &bodyParam{typ: typ, required: required, description: description}
+				continue
+			}
+			if in != "path" && in != "query" && in != "header" {
+				return nil, fmt.Errorf("unsupported parameter location %q of %s", in, name)
+			}
+			schema, ok := basicSchema(typ)
+			if !ok {
+				if typ != "integer" && typ != "number" && typ != "boolean" {
+					return nil, fmt.Errorf("unsupported type %q of parameter %s", typ, name)
+				}
+				schema = &openapi.Schema{Type: typ}
+			}
+			op.Parameters = append(op.Parameters, openapi.Parameter{Name: name, In: in, Description: description, Required: required || in == "path", Schema: schema})
+		case "@success", "@failure":
+			m := responsePattern.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("malformed %s %q", attr, value)
+			}
+			responses = append(responses, responseLine{code: m[1], kind: m[2], typ: m[3], description: m[4]})
+		case "@router":
+			m := routerPattern.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("malformed @Router %q", value)
+			}
+			doc.routes = append(doc.routes, route{method: strings.ToUpper(m[2]), path: m[1]})
+		}
+	}
+	if len(doc.routes) == 0 {
+		return nil, nil
+	}
+	op.Description = strings.Join(descriptions, "\n")
+
+	if body != nil {
+		if len(accept) == 0 {
+			accept = []string{"application/json"}
+		}
+		schema, err := g.typeSchema(body.typ, sc)
+		if err != nil {
+			return nil, fmt.Errorf("body: %w", err)
+		}
+		op.RequestBody = &openapi.RequestBody{Description: body.description, Required: body.required, Content: content(accept, schema)}
+	}
+
+	for _, r := range responses {
+		resp := &openapi.Response{Description: r.description}
+		code, _ := strconv.Atoi(r.code)
+		if resp.Description == "" {
+			resp.Description = http.StatusText(code)
+		}
+		switch r.kind {
+		case "":
+		case "file":
+			types := produce
+			if len(types) == 0 {
+				types = []string{"application/octet-stream"}
+			}
+			resp.Content = content(types, nil)
+		case "object", "array":
+			schema, err := g.typeSchema(r.typ, sc)
+			if err != nil {
+				return nil, fmt.Errorf("response %s: %w", r.code, err)
+			}
+			if r.kind == "array" {
+				schema = &openapi.Schema{Type: "array", Items: schema}
+			}
+			if code >= 400 {
+				// Errors are negotiated between the envelope and RFC 9457 problem details.
+				resp.Content = map[string]openapi.MediaType{
+					"application/json":         {Schema: schema},
+					"application/problem+json": {Schema: refTo("response.Problem")},
+				}
+			} else {
+				types := produce
+				if len(types) == 0 {
+					types = []string{"application/json"}
+				}
+				resp.Content = content(types, schema)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported response kind {%s}", r.kind)
+		}
+		op.Responses[r.code] = resp
+	}
+	if len(op.Responses) == 0 {
+		return nil, fmt.Errorf("no @Success or @Failure responses")
+	}
+	return doc, nil
+}
+
+// typeSchema parses a type named in an annotation, e.g. response.BaseValueResponse[entity.Batch].
+func (g *schemaGen) typeSchema(typ string, sc scope) (*openapi.Schema, error) {
+	expr, err := parser.ParseExpr(typ)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %w", typ, err)
+	}
+	return g.schemaFor(expr, sc)
+}
+
+// content lists a body schema under each media type. Bodies that are not JSON are described as strings of
+// that media type; a nil schema describes a file of any of the media types.
+func content(mimes []string, schema *openapi.Schema) map[string]openapi.MediaType {
+	c := make(map[string]openapi.MediaType, len(mimes))
+	for _, mime := range mimes {
+		if schema != nil && isJSON(mime) {
+			c[mime] = openapi.MediaType{Schema: schema}
+			continue
+		}
+		c[mime] = openapi.MediaType{Schema: &openapi.Schema{Type: "string", ContentMediaType: mime}}
+	}
+	return c
+}
+
+func isJSON(mime string) bool {
+	return mime == "application/json" || strings.HasSuffix(mime, "+json")
+}
+
+func mimeTypes(value string) []string {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if alias, ok := mimeAliases[t]; ok {
+			t = alias
+		}
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// generalInfo reads the @title, @version and @description annotations of a doc comment.
+func generalInfo(comments *ast.CommentGroup) openapi.Info {
+	var info openapi.Info
+	var descriptions []string
+	if comments == nil {
+		return info
+	}
+	for _, c := range comments.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		attr, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(attr) {
+		case "@title":
+			info.Title = value
+		case "@version":
+			info.Version = value
+		case "@description":
+			descriptions = append(descriptions, value)
+		}
+	}
+	info.Description = strings.Join(descriptions, "\n")
+	return info
+}
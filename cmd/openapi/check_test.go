@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSpecMatchesServer fails when a route of cmd/server is not documented, when an annotation names a route
+// the server does not serve, or when internal/openapi/openapi.json is not the document the sources generate.
+func TestSpecMatchesServer(t *testing.T) {
+	root := filepath.Join("..", "..")
+	spec, problems, err := generate(root)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+
+	file := filepath.Join(root, "internal", "openapi", "openapi.json")
+	existing, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read %s: %v", file, err)
+	}
+	if !bytes.Equal(existing, spec) {
+		t.Errorf("%s is out of date; run go generate ./internal/openapi", file)
+	}
+}
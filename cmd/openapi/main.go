@@ -0,0 +1,201 @@
+// Command openapi generates the OpenAPI 3.1 document of the MedTrace API from the swag-style annotations of the
+// handlers and the request and response types they name, and checks it against the routes of cmd/server.
+//
+//	go generate ./internal/openapi         # regenerate internal/openapi/openapi.json
+//	go run ./cmd/openapi -check -o internal/openapi/openapi.json
+//
+// It exits with status 1 when a route of the server is not documented, when an annotation names a route the
+// server does not serve, or, with -check, when the document on disk is not the one the sources generate.
+// go test ./cmd/openapi runs the same checks.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/openapi"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the module")
+	out := flag.String("o", "", "file to write the document to; stdout when empty")
+	check := flag.Bool("check", false, "compare the document with the file given by -o instead of writing it")
+	flag.Parse()
+	if *check && *out == "" {
+		log.Fatal("-check requires -o")
+	}
+
+	spec, problems, err := generate(*root)
+	if err != nil {
+		log.Fatalf("Failed to generate the OpenAPI document: %v", err)
+	}
+	if *check {
+		existing, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *out, err)
+		}
+		if !bytes.Equal(existing, spec) {
+			problems = append(problems, fmt.Sprintf("%s is out of date; run go generate ./internal/openapi", *out))
+		}
+	}
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		os.Exit(1)
+	}
+	if *check {
+		return
+	}
+	if *out == "" {
+		os.Stdout.Write(spec)
+		return
+	}
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
+
+// generate builds the document of the module at root and lists where its annotations and the server's routes disagree.
+func generate(root string) ([]byte, []string, error) {
+	module, err := modulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, nil, err
+	}
+	idx, err := loadPackages(root, module, "internal", "pkg")
+	if err != nil {
+		return nil, nil, err
+	}
+	g := &schemaGen{idx: idx, schemas: map[string]*openapi.Schema{}}
+	// The error envelope and problem details are referenced by every failure response.
+	responsePkg := idx.byPath[module+"/internal/models/response"]
+	if responsePkg == nil {
+		return nil, nil, fmt.Errorf("package %s/internal/models/response not found", module)
+	}
+	for _, name := range []string{"response.BaseResponse", "response.Problem"} {
+		if _, err := g.typeSchema(name, scope{pkg: responsePkg}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	mainFunc, served, err := parseServerRoutes(filepath.Join(root, "cmd", "server", "main.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	handlers, err := parseHandlers(g, module+"/internal/handlers", module+"/internal/auth")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := openapi.Document{
+		OpenAPI: openapi.Version,
+		Info:    generalInfo(mainFunc.Doc),
+		Paths:   map[string]map[string]*openapi.Operation{},
+		Components: openapi.Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	servedByKey := map[string]serverRoute{}
+	for _, r := range served {
+		servedByKey[routeKey(r.route)] = r
+	}
+	var problems []string
+	documented := map[string]string{}
+	for _, h := range handlers {
+		for i, r := range h.routes {
+			key := routeKey(r)
+			if other, ok := documented[key]; ok {
+				problems = append(problems, fmt.Sprintf("%s: route %s is also documented by %s", h.name, key, other))
+				continue
+			}
+			documented[key] = h.name
+			s, ok := servedByKey[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: route %s %s is not served by cmd/server", h.name, r.method, r.path))
+			}
+			problems = append(problems, checkPathParams(h, r)...)
+
+			op := *h.operation
+			if i > 0 {
+				op.OperationID += strconv.Itoa(i + 1)
+			}
+			// Whether a route needs a JWT is decided by its middleware, whatever the annotations say.
+			if s.authenticated {
+				op.Security = []map[string][]string{{"BearerAuth": {}}}
+			}
+			if doc.Paths[r.path] == nil {
+				doc.Paths[r.path] = map[string]*openapi.Operation{}
+			}
+			doc.Paths[r.path][strings.ToLower(r.method)] = &op
+		}
+	}
+	for _, r := range served {
+		if _, ok := documented[routeKey(r.route)]; !ok {
+			problems = append(problems, fmt.Sprintf("route %s %s (%s) has no handler annotated with @Router", r.method, r.path, r.handler))
+		}
+	}
+	sort.Strings(problems)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), problems, nil
+}
+
+// checkPathParams reports path parameters of a route that are not declared with @Param, and the reverse.
+func checkPathParams(h *handlerDoc, r route) []string {
+	declared := map[string]bool{}
+	for _, p := range h.operation.Parameters {
+		if p.In == "path" {
+			declared[p.Name] = true
+		}
+	}
+	var problems []string
+	for _, name := range pathParams(r.path) {
+		if !declared[name] {
+			problems = append(problems, fmt.Sprintf("%s: path parameter %s of %s has no @Param", h.name, name, r.path))
+		}
+		delete(declared, name)
+	}
+	if len(h.routes) == 1 {
+		for name := range declared {
+			problems = append(problems, fmt.Sprintf("%s: @Param %s is not a parameter of %s", h.name, name, r.path))
+		}
+	}
+	return problems
+}
+
+func modulePath(gomod string) (string, error) {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s declares no module", gomod)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// serverRoute is a route registered by the server, with whether it requires a JWT.
+type serverRoute struct {
+	route
+	handler       string
+	authenticated bool
+}
+
+var routeMethods = map[string]bool{"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true}
+
+// parseServerRoutes reads the routes registered in the main function of a server: calls of e.GET, e.POST, ...
+// on the Echo instance and on groups created with e.Group, along with the middleware of each route and group.
+func parseServerRoutes(filename string) (*ast.FuncDecl, []serverRoute, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	var mainFunc *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			mainFunc = fn
+		}
+	}
+	if mainFunc == nil {
+		return nil, nil, fmt.Errorf("%s has no main function", filename)
+	}
+
+	type group struct {
+		prefix     string
+		middleware []ast.Expr
+	}
+	groups := map[string]group{}
+	// groupOf returns the group a call is made on; the Echo instance is the group without a prefix.
+	groupOf := func(x ast.Expr) group {
+		if id, ok := x.(*ast.Ident); ok {
+			return groups[id.Name]
+		}
+		return group{}
+	}
+
+	var routes []serverRoute
+	var inspectErr error
+	ast.Inspect(mainFunc.Body, func(n ast.Node) bool {
+		if inspectErr != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				return true
+			}
+			call, sel := methodCall(n.Rhs[0])
+			id, ok := n.Lhs[0].(*ast.Ident)
+			if call == nil || !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+				return true
+			}
+			prefix, err := stringLit(call.Args[0])
+			if err != nil {
+				inspectErr = err
+				return false
+			}
+			parent := groupOf(sel.X)
+			groups[id.Name] = group{
+				prefix:     parent.prefix + prefix,
+				middleware: append(append([]ast.Expr{}, parent.middleware...), call.Args[1:]...),
+			}
+			return false
+		case *ast.CallExpr:
+			call, sel := methodCall(n)
+			if call == nil || !routeMethods[sel.Sel.Name] || len(call.Args) < 2 {
+				return true
+			}
+			p, err := stringLit(call.Args[0])
+			if err != nil {
+				inspectErr = err
+				return false
+			}
+			g := groupOf(sel.X)
+			r := serverRoute{route: route{method: sel.Sel.Name, path: echoToOpenAPI(g.prefix + p)}, handler: exprString(call.Args[1])}
+			for _, mw := range append(append([]ast.Expr{}, g.middleware...), call.Args[2:]...) {
+				if exprString(mw) == "auth.AuthMiddleware" {
+					r.authenticated = true
+				}
+			}
+			routes = append(routes, r)
+			return false
+		}
+		return true
+	})
+	return mainFunc, routes, inspectErr
+}
+
+func methodCall(expr ast.Expr) (*ast.CallExpr, *ast.SelectorExpr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	return call, sel
+}
+
+func stringLit(expr ast.Expr) (string, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("route path %s is not a string literal", exprString(expr))
+	}
+	return strconv.Unquote(lit.Value)
+}
+
+var echoParam = regexp.MustCompile(`:([^/]+)`)
+
+// echoToOpenAPI turns an Echo path into an OpenAPI path: ":id" becomes "{id}" and a trailing "*" becomes "{*}".
+func echoToOpenAPI(p string) string {
+	p = echoParam.ReplaceAllString(p, "{$1}")
+	if strings.HasSuffix(p, "*") {
+		p = strings.TrimSuffix(p, "*") + "{*}"
+	}
+	return p
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// routeKey identifies a route regardless of the names of its path parameters.
+func routeKey(r route) string {
+	return r.method + " " + pathParam.ReplaceAllString(r.path, "{}")
+}
+
+// pathParams lists the names of the parameters of an OpenAPI path.
+func pathParams(p string) []string {
+	var names []string
+	for _, m := range pathParam.FindAllString(p, -1) {
+		names = append(names, strings.Trim(m, "{}"))
+	}
+	return names
+}
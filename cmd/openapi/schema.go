@@ -0,0 +1,502 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/openapi"
+)
+
+// pkgInfo is a parsed package of the module.
+type pkgInfo struct {
+	path    string
+	name    string
+	types   map[string]*typeDecl
+	methods map[string]map[string]bool // Methods by receiver type name
+}
+
+// typeDecl is a type declaration and the file it was declared in, for resolving its imports.
+type typeDecl struct {
+	pkg  *pkgInfo
+	file *ast.File
+	spec *ast.TypeSpec
+}
+
+// scope resolves identifiers within one file.
+type scope struct {
+	pkg    *pkgInfo
+	file   *ast.File
+	params map[string]*openapi.Schema // Type parameters of a generic type being instantiated
+}
+
+// typeIndex holds the type declarations of every package of the module.
+type typeIndex struct {
+	module string
+	byPath map[string]*pkgInfo
+	byName map[string][]*pkgInfo
+	files  map[string][]*ast.File // Parsed files by package import path
+}
+
+// loadPackages parses the non-test Go files under the given directories of the module rooted at root.
+func loadPackages(root, module string, dirs ...string) (*typeIndex, error) {
+	idx := &typeIndex{module: module, byPath: map[string]*pkgInfo{}, byName: map[string][]*pkgInfo{}, files: map[string][]*ast.File{}}
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, filepath.Dir(p))
+			if err != nil {
+				return err
+			}
+			importPath := path.Join(module, filepath.ToSlash(rel))
+			pkg := idx.byPath[importPath]
+			if pkg == nil {
+				pkg = &pkgInfo{path: importPath, name: file.Name.Name, types: map[string]*typeDecl{}, methods: map[string]map[string]bool{}}
+				idx.byPath[importPath] = pkg
+				idx.byName[pkg.name] = append(idx.byName[pkg.name], pkg)
+			}
+			idx.files[importPath] = append(idx.files[importPath], file)
+			for _, decl := range file.Decls {
+				switch decl := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							pkg.types[ts.Name.Name] = &typeDecl{pkg: pkg, file: file, spec: ts}
+						}
+					}
+				case *ast.FuncDecl:
+					if decl.Recv != nil && len(decl.Recv.List) == 1 {
+						recv := receiverName(decl.Recv.List[0].Type)
+						if pkg.methods[recv] == nil {
+							pkg.methods[recv] = map[string]bool{}
+						}
+						pkg.methods[recv][decl.Name.Name] = true
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// importPath resolves a package name used in a file to an import path.
+// Names the file does not import are looked up among the module's packages, as swag does.
+func (idx *typeIndex) importPath(file *ast.File, name string) (string, bool) {
+	if file != nil {
+		for _, imp := range file.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			local := path.Base(p)
+			if pkg, ok := idx.byPath[p]; ok {
+				local = pkg.name
+			}
+			if imp.Name != nil {
+				local = imp.Name.Name
+			}
+			if local == name {
+				return p, true
+			}
+		}
+	}
+	if pkgs := idx.byName[name]; len(pkgs) == 1 {
+		return pkgs[0].path, true
+	}
+	return "", false
+}
+
+// schemaGen converts Go types to JSON schemas, collecting named types as components.
+type schemaGen struct {
+	idx     *typeIndex
+	schemas map[string]*openapi.Schema
+}
+
+func refTo(name string) *openapi.Schema {
+	return &openapi.Schema{Ref: "#/components/schemas/" + name}
+}
+
+// schemaFor returns the schema of a type expression.
+func (g *schemaGen) schemaFor(expr ast.Expr, sc scope) (*openapi.Schema, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if s, ok := sc.params[e.Name]; ok {
+			return s, nil
+		}
+		if s, ok := basicSchema(e.Name); ok {
+			return s, nil
+		}
+		if decl, ok := sc.pkg.types[e.Name]; ok {
+			return g.named(decl, nil, sc)
+		}
+		return nil, fmt.Errorf("unknown type %s in package %s", e.Name, sc.pkg.name)
+	case *ast.StarExpr:
+		return g.schemaFor(e.X, sc)
+	case *ast.ArrayType:
+		if id, ok := e.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return &openapi.Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.schemaFor(e.Elt, sc)
+		if err != nil {
+			return nil, err
+		}
+		return &openapi.Schema{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		values, err := g.schemaFor(e.Value, sc)
+		if err != nil {
+			return nil, err
+		}
+		return &openapi.Schema{Type: "object", AdditionalProperties: values}, nil
+	case *ast.InterfaceType:
+		return &openapi.Schema{}, nil
+	case *ast.StructType:
+		return g.structSchema(e, sc)
+	case *ast.SelectorExpr:
+		pkgName, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", exprString(e))
+		}
+		if s, ok := externalSchema(pkgName.Name + "." + e.Sel.Name); ok {
+			return s, nil
+		}
+		decl, err := g.lookup(sc.file, pkgName.Name, e.Sel.Name)
+		if err != nil {
+			return nil, err
+		}
+		return g.named(decl, nil, sc)
+	case *ast.IndexExpr:
+		return g.instantiate(e.X, []ast.Expr{e.Index}, sc)
+	case *ast.IndexListExpr:
+		return g.instantiate(e.X, e.Indices, sc)
+	}
+	return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+}
+
+func (g *schemaGen) lookup(file *ast.File, pkgName, typeName string) (*typeDecl, error) {
+	p, ok := g.idx.importPath(file, pkgName)
+	if !ok {
+		return nil, fmt.Errorf("unknown package %s", pkgName)
+	}
+	pkg, ok := g.idx.byPath[p]
+	if !ok {
+		return nil, fmt.Errorf("package %s is not part of the module", p)
+	}
+	decl, ok := pkg.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s.%s", pkgName, typeName)
+	}
+	return decl, nil
+}
+
+// instantiate returns the schema of a generic type with its type arguments.
+func (g *schemaGen) instantiate(generic ast.Expr, args []ast.Expr, sc scope) (*openapi.Schema, error) {
+	var decl *typeDecl
+	switch e := generic.(type) {
+	case *ast.Ident:
+		decl = sc.pkg.types[e.Name]
+		if decl == nil {
+			return nil, fmt.Errorf("unknown generic type %s", e.Name)
+		}
+	case *ast.SelectorExpr:
+		pkgName, _ := e.X.(*ast.Ident)
+		if pkgName == nil {
+			return nil, fmt.Errorf("unsupported generic type %s", exprString(e))
+		}
+		var err error
+		if decl, err = g.lookup(sc.file, pkgName.Name, e.Sel.Name); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported generic type %s", exprString(generic))
+	}
+	return g.named(decl, args, sc)
+}
+
+// named returns a reference to the component of a named type, creating the component on first use.
+// Aliases resolve to the aliased type. Generic types get one component per list of type arguments.
+func (g *schemaGen) named(decl *typeDecl, args []ast.Expr, argScope scope) (*openapi.Schema, error) {
+	declScope := scope{pkg: decl.pkg, file: decl.file}
+	if decl.spec.Assign.IsValid() {
+		return g.schemaFor(decl.spec.Type, declScope)
+	}
+
+	name := decl.pkg.name + "." + decl.spec.Name.Name
+	if len(args) > 0 {
+		params := decl.spec.TypeParams
+		if params == nil || params.NumFields() != len(args) {
+			return nil, fmt.Errorf("%s takes %d type arguments", name, params.NumFields())
+		}
+		declScope.params = map[string]*openapi.Schema{}
+		i := 0
+		for _, field := range params.List {
+			for _, id := range field.Names {
+				arg, err := g.schemaFor(args[i], argScope)
+				if err != nil {
+					return nil, err
+				}
+				declScope.params[id.Name] = arg
+				name += "-" + g.qualifiedName(args[i], argScope)
+				i++
+			}
+		}
+	}
+	if _, ok := g.schemas[name]; ok {
+		return refTo(name), nil
+	}
+
+	if decl.pkg.methods[decl.spec.Name.Name]["MarshalJSON"] {
+		g.schemas[name] = customJSONSchema(name)
+		return refTo(name), nil
+	}
+	// Register a placeholder first so that recursive types end in a reference.
+	s := &openapi.Schema{}
+	g.schemas[name] = s
+	built, err := g.schemaFor(decl.spec.Type, declScope)
+	if err != nil {
+		return nil, err
+	}
+	*s = *built
+	if s.Description == "" && decl.spec.Doc != nil {
+		s.Description = strings.TrimSpace(decl.spec.Doc.Text())
+	}
+	return refTo(name), nil
+}
+
+// qualifiedName names a type argument for a component name, e.g. "entity.Batch" or "string".
+func (g *schemaGen) qualifiedName(expr ast.Expr, sc scope) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if _, ok := sc.pkg.types[e.Name]; ok {
+			return sc.pkg.name + "." + e.Name
+		}
+		return e.Name
+	case *ast.StarExpr:
+		return g.qualifiedName(e.X, sc)
+	case *ast.ArrayType:
+		return "array." + g.qualifiedName(e.Elt, sc)
+	}
+	return strings.NewReplacer("[", "-", "]", "", " ", "", "*", "").Replace(exprString(expr))
+}
+
+// structSchema builds an object schema from the JSON names of a struct's exported fields.
+// Fields of embedded structs without a JSON name are promoted, as encoding/json does.
+func (g *schemaGen) structSchema(st *ast.StructType, sc scope) (*openapi.Schema, error) {
+	s := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(unquoted)
+		}
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			embedded, err := g.schemaFor(field.Type, sc)
+			if err != nil {
+				return nil, err
+			}
+			if embedded.Ref != "" {
+				embedded = g.schemas[strings.TrimPrefix(embedded.Ref, "#/components/schemas/")]
+			}
+			for name, prop := range embedded.Properties {
+				s.Properties[name] = prop
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(receiverName(field.Type))}
+		}
+		for _, id := range names {
+			if !id.IsExported() {
+				continue
+			}
+			prop, err := g.schemaFor(field.Type, sc)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", id.Name, err)
+			}
+			propName := jsonName
+			if propName == "" {
+				propName = id.Name
+			}
+			// Copy so that constraints and descriptions do not leak into shared schemas.
+			cp := *prop
+			prop = &cp
+			if field.Comment != nil {
+				prop.Description = strings.TrimSpace(field.Comment.Text())
+			} else if field.Doc != nil {
+				prop.Description = strings.TrimSpace(field.Doc.Text())
+			}
+			if applyRules(prop, tag.Get("validate")) {
+				s.Required = append(s.Required, propName)
+			}
+			s.Properties[propName] = prop
+		}
+	}
+	sort.Strings(s.Required)
+	return s, nil
+}
+
+// applyRules adds the constraints of a validate tag to a property schema and reports whether it is required.
+func applyRules(s *openapi.Schema, rules string) bool {
+	if rules == "" {
+		return false
+	}
+	required := false
+	target := s
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			if target.Items == nil {
+				break
+			}
+			items := *target.Items
+			target.Items = &items
+			target = &items
+			continue
+		}
+		if target.Ref != "" {
+			if name == "required" && target == s {
+				required = true
+			}
+			continue
+		}
+		n, numErr := strconv.ParseFloat(param, 64)
+		isArray := target.Type == "array"
+		isNumber := target.Type == "integer" || target.Type == "number"
+		switch name {
+		case "required":
+			if target == s {
+				required = true
+			}
+		case "gt":
+			if isNumber && numErr == nil {
+				target.ExclusiveMinimum = &n
+			}
+		case "gte", "min":
+			if isNumber && numErr == nil {
+				target.Minimum = &n
+			} else if isArray && numErr == nil {
+				m := int(n)
+				target.MinItems = &m
+			}
+		case "lte", "max":
+			if isNumber && numErr == nil {
+				target.Maximum = &n
+			} else if isArray && numErr == nil {
+				m := int(n)
+				target.MaxItems = &m
+			}
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				target.Enum = append(target.Enum, v)
+			}
+		case "unique":
+			target.UniqueItems = true
+		}
+	}
+	return required
+}
+
+func basicSchema(name string) (*openapi.Schema, bool) {
+	switch name {
+	case "string":
+		return &openapi.Schema{Type: "string"}, true
+	case "bool":
+		return &openapi.Schema{Type: "boolean"}, true
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "byte", "rune":
+		return &openapi.Schema{Type: "integer"}, true
+	case "int64", "uint64":
+		return &openapi.Schema{Type: "integer", Format: "int64"}, true
+	case "float32", "float64":
+		return &openapi.Schema{Type: "number"}, true
+	case "any", "error":
+		return &openapi.Schema{}, true
+	}
+	return nil, false
+}
+
+// externalSchema maps the types of other modules and of the standard library that appear in the API.
+func externalSchema(name string) (*openapi.Schema, bool) {
+	switch name {
+	case "time.Time":
+		return &openapi.Schema{Type: "string", Format: "date-time"}, true
+	case "time.Duration":
+		return &openapi.Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}, true
+	case "json.RawMessage":
+		return &openapi.Schema{}, true
+	}
+	return nil, false
+}
+
+// customJSONSchema describes types that implement json.Marshaler.
+func customJSONSchema(name string) *openapi.Schema {
+	if name == "utils.OptionalTime" {
+		return &openapi.Schema{Type: []string{"string", "null"}, Format: "date-time"}
+	}
+	return &openapi.Schema{Description: name + " has a custom JSON encoding"}
+}
+
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(e.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(e.Key) + "]" + exprString(e.Value)
+	case *ast.IndexExpr:
+		return exprString(e.X) + "[" + exprString(e.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(e.Indices))
+		for i, a := range e.Indices {
+			args[i] = exprString(a)
+		}
+		return exprString(e.X) + "[" + strings.Join(args, ",") + "]"
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return fmt.Sprintf("%T", expr)
+}
@@ -1,29 +1,81 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/graph"
+	"github.com/AryaJayadi/MedTrace_api/internal/grpcapi"
 	"github.com/AryaJayadi/MedTrace_api/internal/handlers"
+	"github.com/AryaJayadi/MedTrace_api/internal/idempotency"
+	"github.com/AryaJayadi/MedTrace_api/internal/jobs"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
+	"github.com/AryaJayadi/MedTrace_api/internal/openapi"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/tracing"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// shutdownTimeout bounds how long in-flight requests may run after SIGINT or SIGTERM.
+const shutdownTimeout = 30 * time.Second
+
+// @title MedTrace API
+// @version 1.0
+// @description Drug traceability on Hyperledger Fabric: organizations, batches, serialized drugs and their transfers,
+// @description with GS1 identifiers, labels, EPCIS exchange and verifiable ledger history.
 func main() {
 	err := godotenv.Load()
+
+	logLevel := os.Getenv("LOG_LEVEL")
+	if logLevel == "" {
+		logLevel = "info"
+	}
+	// LOG_LEVELS sets the levels of individual packages, e.g. "fabric=debug,http=warn".
+	if errLog := logging.Setup(logLevel, os.Getenv("LOG_LEVELS")); errLog != nil {
+		// Logging is not set up, so report the error in JSON on stderr.
+		slog.New(slog.NewJSONHandler(os.Stderr, nil)).Error("Invalid LOG_LEVEL or LOG_LEVELS", "error", errLog)
+		os.Exit(1)
+	}
 	if err != nil {
-		log.Println("Info: Error loading .env file (this is not fatal if environment variables are set directly):", err)
+		slog.Info("Error loading .env file (this is not fatal if environment variables are set directly)", "error", err)
 	} else {
-		log.Println("Successfully loaded .env file")
+		slog.Info("Successfully loaded .env file")
+	}
+
+	tracesExporter := os.Getenv("OTEL_TRACES_EXPORTER")
+	if tracesExporter == "" {
+		slog.Info("OTEL_TRACES_EXPORTER not set in environment, spans are not exported. Set it to otlp or console to export them.")
+		tracesExporter = tracing.ExporterNone
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), tracesExporter)
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
 	}
 
 	e := echo.New()
-	e.Use(middleware.Logger())
+	e.Validator = validation.New()
+	e.Binder = &validation.Binder{}
+	e.HTTPErrorHandler = handlers.ErrorHandler
+	e.Use(middleware.RequestID())
+	e.Use(logging.Middleware)
+	e.Use(tracing.Middleware)
+	e.Use(metrics.Middleware)
 	e.Use(middleware.Recover())
 
 	allowedOriginsEnv := os.Getenv("ALLOWED_ORIGINS")
@@ -32,33 +84,142 @@ func main() {
 		allowedOrigins = append(allowedOrigins, strings.Split(allowedOriginsEnv, ",")...)
 	} else {
 		allowedOrigins = []string{"http://localhost:5173"}
-		log.Println("ALLOWED_ORIGINS not set in environment, using default:", allowedOrigins)
+		slog.Info("ALLOWED_ORIGINS not set in environment, using default", "origins", allowedOrigins)
 	}
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: allowedOrigins,
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowOrigins:  allowedOrigins,
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, echo.HeaderXRequestID, idempotency.HeaderKey, "traceparent", "tracestate"},
+		ExposeHeaders: []string{echo.HeaderXRequestID, idempotency.HeaderReplayed},
 	}))
 
 	chaincodeName := os.Getenv("CHAINCODE_NAME")
 	if chaincodeName == "" {
-		log.Println("CHAINCODE_NAME not set in environment, using default from auth package.")
+		slog.Info("CHAINCODE_NAME not set in environment, using default from auth package.")
 		chaincodeName = auth.DefaultChaincodeName
 	}
 
 	channelName := os.Getenv("CHANNEL_NAME")
 	if channelName == "" {
-		log.Println("CHANNEL_NAME not set in environment, using default from auth package.")
+		slog.Info("CHANNEL_NAME not set in environment, using default from auth package.")
 		channelName = auth.DefaultChannelName
 	}
-	log.Printf("Using Chaincode: %s, Channel: %s", chaincodeName, channelName)
+	slog.Info("Using chaincode", "chaincode", chaincodeName, "channel", channelName)
+
+	orgRegistryFile := os.Getenv("ORG_REGISTRY_FILE")
+	if orgRegistryFile == "" {
+		orgRegistryFile = config.DefaultOrgRegistryFile
+	}
+	if err := config.LoadRegistry(orgRegistryFile); err != nil {
+		fatal("Failed to load registered organizations", "file", orgRegistryFile, "error", err)
+	}
+
+	adminOrgsEnv := os.Getenv("ADMIN_ORGS")
+	if adminOrgsEnv == "" {
+		slog.Info("ADMIN_ORGS not set in environment, organization management endpoints are disabled.")
+	}
+	auth.SetAdminOrgs(strings.Split(adminOrgsEnv, ","))
+
+	partnersFile := os.Getenv("TRADING_PARTNERS_FILE")
+	if partnersFile == "" {
+		partnersFile = services.DefaultPartnersFile
+	}
+
+	appMode := os.Getenv("APP_MODE")
+	if appMode == "" {
+		appMode = "production"
+	}
+	ledgerInitEnabled := appMode == "development"
+	if !ledgerInitEnabled {
+		slog.Info("Ledger initialization is disabled. Set APP_MODE=development to enable it.", "app_mode", appMode)
+	}
+	ledgerSeedFile := os.Getenv("LEDGER_SEED_FILE")
+	ledgerAuditFile := os.Getenv("LEDGER_INIT_AUDIT_FILE")
+	if ledgerAuditFile == "" {
+		ledgerAuditFile = services.DefaultLedgerAuditFile
+	}
+
+	idempotencyTTL := idempotency.DefaultTTL
+	if ttlEnv := os.Getenv("IDEMPOTENCY_KEY_TTL"); ttlEnv != "" {
+		idempotencyTTL, err = time.ParseDuration(ttlEnv)
+		if err != nil || idempotencyTTL <= 0 {
+			fatal("Invalid IDEMPOTENCY_KEY_TTL: must be a positive duration", "value", ttlEnv)
+		}
+	}
+	idempotencyStore := idempotency.NewStore(idempotencyTTL)
 
 	// Services are instantiated without a contract. The contract will be passed per method.
 	organizationService := services.NewOrganizationService() // Adjusted constructor
 	batchService := services.NewBatchService()               // Adjusted constructor
 	drugService := services.NewDrugService()                 // Adjusted constructor
-	transferService := services.NewTransferService()         // Adjusted constructor
-	ledgerService := services.NewLedgerService()             // Adjusted constructor
+	partnerService := services.NewPartnerService(organizationService, partnersFile)
+	transferService := services.NewTransferService(partnerService)
+	ledgerService := services.NewLedgerService(organizationService, batchService, drugService, ledgerInitEnabled, ledgerSeedFile, ledgerAuditFile, auth.NewContractForOrg)
+	integrityService := services.NewIntegrityService()
+	certExpiryWarning := services.DefaultCertExpiryWarning
+	if warningEnv := os.Getenv("CERT_EXPIRY_WARNING"); warningEnv != "" {
+		certExpiryWarning, err = time.ParseDuration(warningEnv)
+		if err != nil || certExpiryWarning <= 0 {
+			fatal("Invalid CERT_EXPIRY_WARNING: must be a positive duration", "value", warningEnv)
+		}
+	}
+	certificateService := services.NewCertificateService(certExpiryWarning)
+	healthService := services.NewHealthService(auth.NewNetworkForOrg, certificateService)
+
+	resolverBase := os.Getenv("GS1_RESOLVER_BASE_URL")
+	if resolverBase == "" {
+		resolverBase = "http://localhost:8080"
+		slog.Info("GS1_RESOLVER_BASE_URL not set in environment, using default", "url", resolverBase)
+	}
+	labelService := services.NewLabelService(drugService, batchService, resolverBase)
+	epcisService := services.NewEPCISService(drugService, batchService, transferService)
+
+	t3Dir := os.Getenv("T3_DOCUMENTS_DIR")
+	if t3Dir == "" {
+		t3Dir = services.DefaultT3Dir
+	}
+	t3RetentionYears := services.DefaultT3RetentionYears
+	if retentionEnv := os.Getenv("T3_RETENTION_YEARS"); retentionEnv != "" {
+		t3RetentionYears, err = strconv.Atoi(retentionEnv)
+		if err != nil || t3RetentionYears <= 0 {
+			fatal("Invalid T3_RETENTION_YEARS: must be a positive number of years", "value", retentionEnv)
+		}
+	}
+	provenanceService := services.NewProvenanceService(drugService, batchService, transferService, organizationService)
+
+	t3Service := services.NewT3Service(drugService, batchService, transferService, organizationService, t3Dir, t3RetentionYears)
+
+	serializationJobsFile := os.Getenv("SERIALIZATION_JOBS_FILE")
+	if serializationJobsFile == "" {
+		serializationJobsFile = jobs.DefaultSerializationJobsFile
+	}
+	serializationRunner, err := jobs.NewSerializationRunner(drugService, serializationJobsFile)
+	if err != nil {
+		fatal("Failed to load serialization jobs", "file", serializationJobsFile, "error", err)
+	}
+
+	graphLimits := graph.DefaultLimits
+	if depthEnv := os.Getenv("GRAPHQL_MAX_DEPTH"); depthEnv != "" {
+		graphLimits.MaxDepth, err = strconv.Atoi(depthEnv)
+		if err != nil || graphLimits.MaxDepth <= 0 {
+			fatal("Invalid GRAPHQL_MAX_DEPTH: must be a positive number", "value", depthEnv)
+		}
+	}
+	if complexityEnv := os.Getenv("GRAPHQL_MAX_COMPLEXITY"); complexityEnv != "" {
+		graphLimits.MaxComplexity, err = strconv.Atoi(complexityEnv)
+		if err != nil || graphLimits.MaxComplexity <= 0 {
+			fatal("Invalid GRAPHQL_MAX_COMPLEXITY: must be a positive number of ledger queries", "value", complexityEnv)
+		}
+	}
+	graphSchema, err := graph.NewSchema(graph.Services{
+		Organizations: organizationService,
+		Batches:       batchService,
+		Drugs:         drugService,
+		Transfers:     transferService,
+	}, graphLimits)
+	if err != nil {
+		fatal("Failed to load GraphQL schema", "error", err)
+	}
 
 	// Handlers are instantiated with services.
 	organizationHandler := handlers.NewOrganizationHandler(organizationService)
@@ -66,53 +227,239 @@ func main() {
 	drugHandler := handlers.NewDrugHandler(drugService)
 	transferHandler := handlers.NewTransferHandler(transferService)
 	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	serializationHandler := handlers.NewSerializationHandler(serializationRunner)
+	labelHandler := handlers.NewLabelHandler(labelService)
+	epcisHandler := handlers.NewEPCISHandler(epcisService)
+	t3Handler := handlers.NewT3Handler(t3Service)
+	provenanceHandler := handlers.NewProvenanceHandler(provenanceService)
+	integrityHandler := handlers.NewIntegrityHandler(integrityService)
+	partnerHandler := handlers.NewPartnerHandler(partnerService)
+	docsHandler := handlers.NewDocsHandler(openapi.Spec)
+	graphQLHandler := handlers.NewGraphQLHandler(graphSchema)
+	// The metrics name organizations and chaincode functions but hold no ledger data. Without a token they are
+	// public, for deployments where only the scraper's network can reach the API port.
+	metricsToken := os.Getenv("METRICS_TOKEN")
+	if metricsToken == "" {
+		slog.Warn("METRICS_TOKEN not set in environment, /metrics is public. Set it, or keep the API port away from untrusted networks.")
+	}
+	metricsHandler := handlers.NewMetricsHandler(metricsToken)
+	healthHandler := handlers.NewHealthHandler(healthService, certificateService)
 
 	// --- Public Routes ---
 	e.POST("/login", auth.LoginHandler)
 	e.POST("/logout", auth.LogoutHandler) // Or GET, but POST is often preferred for logout
 	e.POST("/refresh", auth.RefreshTokenHandler)
-	e.GET("/history/drug/:drugID", drugHandler.GetHistoryDrug)
+	e.GET("/history/drug/:drugID", drugHandler.GetHistoryDrug, auth.PublicMiddleware)
+	// Verification proofs carry whole blocks, with the transactions of every organization ordered in them.
+	if os.Getenv("PUBLIC_HISTORY_VERIFICATION") == "true" {
+		slog.Warn("PUBLIC_HISTORY_VERIFICATION is true: anyone can read the blocks holding a drug's transactions, including the transactions of other organizations in them")
+		e.GET("/history/drug/:drugID/verification", integrityHandler.GetVerifiableHistoryDrug, auth.PublicMiddleware)
+	}
+	e.GET("/01/*", drugHandler.ResolveDigitalLink) // GS1 Digital Link URIs printed in label QR codes
+	e.GET("/openapi.json", docsHandler.GetSpec)
+	e.GET("/docs", docsHandler.GetDocs)
+	e.GET("/metrics", metricsHandler.GetMetrics)
+	e.GET("/healthz", healthHandler.GetHealthz)
+	e.GET("/readyz", healthHandler.GetReadyz)
+	e.GET("/health/details", healthHandler.GetHealthDetails, auth.AuthMiddleware, auth.RequireAdmin)
+	e.GET("/health/certificates", healthHandler.GetCertificates, auth.AuthMiddleware, auth.RequireAdmin)
 
 	// --- Protected Route Groups ---
 	// These groups will use the AuthMiddleware to ensure a valid JWT and set up the Fabric context.
+	// Unsafe requests with an Idempotency-Key header are processed once per organization and key. The body limit
+	// runs before the idempotency middleware, which reads the whole body to fingerprint it.
 
-	orgGroup := e.Group("/organizations", auth.AuthMiddleware)
+	orgGroup := e.Group("/organizations", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
 	orgGroup.GET("", organizationHandler.GetOrganizations)
+	orgGroup.GET("/:id/history", organizationHandler.GetHistoryOrganization)
+	orgGroup.GET("/:id/history/changes", organizationHandler.GetOrganizationChanges)
 	orgGroup.GET("/:id", organizationHandler.GetOrganizationByID)
+	orgGroup.POST("", organizationHandler.RegisterOrganization, auth.RequireAdmin)
+	orgGroup.PATCH("/:id", organizationHandler.UpdateOrganization, auth.RequireAdmin)
+	orgGroup.POST("/:id/deactivate", organizationHandler.DeactivateOrganization, auth.RequireAdmin)
 
-	batchesGroup := e.Group("/batches", auth.AuthMiddleware)
+	batchesGroup := e.Group("/batches", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
 	batchesGroup.POST("", batchHandler.CreateBatch)
 	batchesGroup.GET("", batchHandler.GetAllBatches)
 	batchesGroup.GET("/:id/exists", batchHandler.BatchExists)
+	batchesGroup.GET("/:id/label", labelHandler.GetBatchLabel)
+	batchesGroup.GET("/:id/labels", labelHandler.GetBatchLabelSheet)
+	batchesGroup.GET("/:id/provenance", provenanceHandler.GetBatchProvenance)
+	batchesGroup.GET("/:id/history", batchHandler.GetHistoryBatch)
+	batchesGroup.GET("/:id/history/changes", batchHandler.GetBatchChanges)
 	batchesGroup.GET("/:id", batchHandler.GetBatchByID)
 	batchesGroup.PATCH("/:id", batchHandler.UpdateBatch)
 
-	ledgerGroup := e.Group("/ledger", auth.AuthMiddleware)
-	ledgerGroup.POST("/init", ledgerHandler.InitLedger)
+	ledgerGroup := e.Group("/ledger", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
+	ledgerGroup.POST("/init", ledgerHandler.InitLedger, auth.RequireAdmin)
+	ledgerGroup.GET("/init/audit", ledgerHandler.GetInitAudit, auth.RequireAdmin)
 
-	drugsGroup := e.Group("/drugs", auth.AuthMiddleware)
+	drugsGroup := e.Group("/drugs", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
 	drugsGroup.POST("", drugHandler.CreateDrug)
+	drugsGroup.POST("/bulk", serializationHandler.CreateDrugsBulk)
+	drugsGroup.GET("/bulk/:jobID", serializationHandler.GetBulkJob)
+	drugsGroup.POST("/bulk/:jobID/resume", serializationHandler.ResumeBulkJob)
 	drugsGroup.GET("/my", drugHandler.GetMyDrugs)
 	drugsGroup.GET("/my/available", drugHandler.GetMyAvailDrugs)
 	drugsGroup.GET("/:drugID", drugHandler.GetDrug)
+	drugsGroup.GET("/:drugID/label", labelHandler.GetDrugLabel)
+	drugsGroup.GET("/:drugID/provenance", provenanceHandler.GetDrugProvenance)
 	drugsGroup.GET("/batch/:batchID", drugHandler.GetDrugByBatch)
 	drugsGroup.GET("/transfer/:transferID", drugHandler.GetDrugByTransfer)
 	drugsGroup.GET("/history/:drugID", drugHandler.GetHistoryDrug)
+	drugsGroup.GET("/history/:drugID/changes", drugHandler.GetDrugChanges)
+	drugsGroup.GET("/history/:drugID/verification", integrityHandler.GetVerifiableHistoryDrug)
 
-	transferGroup := e.Group("/transfers", auth.AuthMiddleware)
+	transferGroup := e.Group("/transfers", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
 	transferGroup.POST("", transferHandler.CreateTransfer)
+	transferGroup.POST("/batch", transferHandler.CreateTransferByBatch)
 	transferGroup.GET("/my", transferHandler.GetMyTransfers)
 	transferGroup.GET("/my/outgoing", transferHandler.GetMyOutTransfer)
 	transferGroup.GET("/my/incoming", transferHandler.GetMyInTransfer)
 	transferGroup.POST("/accept", transferHandler.AcceptTransfer)
+	transferGroup.POST("/accept/partial", transferHandler.AcceptTransferPartial)
 	transferGroup.POST("/reject", transferHandler.RejectTransfer)
+	transferGroup.GET("/:id/discrepancy", transferHandler.GetTransferDiscrepancy)
+	transferGroup.POST("/:id/cancel", transferHandler.CancelTransfer)
+	transferGroup.GET("/:id/t3", t3Handler.GetTransferT3)
+	transferGroup.GET("/:id/provenance", provenanceHandler.GetTransferProvenance)
+	transferGroup.GET("/:id/history", transferHandler.GetHistoryTransfer)
+	transferGroup.GET("/:id/history/changes", transferHandler.GetTransferChanges)
 	transferGroup.GET("/:id", transferHandler.GetTransfer)
 
+	partnerGroup := e.Group("/partners", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxRequestSize), idempotencyStore.Middleware)
+	partnerGroup.GET("", partnerHandler.GetPartners)
+	partnerGroup.GET("/:partnerID", partnerHandler.GetPartner)
+	partnerGroup.PUT("/:partnerID", partnerHandler.UpsertPartner)
+	partnerGroup.DELETE("/:partnerID", partnerHandler.RemovePartner)
+
+	epcisGroup := e.Group("/epcis", auth.AuthMiddleware, middleware.BodyLimit(handlers.MaxCaptureSize), idempotencyStore.Middleware)
+	epcisGroup.GET("/events/drugs/:drugID", epcisHandler.ExportDrugEvents)
+	epcisGroup.GET("/events/batches/:id", epcisHandler.ExportBatchEvents)
+	epcisGroup.GET("/events/transfers/:id", epcisHandler.ExportTransferEvents)
+	epcisGroup.POST("/capture", epcisHandler.CaptureEvents)
+
+	// GraphQL is read-only, so it needs no idempotency keys.
+	e.POST("/graphql", graphQLHandler.ServeGraphQL, auth.AuthMiddleware)
+
+	// SIGINT and SIGTERM cancel ctx: background jobs stop and both servers shut down gracefully, letting
+	// in-flight requests and streams finish first.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sweepInterval := jobs.DefaultTransferExpiryInterval
+	if sweepIntervalEnv := os.Getenv("TRANSFER_EXPIRY_SWEEP_INTERVAL"); sweepIntervalEnv != "" {
+		sweepInterval, err = time.ParseDuration(sweepIntervalEnv)
+		if err != nil {
+			fatal("Invalid TRANSFER_EXPIRY_SWEEP_INTERVAL", "value", sweepIntervalEnv, "error", err)
+		}
+	}
+	if sweepInterval > 0 {
+		jobs.NewTransferExpirySweeper(transferService, sweepInterval).Start(ctx)
+		slog.Info("Transfer expiry sweeper running", "interval", sweepInterval.String())
+	} else {
+		slog.Info("Transfer expiry sweeper disabled")
+	}
+
+	certCheckInterval := jobs.DefaultCertificateCheckInterval
+	if certCheckIntervalEnv := os.Getenv("CERT_CHECK_INTERVAL"); certCheckIntervalEnv != "" {
+		certCheckInterval, err = time.ParseDuration(certCheckIntervalEnv)
+		if err != nil || certCheckInterval < 0 {
+			fatal("Invalid CERT_CHECK_INTERVAL: must be a duration, or 0 to check only at startup", "value", certCheckIntervalEnv)
+		}
+	}
+	jobs.NewCertificateMonitor(certificateService, certCheckInterval).Start(ctx)
+	slog.Info("Certificate monitor running", "interval", certCheckInterval.String(), "warning", certExpiryWarning.String())
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		slog.Info("GRPC_PORT not set in environment, using default 9090")
+		grpcPort = "9090"
+	}
+	watchInterval := grpcapi.DefaultWatchInterval
+	if watchIntervalEnv := os.Getenv("GRPC_TRANSFER_WATCH_INTERVAL"); watchIntervalEnv != "" {
+		watchInterval, err = time.ParseDuration(watchIntervalEnv)
+		if err != nil || watchInterval <= 0 {
+			fatal("Invalid GRPC_TRANSFER_WATCH_INTERVAL: must be a positive duration", "value", watchIntervalEnv)
+		}
+	}
+	// The gRPC API shares the services above and authenticates with the same access tokens.
+	var grpcOptions []grpc.ServerOption
+	grpcTLSCert, grpcTLSKey := os.Getenv("GRPC_TLS_CERT"), os.Getenv("GRPC_TLS_KEY")
+	if grpcTLSCert != "" || grpcTLSKey != "" {
+		creds, err := credentials.NewServerTLSFromFile(grpcTLSCert, grpcTLSKey)
+		if err != nil {
+			fatal("Invalid GRPC_TLS_CERT or GRPC_TLS_KEY", "cert", grpcTLSCert, "key", grpcTLSKey, "error", err)
+		}
+		grpcOptions = append(grpcOptions, grpc.Creds(creds))
+	} else {
+		slog.Warn("GRPC_TLS_CERT and GRPC_TLS_KEY not set in environment, the gRPC server accepts plaintext connections. Terminate TLS in front of it or set both.")
+	}
+	grpcServer := grpcapi.NewServer(grpcapi.Services{
+		Organizations: organizationService,
+		Batches:       batchService,
+		Drugs:         drugService,
+		Transfers:     transferService,
+	}, watchInterval, grpcOptions...)
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		fatal("Failed to listen on gRPC port", "port", grpcPort, "error", err)
+	}
+	go func() {
+		slog.Info("Starting gRPC server", "port", grpcPort, "tls", len(grpcOptions) > 0)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			fatal("gRPC server stopped", "error", err)
+		}
+	}()
+
 	port := os.Getenv("API_PORT")
 	if port == "" {
-		log.Println("API_PORT not set in environment, using default 8080")
+		slog.Info("API_PORT not set in environment, using default 8080")
 		port = "8080"
 	}
-	log.Printf("Starting server on port %s", port)
-	e.Logger.Fatal(e.Start(":" + port))
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("Starting server", "port", port)
+		serverErr <- e.Start(":" + port)
+	}()
+	var errServe error
+	select {
+	case errServe = <-serverErr:
+		slog.Error("Server stopped", "error", errServe)
+	case <-ctx.Done():
+		slog.Info("Shutting down", "timeout", shutdownTimeout.String())
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if errShutdown := e.Shutdown(shutdownCtx); errShutdown != nil {
+		slog.Error("Failed to shut down the HTTP server", "error", errShutdown)
+	}
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		// Watch streams run until the client leaves, so cut off what is left after the timeout.
+		slog.Warn("gRPC calls still running after the shutdown timeout, closing them")
+		grpcServer.Stop()
+	}
+
+	// Export the spans still buffered before exiting.
+	if errShutdown := shutdownTracing(shutdownCtx); errShutdown != nil {
+		slog.Error("Failed to flush spans", "error", errShutdown)
+	}
+	if errServe != nil {
+		os.Exit(1)
+	}
+}
+
+// fatal logs an error that prevents the server from running and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
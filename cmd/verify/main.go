@@ -0,0 +1,231 @@
+// Command verify checks a verifiable history served by the MedTrace API offline, against the MSP certificates
+// of the network's organizations and of its ordering service.
+//
+//	curl -s -H "Authorization: Bearer $TOKEN" http://api/drugs/history/DRUG-1/verification > history.json
+//	verify -msp Org1MSP=organizations/peerOrganizations/org1.medtrace.com/msp \
+//	       -msp Org2MSP=organizations/peerOrganizations/org2.medtrace.com/msp \
+//	       -msp OrdererMSP=organizations/ordererOrganizations/medtrace.com/msp -orderer OrdererMSP history.json
+//
+// It prints a JSON report and exits with status 1 if any entry could not be verified.
+//
+// The t3 subcommand checks the signature of T3 documents, as returned by GET /transfers/{id}/t3 or attached to
+// their PDF, and that the signer certificate is issued by the signer's MSP:
+//
+//	verify t3 -msp Org1MSP=organizations/peerOrganizations/org1.medtrace.com/msp t3-TRF-1.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/signing"
+	"github.com/AryaJayadi/MedTrace_api/pkg/verifier"
+)
+
+// mspFlags collects repeated -msp MSPID=dir flags.
+type mspFlags map[string]string
+
+func (m mspFlags) String() string {
+	pairs := make([]string, 0, len(m))
+	for id, dir := range m {
+		pairs = append(pairs, id+"="+dir)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m mspFlags) Set(value string) error {
+	id, dir, ok := strings.Cut(value, "=")
+	if !ok || id == "" || dir == "" {
+		return fmt.Errorf("expected MSPID=dir, got %q", value)
+	}
+	m[id] = dir
+	return nil
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "t3" {
+		verifyT3(os.Args[2:])
+		return
+	}
+
+	msps := mspFlags{}
+	flag.Var(msps, "msp", "MSP ID and local MSP directory holding cacerts, as MSPID=dir; repeat for every organization")
+	minEndorsements := flag.Int("min-endorsements", 1, "number of distinct organizations that must endorse each transaction")
+	orderers := flag.String("orderer", "", "comma-separated MSP IDs of the ordering service, each also given with -msp")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -msp MSPID=dir [-msp ...] -orderer MSPID[,...] [history.json]\n\nReads the response from stdin when no file is given.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if len(msps) == 0 || *orderers == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	ordererMSPs := strings.Split(*orderers, ",")
+	for _, id := range ordererMSPs {
+		if _, ok := msps[id]; !ok {
+			log.Fatalf("Orderer MSP %s has no -msp directory", id)
+		}
+	}
+
+	trust := loadTrust(msps)
+	data, err := readInput(flag.Args())
+	if err != nil {
+		log.Fatalf("Failed to read history: %v", err)
+	}
+	history, err := decodeHistory(data)
+	if err != nil {
+		log.Fatalf("Failed to decode history: %v", err)
+	}
+
+	report := (&verifier.Verifier{Trust: trust, MinEndorsements: *minEndorsements, OrdererMSPs: ordererMSPs}).Verify(history)
+	writeReport(report)
+	if !report.Valid {
+		os.Exit(1)
+	}
+}
+
+// t3Report is the outcome of checking the signature of T3 documents.
+type t3Report struct {
+	TransferID  string     `json:"TransferID"`
+	Valid       bool       `json:"Valid"`
+	SignerOrgID string     `json:"SignerOrgID,omitempty"`
+	SignerMSPID string     `json:"SignerMSPID,omitempty"`
+	SignedAt    *time.Time `json:"SignedAt,omitempty"`
+	Error       string     `json:"Error,omitempty"`
+}
+
+// verifyT3 runs the t3 subcommand.
+func verifyT3(args []string) {
+	fs := flag.NewFlagSet("t3", flag.ExitOnError)
+	msps := mspFlags{}
+	fs.Var(msps, "msp", "MSP ID and local MSP directory holding cacerts, as MSPID=dir; repeat for every organization that may sign")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s t3 -msp MSPID=dir [-msp ...] [t3.json]\n\nReads the documents from stdin when no file is given.\n\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if len(msps) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	trust := loadTrust(msps)
+	data, err := readInput(fs.Args())
+	if err != nil {
+		log.Fatalf("Failed to read T3 documents: %v", err)
+	}
+	doc, err := decodeT3(data)
+	if err != nil {
+		log.Fatalf("Failed to decode T3 documents: %v", err)
+	}
+
+	report := t3Report{TransferID: doc.TransferID}
+	if sig := doc.Signature; sig == nil {
+		report.Error = "documents are not signed"
+	} else {
+		report.SignerOrgID, report.SignerMSPID, report.SignedAt = sig.SignerOrgID, sig.SignerMSPID, &sig.SignedAt
+		if signed, err := signing.SignedBytes(doc); err != nil {
+			report.Error = err.Error()
+		} else if err := signing.Verify(*sig, signed, trust); err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Valid = true
+		}
+	}
+	writeReport(report)
+	if !report.Valid {
+		os.Exit(1)
+	}
+}
+
+func loadTrust(msps mspFlags) verifier.TrustStore {
+	trust := verifier.TrustStore{}
+	for id, dir := range msps {
+		if err := trust.LoadMSP(id, dir); err != nil {
+			log.Fatalf("Failed to load MSP %s: %v", id, err)
+		}
+	}
+	return trust
+}
+
+// readInput reads the file named by the first argument, or stdin when there is none.
+func readInput(args []string) ([]byte, error) {
+	if len(args) > 0 {
+		return os.ReadFile(args[0])
+	}
+	return io.ReadAll(os.Stdin)
+}
+
+func writeReport(report any) {
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	if err := out.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+}
+
+// decodeHistory accepts either an API response envelope or a bare history.
+func decodeHistory(data []byte) (verifier.History, error) {
+	var envelope struct {
+		Success *bool             `json:"success"`
+		Value   *verifier.History `json:"value"`
+		Error   *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return verifier.History{}, err
+	}
+	if envelope.Success != nil {
+		if !*envelope.Success || envelope.Value == nil {
+			if envelope.Error != nil {
+				return verifier.History{}, fmt.Errorf("API returned an error: %s", envelope.Error.Message)
+			}
+			return verifier.History{}, fmt.Errorf("API response holds no history")
+		}
+		return *envelope.Value, nil
+	}
+
+	var history verifier.History
+	if err := json.Unmarshal(data, &history); err != nil {
+		return verifier.History{}, err
+	}
+	return history, nil
+}
+
+// decodeT3 accepts either an API response envelope or bare T3 documents.
+func decodeT3(data []byte) (entity.T3Document, error) {
+	var envelope struct {
+		Success *bool              `json:"success"`
+		Value   *entity.T3Document `json:"value"`
+		Error   *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return entity.T3Document{}, err
+	}
+	if envelope.Success != nil {
+		if !*envelope.Success || envelope.Value == nil {
+			if envelope.Error != nil {
+				return entity.T3Document{}, fmt.Errorf("API returned an error: %s", envelope.Error.Message)
+			}
+			return entity.T3Document{}, fmt.Errorf("API response holds no T3 documents")
+		}
+		return *envelope.Value, nil
+	}
+
+	var doc entity.T3Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return entity.T3Document{}, err
+	}
+	return doc, nil
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -9,11 +10,16 @@ import (
 
 	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
 	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/auth"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/tracing"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // JWTCustomClaims are custom claims extending default ones.
@@ -25,9 +31,22 @@ type JWTCustomClaims struct {
 
 var jwtSecret []byte
 
+var tracer = otel.Tracer("github.com/AryaJayadi/MedTrace_api/internal/auth")
+
+var logger = logging.Logger("auth")
+
+// adminOrgs are the organizations allowed to manage other organizations, set from ADMIN_ORGS at startup.
+var adminOrgs = map[string]bool{}
+
 const (
 	// OrgContextKey is the key used to store the Fabric contract in Echo context.
 	OrgContextKey = "org_contract"
+	// OrgIDContextKey is the key used to store the authenticated organization ID in Echo context.
+	OrgIDContextKey = "org_id"
+	// NetworkContextKey is the key used to store the Fabric network (channel) in Echo context.
+	NetworkContextKey = "org_network"
+	// DefaultPublicOrg is used if PUBLIC_QUERY_ORG env var is not set.
+	DefaultPublicOrg = "Org1"
 	// DefaultChaincodeName is used if CHAINCODE_NAME env var is not set.
 	DefaultChaincodeName = "medtrace_cc"
 	// DefaultChannelName is used if CHANNEL_NAME env var is not set.
@@ -101,73 +120,154 @@ func GenerateRefreshToken(orgID string) (string, error) {
 // and initializes the Fabric connection context for the authenticated organization.
 func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" {
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[interface{}](http.StatusUnauthorized, "Missing or malformed JWT"))
+		orgID, errInfo := AuthenticateBearer(c.Request().Header.Get("Authorization"))
+		if errInfo != nil {
+			return errInfo
 		}
+		c.Set(OrgIDContextKey, orgID)
+		return withOrgNetwork(c, "AuthMiddleware", orgID, next)
+	}
+}
+
+// AuthenticateBearer validates an Authorization header value of the form "Bearer <access token>"
+// and returns the organization the token was issued to. It is shared by the REST and gRPC APIs.
+func AuthenticateBearer(authHeader string) (string, *response.ErrorInfo) {
+	claims, errInfo := AuthenticateBearerClaims(authHeader)
+	if errInfo != nil {
+		return "", errInfo
+	}
+	return claims.OrgID, nil
+}
+
+// AuthenticateBearerClaims is AuthenticateBearer for callers that also need the token's claims, such as its
+// expiry for long-lived streams.
+func AuthenticateBearerClaims(authHeader string) (*JWTCustomClaims, *response.ErrorInfo) {
+	if authHeader == "" {
+		return nil, response.NewError(http.StatusUnauthorized, "Missing or malformed JWT")
+	}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[interface{}](http.StatusUnauthorized, "Malformed Authorization header: expecting 'Bearer <token>'"))
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return nil, response.NewError(http.StatusUnauthorized, "Malformed Authorization header: expecting 'Bearer <token>'")
+	}
+	tokenString := parts[1]
+
+	token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		tokenString := parts[1]
-
-		token, err := jwt.ParseWithClaims(tokenString, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return jwtSecret, nil
-		})
-		if err != nil {
-			// Handle specific JWT errors like expiry
-			if err == jwt.ErrTokenExpired {
-				return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[interface{}](http.StatusUnauthorized, "Access token has expired"))
-			}
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[interface{}](http.StatusUnauthorized, fmt.Sprintf("Invalid access token: %s", err.Error())))
+		return jwtSecret, nil
+	})
+	if err != nil {
+		// Handle specific JWT errors like expiry
+		if err == jwt.ErrTokenExpired {
+			return nil, response.NewError(http.StatusUnauthorized, "Access token has expired")
 		}
+		return nil, response.NewError(http.StatusUnauthorized, "Invalid access token: %v", err)
+	}
+
+	if claims, ok := token.Claims.(*JWTCustomClaims); ok && token.Valid {
+		if claims.TokenType != TokenTypeAccess {
+			return nil, response.NewError(http.StatusForbidden, "Invalid token type: an access token is required")
+		}
+		return claims, nil
+	}
+	return nil, response.NewError(http.StatusUnauthorized, "Invalid access token claims")
+}
 
-		if claims, ok := token.Claims.(*JWTCustomClaims); ok && token.Valid {
-			if claims.TokenType != TokenTypeAccess {
-				return c.JSON(http.StatusForbidden, response.ErrorValueResponse[interface{}](http.StatusForbidden, "Invalid token type: an access token is required"))
-			}
-
-			orgCfg, err := config.GetOrgConfig(claims.OrgID)
-			if err != nil {
-				c.Logger().Errorf("AuthMiddleware: Failed to get org config for %s: %v", claims.OrgID, err)
-				return c.JSON(http.StatusInternalServerError, response.ErrorValueResponse[interface{}](http.StatusInternalServerError, fmt.Sprintf("Cannot process request for organization %s", claims.OrgID)))
-			}
-
-			orgSetup, err := fabric.Initialize(orgCfg)
-			if err != nil {
-				c.Logger().Errorf("AuthMiddleware: Failed to initialize Fabric for Org %s: %v", claims.OrgID, err)
-				return c.JSON(http.StatusInternalServerError, response.ErrorValueResponse[interface{}](http.StatusInternalServerError, fmt.Sprintf("Failed to connect to network for organization %s", claims.OrgID)))
-			}
-
-			// Defer closing the gateway connection. orgSetup.Gateway is client.Gateway (a struct).
-			// It's valid if fabric.Initialize succeeded.
-			defer func() {
-				if errClose := orgSetup.Gateway.Close(); errClose != nil {
-					c.Logger().Errorf("AuthMiddleware: Error closing Fabric gateway for %s: %v", claims.OrgID, errClose)
-				}
-			}()
-
-			chaincodeName := os.Getenv("CHAINCODE_NAME")
-			if chaincodeName == "" {
-				chaincodeName = DefaultChaincodeName
-			}
-			channelName := os.Getenv("CHANNEL_NAME")
-			if channelName == "" {
-				channelName = DefaultChannelName
-			}
-
-			network := orgSetup.Gateway.GetNetwork(channelName)
-			contractInstance := network.GetContract(chaincodeName)
-
-			c.Set(OrgContextKey, contractInstance)
-			return next(c)
+// PublicMiddleware is an Echo middleware for unauthenticated read-only routes. It connects to the Fabric network
+// as the organization named by PUBLIC_QUERY_ORG (default Org1) without setting an authenticated organization ID.
+func PublicMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orgID := os.Getenv("PUBLIC_QUERY_ORG")
+		if orgID == "" {
+			orgID = DefaultPublicOrg
 		}
-		return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[interface{}](http.StatusUnauthorized, "Invalid access token claims"))
+		return withOrgNetwork(c, "PublicMiddleware", orgID, next)
+	}
+}
+
+// withOrgNetwork connects to the Fabric network as orgID, stores the network and contract in the context
+// for the duration of next and closes the gateway afterwards.
+func withOrgNetwork(c echo.Context, caller, orgID string, next echo.HandlerFunc) error {
+	ctx := logging.With(c.Request().Context(), slog.String(logging.OrgKey, orgID))
+	c.SetRequest(c.Request().WithContext(ctx))
+	trace.SpanFromContext(ctx).SetAttributes(tracing.OrgKey.String(orgID))
+	_, span := tracer.Start(ctx, caller+" gateway", trace.WithAttributes(tracing.OrgKey.String(orgID)))
+
+	orgCfg, err := config.GetOrgConfig(orgID)
+	if err != nil {
+		tracing.Fail(span, err)
+		span.End()
+		logger.ErrorContext(ctx, caller+": Failed to get org config", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Cannot process request for organization %s", orgID)
+	}
+
+	orgSetup, err := fabric.Initialize(orgCfg)
+	if err != nil {
+		tracing.Fail(span, err)
+		span.End()
+		logger.ErrorContext(ctx, caller+": Failed to initialize Fabric", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to connect to network for organization %s", orgID)
+	}
+	span.End()
+	c.SetRequest(c.Request().WithContext(logging.With(c.Request().Context(), slog.String(logging.UserKey, orgSetup.User))))
+
+	// Defer closing the gateway and its connection. It's valid if fabric.Initialize succeeded.
+	defer func() {
+		if errClose := orgSetup.Close(); errClose != nil {
+			logger.ErrorContext(c.Request().Context(), caller+": Error closing Fabric gateway", "error", errClose)
+		}
+	}()
+
+	chaincodeName, channelName := chaincodeAndChannel()
+	network := orgSetup.Gateway.GetNetwork(channelName)
+	contractInstance := network.GetContract(chaincodeName)
+
+	c.Set(NetworkContextKey, network)
+	c.Set(OrgContextKey, contractInstance)
+	return next(c)
+}
+
+// chaincodeAndChannel returns the chaincode and channel names from the environment, falling back to the defaults.
+func chaincodeAndChannel() (string, string) {
+	chaincodeName := os.Getenv("CHAINCODE_NAME")
+	if chaincodeName == "" {
+		chaincodeName = DefaultChaincodeName
+	}
+	channelName := os.Getenv("CHANNEL_NAME")
+	if channelName == "" {
+		channelName = DefaultChannelName
+	}
+	return chaincodeName, channelName
+}
+
+// NewContractForOrg connects to the Fabric network as the given organization outside of a request,
+// e.g. for background jobs. The returned close function releases the gateway and must always be called.
+func NewContractForOrg(orgID string) (*client.Contract, func() error, error) {
+	network, closeGateway, err := NewNetworkForOrg(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	chaincodeName, _ := chaincodeAndChannel()
+	return network.GetContract(chaincodeName), closeGateway, nil
+}
+
+// NewNetworkForOrg connects to the channel as the given organization outside of a request, for queries to
+// system chaincodes such as health checks. The returned close function releases the gateway and must always be called.
+func NewNetworkForOrg(orgID string) (*client.Network, func() error, error) {
+	orgCfg, err := config.GetOrgConfig(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgSetup, err := fabric.Initialize(orgCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize Fabric for organization %s: %w", orgID, err)
 	}
+
+	_, channelName := chaincodeAndChannel()
+	return orgSetup.Gateway.GetNetwork(channelName), orgSetup.Close, nil
 }
 
 // GetContractFromContext retrieves the Fabric contract from the Echo context.
@@ -184,40 +284,94 @@ func GetContractFromContext(c echo.Context) (*client.Contract, error) {
 	return contract, nil
 }
 
+// GetNetworkFromContext retrieves the Fabric network from the Echo context, for queries to system chaincodes
+// such as qscc that are not reachable through the MedTrace contract.
+func GetNetworkFromContext(c echo.Context) (*client.Network, error) {
+	network, ok := c.Get(NetworkContextKey).(*client.Network)
+	if !ok || network == nil {
+		return nil, fmt.Errorf("Fabric network not found in context, ensure AuthMiddleware or PublicMiddleware is applied")
+	}
+	return network, nil
+}
+
+// GetOrgIDFromContext retrieves the authenticated organization ID from the Echo context.
+func GetOrgIDFromContext(c echo.Context) (string, error) {
+	orgID, ok := c.Get(OrgIDContextKey).(string)
+	if !ok || orgID == "" {
+		return "", fmt.Errorf("Organization ID not found in context, ensure AuthMiddleware is applied")
+	}
+	return orgID, nil
+}
+
+// SetAdminOrgs replaces the set of organizations allowed to call admin endpoints.
+func SetAdminOrgs(orgIDs []string) {
+	admins := make(map[string]bool, len(orgIDs))
+	for _, id := range orgIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = true
+		}
+	}
+	adminOrgs = admins
+}
+
+// IsAdmin reports whether an organization may call admin endpoints.
+func IsAdmin(orgID string) bool {
+	return adminOrgs[orgID]
+}
+
+// RequireAdmin is an Echo middleware for admin endpoints. It must run after AuthMiddleware.
+func RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orgID, err := GetOrgIDFromContext(c)
+		if err != nil {
+			return response.NewError(http.StatusUnauthorized, "Missing or malformed JWT")
+		}
+		if !IsAdmin(orgID) {
+			return response.NewError(http.StatusForbidden, "Organization %s is not an administrator", orgID)
+		}
+		return next(c)
+	}
+}
+
 func validateOrgAndPassword(org, password string) bool {
 	expectedPassword := org + "asdf"
 	return password == expectedPassword
 }
 
 // LoginHandler handles the /login endpoint.
+// @Summary Log in as an organization
+// @Description Exchange an organization's credentials for an access token and a refresh token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body auth.PayloadLogin true "Organization ID and password"
+// @Success 200 {object} response.BaseValueResponse[auth.LoginResponseData]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload or organization"
+// @Failure 401 {object} response.BaseResponse "Invalid organization or password"
+// @Failure 500 {object} response.BaseResponse "Tokens could not be generated"
+// @Router /login [post]
 func LoginHandler(c echo.Context) error {
 	payload := new(auth.PayloadLogin)
 	if err := c.Bind(payload); err != nil {
-		return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.LoginResponseData](http.StatusBadRequest, "Invalid request payload: %v", err))
-	}
-	if payload.Organization == "" {
-		return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.LoginResponseData](http.StatusBadRequest, "organization is required"))
-	}
-	if payload.Password == "" {
-		return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.LoginResponseData](http.StatusBadRequest, "Password is required"))
+		return validation.BindError(err)
 	}
 	if !validateOrgAndPassword(payload.Organization, payload.Password) {
-		return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.LoginResponseData](http.StatusUnauthorized, "Invalid organization or password"))
+		return response.NewError(http.StatusUnauthorized, "Invalid organization or password")
 	}
 
 	accessToken, err := GenerateAccessToken(payload.Organization)
 	if err != nil {
-		c.Logger().Errorf("LoginHandler: Failed to generate access token for OrgID '%s': %v", payload.Organization, err)
+		logger.ErrorContext(c.Request().Context(), "LoginHandler: Failed to generate access token", logging.OrgKey, payload.Organization, "error", err)
 		if strings.Contains(err.Error(), "cannot generate access token for invalid organization") {
-			return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.LoginResponseData](http.StatusBadRequest, "Invalid organization ID: %s", payload.Organization))
+			return response.NewError(http.StatusBadRequest, "Invalid organization ID: %s", payload.Organization)
 		}
-		return c.JSON(http.StatusInternalServerError, response.ErrorValueResponse[auth.LoginResponseData](http.StatusInternalServerError, "Login failed: could not generate access token."))
+		return response.NewError(http.StatusInternalServerError, "Login failed: could not generate access token.")
 	}
 
 	refreshToken, err := GenerateRefreshToken(payload.Organization)
 	if err != nil {
-		c.Logger().Errorf("LoginHandler: Failed to generate refresh token for OrgID '%s': %v", payload.Organization, err)
-		return c.JSON(http.StatusInternalServerError, response.ErrorValueResponse[auth.LoginResponseData](http.StatusInternalServerError, "Login failed: could not generate refresh token."))
+		logger.ErrorContext(c.Request().Context(), "LoginHandler: Failed to generate refresh token", logging.OrgKey, payload.Organization, "error", err)
+		return response.NewError(http.StatusInternalServerError, "Login failed: could not generate refresh token.")
 	}
 
 	responseData := auth.LoginResponseData{ // Use the updated LoginResponseData struct
@@ -229,15 +383,23 @@ func LoginHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, response.SuccessValueResponse(responseData))
 }
 
-// RefreshTokenHandler handles the /auth/refresh endpoint.
+// RefreshTokenHandler handles the /refresh endpoint.
+// @Summary Refresh an access token
+// @Description Exchange a valid refresh token for a new access token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body auth.PayloadRefreshToken true "Refresh token"
+// @Success 200 {object} response.BaseValueResponse[auth.RefreshTokenResponseData]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload"
+// @Failure 401 {object} response.BaseResponse "Refresh token invalid, expired or for an unknown organization"
+// @Failure 403 {object} response.BaseResponse "Not a refresh token"
+// @Failure 500 {object} response.BaseResponse "Access token could not be generated"
+// @Router /refresh [post]
 func RefreshTokenHandler(c echo.Context) error {
 	reqPayload := new(auth.PayloadRefreshToken) // Use the defined RefreshTokenRequest DTO
 	if err := c.Bind(reqPayload); err != nil {
-		return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusBadRequest, "Invalid request payload: %v", err))
-	}
-
-	if reqPayload.RefreshToken == "" {
-		return c.JSON(http.StatusBadRequest, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusBadRequest, "Refresh token is required"))
+		return validation.BindError(err)
 	}
 
 	token, err := jwt.ParseWithClaims(reqPayload.RefreshToken, &JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
@@ -249,29 +411,29 @@ func RefreshTokenHandler(c echo.Context) error {
 	if err != nil {
 		// Handle specific JWT errors like expiry
 		if err == jwt.ErrTokenExpired {
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusUnauthorized, "Refresh token has expired"))
+			return response.NewError(http.StatusUnauthorized, "Refresh token has expired")
 		}
-		return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusUnauthorized, fmt.Sprintf("Invalid refresh token: %s", err.Error())))
+		return response.NewError(http.StatusUnauthorized, "Invalid refresh token: %v", err)
 	}
 
 	if claims, ok := token.Claims.(*JWTCustomClaims); ok && token.Valid {
 		if claims.TokenType != TokenTypeRefresh {
-			return c.JSON(http.StatusForbidden, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusForbidden, "Invalid token type: not a refresh token"))
+			return response.NewError(http.StatusForbidden, "Invalid token type: not a refresh token")
 		}
 		if claims.OrgID == "" {
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusUnauthorized, "Refresh token missing organization ID"))
+			return response.NewError(http.StatusUnauthorized, "Refresh token missing organization ID")
 		}
 
 		// Validate if the organization from the token still exists/is valid
 		if _, orgErr := config.GetOrgConfig(claims.OrgID); orgErr != nil {
-			c.Logger().Warnf("RefreshTokenHandler: Organization '%s' from refresh token no longer valid: %v", claims.OrgID, orgErr)
-			return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusUnauthorized, "Organization from refresh token is no longer valid"))
+			logger.WarnContext(c.Request().Context(), "RefreshTokenHandler: Organization from refresh token no longer valid", logging.OrgKey, claims.OrgID, "error", orgErr)
+			return response.NewError(http.StatusUnauthorized, "Organization from refresh token is no longer valid")
 		}
 
 		newAccessToken, err := GenerateAccessToken(claims.OrgID)
 		if err != nil {
-			c.Logger().Errorf("RefreshTokenHandler: Failed to generate new access token for OrgID '%s': %v", claims.OrgID, err)
-			return c.JSON(http.StatusInternalServerError, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusInternalServerError, "Failed to generate new access token"))
+			logger.ErrorContext(c.Request().Context(), "RefreshTokenHandler: Failed to generate new access token", logging.OrgKey, claims.OrgID, "error", err)
+			return response.NewError(http.StatusInternalServerError, "Failed to generate new access token")
 		}
 
 		// OPTIONAL: Implement Refresh Token Rotation
@@ -279,7 +441,7 @@ func RefreshTokenHandler(c echo.Context) error {
 		// This invalidates the used refresh token.
 		// newRefreshToken, err := GenerateRefreshToken(claims.OrgID)
 		// if err != nil {
-		//    c.Logger().Errorf("RefreshTokenHandler: Failed to generate new refresh token for OrgID '%s': %v", claims.OrgID, err)
+		//    logger.ErrorContext(c.Request().Context(), "RefreshTokenHandler: Failed to generate new refresh token", logging.OrgKey, claims.OrgID, "error", err)
 		//    // Decide if this failure should prevent issuing the new access token
 		// }
 
@@ -290,10 +452,16 @@ func RefreshTokenHandler(c echo.Context) error {
 		return c.JSON(http.StatusOK, response.SuccessValueResponse(responseData))
 	}
 
-	return c.JSON(http.StatusUnauthorized, response.ErrorValueResponse[auth.RefreshTokenResponseData](http.StatusUnauthorized, "Invalid refresh token claims"))
+	return response.NewError(http.StatusUnauthorized, "Invalid refresh token claims")
 }
 
 // LogoutHandler handles the /logout endpoint.
+// @Summary Log out
+// @Description Tokens are stateless, so logging out only reminds the client to discard them.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.BaseValueResponse[auth.LogoutResponseData]
+// @Router /logout [post]
 func LogoutHandler(c echo.Context) error {
 	responseData := auth.LogoutResponseData{
 		Message: "Logout successful. Please ensure the token is removed from client-side storage.",
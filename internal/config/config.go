@@ -3,18 +3,32 @@ package config
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/store"
 )
 
+// DefaultOrgRegistryFile holds organizations registered at runtime, in addition to the built-in ones.
+const DefaultOrgRegistryFile = "data/organizations.json"
+
 type OrgInfo struct {
 	Name         string
 	MSPID        string
-	CryptoPath   string // Populated by init()
+	CryptoPath   string // Populated by init() or RegisterOrg when empty
 	PeerEndpoint string
 	GatewayPeer  string
 }
 
+// networkRelativePath is the MedTrace_network directory relative to where the application binary is run from.
+const networkRelativePath = "../../../MedTrace_network"
+
+var (
+	mu       sync.RWMutex
+	registry *store.JSONFile[[]OrgInfo]
+)
+
 var orgConfigurations = map[string]OrgInfo{
 	"Org1": {
 		Name:         "Org1",
@@ -45,7 +59,9 @@ var orgConfigurations = map[string]OrgInfo{
 // GetOrgConfig returns the fabric.OrgSetup for the specified organization.
 // It dynamically constructs the necessary paths.
 func GetOrgConfig(orgName string) (fabric.OrgSetup, error) {
+	mu.RLock()
 	orgInfo, ok := orgConfigurations[orgName]
+	mu.RUnlock()
 	if !ok {
 		return fabric.OrgSetup{}, fmt.Errorf("organization '%s' not found in configuration", orgName)
 	}
@@ -57,6 +73,7 @@ func GetOrgConfig(orgName string) (fabric.OrgSetup, error) {
 	return fabric.OrgSetup{
 		OrgName:      orgInfo.Name,
 		MSPID:        orgInfo.MSPID,
+		User:         userAndOrgDomain,
 		CertPath:     filepath.Join(orgInfo.CryptoPath, "users", userAndOrgDomain, "msp", "signcerts", userAndOrgDomain+"-cert.pem"),
 		KeyPath:      filepath.Join(orgInfo.CryptoPath, "users", userAndOrgDomain, "msp", "keystore"), // fabric.Initialize's PopulateWallet handles finding the key file in this directory
 		TLSCertPath:  filepath.Join(orgInfo.CryptoPath, "peers", fmt.Sprintf("peer0.%s.medtrace.com", lc(orgInfo.Name)), "tls", "ca.crt"),
@@ -65,6 +82,18 @@ func GetOrgConfig(orgName string) (fabric.OrgSetup, error) {
 	}, nil
 }
 
+// GetOrgNames returns the names of all configured organizations in sorted order.
+func GetOrgNames() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(orgConfigurations))
+	for name := range orgConfigurations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // lc is a helper to format the organization name for path construction (e.g., "Org1" -> "org1").
 func lc(s string) string {
 	if len(s) > 3 && (s[:3] == "Org" || s[:3] == "org") {
@@ -85,12 +114,88 @@ func init() {
 	// (e.g., MedTrace_api/cmd/server/). It navigates to the MedTrace_network directory.
 	// Example: if binary is at /home/user/MedTrace_api/cmd/server/server_binary
 	// then ../../../MedTrace_network will resolve to /home/user/MedTrace_network
-	networkRelativePath := "../../../MedTrace_network"
-
 	for orgKey, info := range orgConfigurations {
 		// Construct the CryptoPath: e.g., "../../../MedTrace_network/organizations/peerOrganizations/org1.medtrace.com"
 		// lc(info.Name) converts "Org1" to "org1", etc., for the directory name.
-		info.CryptoPath = filepath.Join(networkRelativePath, "organizations", "peerOrganizations", fmt.Sprintf("%s.medtrace.com", lc(info.Name)))
+		info.CryptoPath = defaultCryptoPath(info.Name)
 		orgConfigurations[orgKey] = info // Update the map with the populated CryptoPath
 	}
 }
+
+func defaultCryptoPath(orgName string) string {
+	return filepath.Join(networkRelativePath, "organizations", "peerOrganizations", fmt.Sprintf("%s.medtrace.com", lc(orgName)))
+}
+
+// LoadRegistry adds the organizations stored in the registry file at path to the configuration and makes
+// RegisterOrg persist new organizations there. Built-in organizations cannot be overridden.
+func LoadRegistry(path string) error {
+	file := store.NewJSONFile[[]OrgInfo](path)
+	registered, err := file.Load()
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	registry = file
+	for _, info := range registered {
+		if _, exists := orgConfigurations[info.Name]; exists {
+			return fmt.Errorf("organization '%s' in %s is already configured", info.Name, path)
+		}
+		orgConfigurations[info.Name] = info
+	}
+	return nil
+}
+
+// RegisterOrg adds an organization to the configuration so that it can log in and connect to the network,
+// and stores it in the registry file if one was loaded. An empty CryptoPath is derived from the name like
+// for the built-in organizations.
+func RegisterOrg(info OrgInfo) error {
+	if info.Name == "" || info.MSPID == "" || info.PeerEndpoint == "" || info.GatewayPeer == "" {
+		return fmt.Errorf("organization name, MSP ID, peer endpoint and gateway peer are required")
+	}
+	if info.CryptoPath == "" {
+		info.CryptoPath = defaultCryptoPath(info.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := orgConfigurations[info.Name]; exists {
+		return fmt.Errorf("organization '%s' is already configured", info.Name)
+	}
+	if registry != nil {
+		registered, err := registry.Load()
+		if err != nil {
+			return err
+		}
+		if err := registry.Save(append(registered, info)); err != nil {
+			return err
+		}
+	}
+	orgConfigurations[info.Name] = info
+	return nil
+}
+
+// UnregisterOrg removes an organization added by RegisterOrg from the configuration and the registry file.
+// It undoes a registration whose organization could not be created on the ledger.
+func UnregisterOrg(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if registry != nil {
+		registered, err := registry.Load()
+		if err != nil {
+			return err
+		}
+		kept := make([]OrgInfo, 0, len(registered))
+		for _, info := range registered {
+			if info.Name != name {
+				kept = append(kept, info)
+			}
+		}
+		if err := registry.Save(kept); err != nil {
+			return err
+		}
+	}
+	delete(orgConfigurations, name)
+	return nil
+}
@@ -0,0 +1,60 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+)
+
+// edgeStyles draws transfers that did not change ownership differently from accepted ones.
+var edgeStyles = map[string]string{
+	entity.TransferAccepted:  "solid",
+	entity.TransferPending:   "dashed",
+	entity.TransferRejected:  "dotted",
+	entity.TransferCancelled: "dotted",
+	entity.TransferExpired:   "dotted",
+}
+
+// ProvenanceDOT renders a provenance graph in Graphviz DOT: organizations as nodes labelled with the units they hold,
+// transfers as edges labelled with their date, quantity and status.
+func ProvenanceDOT(p entity.Provenance) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %s {\n", strconv.Quote(p.Subject+" "+p.SubjectID))
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, n := range p.Nodes {
+		label := n.ID
+		if n.Name != "" {
+			label = n.Name + "\n" + n.ID
+		}
+		if n.Location != "" {
+			label += "\n" + n.Location
+		}
+		if n.Holding > 0 {
+			label += fmt.Sprintf("\nholding %d", n.Holding)
+		}
+		attrs := "label=" + strconv.Quote(label)
+		if n.Origin {
+			attrs += ", peripheries=2"
+		}
+		fmt.Fprintf(&buf, "  %s [%s];\n", strconv.Quote(n.ID), attrs)
+	}
+
+	for _, e := range p.Edges {
+		label := fmt.Sprintf("%s\n%s, %d unit(s)\n%s", e.TransferID, e.TransferDate.Format(dateLayout), e.Quantity, e.Status)
+		if !e.ReceiveDate.IsZero() {
+			label += " " + e.ReceiveDate.Format(dateLayout)
+		}
+		style, ok := edgeStyles[e.Status]
+		if !ok {
+			style = "solid"
+		}
+		fmt.Fprintf(&buf, "  %s -> %s [label=%s, style=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(label), style)
+	}
+
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
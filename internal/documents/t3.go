@@ -0,0 +1,148 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/go-pdf/fpdf"
+)
+
+// Page layout on A4 portrait, in millimetres.
+const (
+	pageMargin  = 15.0
+	contentW    = 180.0
+	lineHeight  = 5.0
+	headingGap  = 3.0
+	dateLayout  = "2006-01-02"
+	stampLayout = "2006-01-02 15:04:05 MST"
+)
+
+// T3PDF renders the transaction information, history and statement of a transfer as a PDF.
+// The signed JSON document is embedded as a file attachment so the signature shown on the last page
+// can be verified against exactly what was signed.
+func T3PDF(doc entity.T3Document) ([]byte, error) {
+	signed, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal T3 documents: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Transaction information, history and statement for transfer "+doc.TransferID, true)
+	pdf.SetCreator("MedTraceAPI", true)
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.AliasNbPages("")
+	pdf.SetAttachments([]fpdf.Attachment{{
+		Content:     signed,
+		Filename:    "t3-" + doc.TransferID + ".json",
+		Description: "Signed T3 documents",
+	}})
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pageMargin + 5)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, lineHeight, tr(fmt.Sprintf("Transfer %s - page %d/{nb}", doc.TransferID, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	heading := func(text string) {
+		pdf.Ln(headingGap)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(contentW, lineHeight+2, tr(text), "B", 1, "L", false, 0, "")
+		pdf.Ln(1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+	field := func(label, value string) {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(40, lineHeight, tr(label), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.MultiCell(contentW-40, lineHeight, tr(value), "", "L", false)
+	}
+	party := func(p entity.T3Party) string {
+		s := p.Name + " (" + p.ID + ")"
+		if p.Location != "" {
+			s += ", " + p.Location
+		}
+		return s
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(contentW, 10, tr("Product Tracing Documents"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	field("Transfer", doc.TransferID)
+	field("Generated", doc.GeneratedAt.Format(stampLayout))
+	field("Retain until", doc.RetainUntil.Format(dateLayout))
+
+	info := doc.Information
+	heading("Transaction Information")
+	field("Transaction date", info.TransactionDate.Format(dateLayout))
+	field("Seller", party(info.Seller))
+	field("Buyer", party(info.Buyer))
+	if info.SSCC != "" {
+		field("SSCC", info.SSCC)
+	}
+	for _, p := range info.Products {
+		pdf.Ln(2)
+		field("Product", p.DrugName)
+		if p.GTIN != "" {
+			field("GTIN", p.GTIN)
+		}
+		field("Lot", p.LotNumber)
+		field("Expiry", p.ExpiryDate.Format(dateLayout))
+		field("Manufacturer", p.ManufacturerName)
+		field("Quantity", fmt.Sprintf("%d", p.Quantity))
+		field("Drug IDs", strings.Join(p.DrugsID, ", "))
+	}
+
+	heading("Transaction History")
+	if len(doc.History) == 0 {
+		pdf.MultiCell(contentW, lineHeight, tr("No prior change of ownership: the seller is the original owner of all products."), "", "L", false)
+	}
+	for i, h := range doc.History {
+		if i > 0 {
+			pdf.Ln(2)
+		}
+		field("Transfer", h.TransferID)
+		field("Transaction date", h.TransactionDate.Format(dateLayout))
+		if !h.ReceiveDate.IsZero() {
+			field("Received", h.ReceiveDate.Format(dateLayout))
+		}
+		field("Seller", party(h.Seller))
+		field("Buyer", party(h.Buyer))
+		field("Quantity", fmt.Sprintf("%d", h.Quantity))
+	}
+
+	heading("Transaction Statement")
+	field("Seller", doc.Statement.SellerID)
+	for _, st := range doc.Statement.Statements {
+		pdf.MultiCell(contentW, lineHeight, tr("- "+st), "", "L", false)
+	}
+
+	heading("Signature")
+	if sig := doc.Signature; sig != nil {
+		field("Algorithm", sig.Algorithm)
+		field("Signer", sig.SignerOrgID+" ("+sig.SignerMSPID+")")
+		field("Signed at", sig.SignedAt.Format(stampLayout))
+		field("SHA-256 digest", sig.Digest)
+		field("Signature", sig.Value)
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.MultiCell(contentW, 4, tr(fmt.Sprintf("The signature covers the attached t3-%s.json without its Signature field, as compact JSON with "+
+			"fields in the order of the attachment. The signer certificate is included in the attachment; "+
+			"check both with: verify t3 -msp MSPID=dir t3-%s.json", doc.TransferID, doc.TransferID)), "", "L", false)
+	} else {
+		pdf.MultiCell(contentW, lineHeight, tr("Unsigned."), "", "L", false)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,224 @@
+package epcis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
+)
+
+// MaxQuantity caps the class-level quantity of a commissioning event, and the batch amount it adds up to.
+const MaxQuantity = 1_000_000_000
+
+// Submission is one ledger write an incoming event maps onto. Exactly one of the requests is set.
+type Submission struct {
+	Batch    *batch.CreateBatch
+	Drugs    *drug.BulkCreateDrugRequest
+	Transfer *transfer.CreateTransferRequest
+}
+
+// Plan is the outcome of mapping one event of a captured document.
+type Plan struct {
+	Index       int
+	Event       Event
+	Submissions []Submission
+	Skip        string // Why the event has no ledger equivalent, if it is ignored
+	Err         error  // Why the event cannot be applied
+}
+
+// PlanCapture maps the events of doc onto ledger submissions made by orgID, in document order:
+//   - commissioning ObjectEvents create the batch named by the ILMD lot number (when product name and
+//     expiry are given) and the drugs in the epcList
+//   - shipping TransactionEvents and ObjectEvents create a transfer to the owning party in the destinationList;
+//     SSCCs packed by earlier AggregationEvents in the document are expanded to their children, and a shipping
+//     unit packed for the same business transaction becomes the transfer's SSCC
+//
+// Other events are skipped. Nothing is submitted here.
+func PlanCapture(doc Document, orgID string) []Plan {
+	packed := make(map[string][]string) // SSCC -> child EPCs
+	unitOf := make(map[string]string)   // business transaction -> SSCC packed for it
+	plans := make([]Plan, 0, len(doc.Body.EventList))
+	for i, e := range doc.Body.EventList {
+		p := Plan{Index: i, Event: e}
+		bizStep := vocabulary(e.BizStep)
+		switch {
+		case e.Type == TypeObjectEvent && e.Action == ActionAdd && bizStep == BizStepCommissioning:
+			p.Submissions, p.Err = planCommissioning(e, orgID)
+		case (e.Type == TypeTransactionEvent || e.Type == TypeObjectEvent) && e.Action != ActionDelete && bizStep == BizStepShipping:
+			var sub Submission
+			sub, p.Err = planShipping(e, orgID, packed, unitOf)
+			if p.Err == nil {
+				p.Submissions = []Submission{sub}
+			}
+		case e.Type == TypeAggregationEvent && e.Action == ActionAdd && SSCCFromEPC(e.ParentID) != "":
+			sscc := SSCCFromEPC(e.ParentID)
+			packed[sscc] = append(packed[sscc], e.ChildEPCs...)
+			for _, bt := range e.BizTransactionList {
+				unitOf[bt.BizTransaction] = sscc
+			}
+			p.Skip = fmt.Sprintf("Recorded the contents of shipping unit %s for later shipping events", sscc)
+		default:
+			p.Skip = fmt.Sprintf("No ledger equivalent for %s %s with bizStep '%s'", e.Type, e.Action, e.BizStep)
+		}
+		plans = append(plans, p)
+	}
+	return plans
+}
+
+func planCommissioning(e Event, orgID string) ([]Submission, error) {
+	lot, gtin := "", ""
+	if e.ILMD != nil {
+		lot = e.ILMD.LotNumber
+	}
+	for _, q := range e.QuantityList {
+		if ids, err := gs1.ParseDigitalLink(q.EPCClass); err == nil {
+			gtin = ids.GTIN
+			if lot == "" {
+				lot = ids.Lot
+			}
+		}
+	}
+
+	drugsID := make([]string, 0, len(e.EPCList))
+	for _, epc := range e.EPCList {
+		id, err := DrugIDFromEPC(epc)
+		if err != nil {
+			return nil, err
+		}
+		drugsID = append(drugsID, id)
+		if sgtin, err := gs1.ParseSGTIN(id); err == nil && gtin == "" {
+			gtin = sgtin.GTIN
+		}
+	}
+	if lot == "" {
+		return nil, fmt.Errorf("commissioning event has no lot number in its ILMD or quantityList")
+	}
+
+	var subs []Submission
+	if e.ILMD != nil && e.ILMD.RegulatedProductName != "" && e.ILMD.ItemExpirationDate != "" {
+		eventTime, err := e.Time()
+		if err != nil {
+			return nil, fmt.Errorf("invalid eventTime '%s': %w", e.EventTime, err)
+		}
+		expiry, err := time.Parse("2006-01-02", e.ILMD.ItemExpirationDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid itemExpirationDate '%s', expected YYYY-MM-DD", e.ILMD.ItemExpirationDate)
+		}
+		amount := len(drugsID)
+		for _, q := range e.QuantityList {
+			if q.Quantity < 0 || q.Quantity > MaxQuantity || q.Quantity != math.Trunc(q.Quantity) {
+				return nil, fmt.Errorf("quantity %v of %s must be a whole number between 0 and %d", q.Quantity, q.EPCClass, MaxQuantity)
+			}
+			amount += int(q.Quantity)
+		}
+		if amount > MaxQuantity {
+			return nil, fmt.Errorf("commissioning event for lot %s adds up to %d units, the maximum is %d", lot, amount, MaxQuantity)
+		}
+		subs = append(subs, Submission{Batch: &batch.CreateBatch{
+			ID:             lot,
+			DrugName:       e.ILMD.RegulatedProductName,
+			ExpiryDate:     expiry,
+			ProductionDate: eventTime,
+			Amount:         amount,
+			GTIN:           gtin,
+		}})
+	}
+	if len(drugsID) > 0 {
+		subs = append(subs, Submission{Drugs: &drug.BulkCreateDrugRequest{OwnerID: orgID, BatchID: lot, DrugsID: drugsID}})
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("commissioning event for lot %s has neither an epcList nor product name and expiry date", lot)
+	}
+	return subs, nil
+}
+
+func planShipping(e Event, orgID string, packed map[string][]string, unitOf map[string]string) (Submission, error) {
+	for _, s := range e.SourceList {
+		if vocabulary(s.Type) != PartyOwning {
+			continue
+		}
+		if sender, err := OrgIDFromParty(s.Source); err != nil || sender != orgID {
+			return Submission{}, fmt.Errorf("source party '%s' is not the caller's organization %s", s.Source, orgID)
+		}
+	}
+	receiverID := ""
+	for _, d := range e.DestinationList {
+		if vocabulary(d.Type) == PartyOwning {
+			id, err := OrgIDFromParty(d.Destination)
+			if err != nil {
+				return Submission{}, err
+			}
+			receiverID = id
+		}
+	}
+	if receiverID == "" {
+		return Submission{}, fmt.Errorf("shipping event has no owning_party destination")
+	}
+	eventTime, err := e.Time()
+	if err != nil {
+		return Submission{}, fmt.Errorf("invalid eventTime '%s': %w", e.EventTime, err)
+	}
+
+	sscc := SSCCFromEPC(e.ParentID)
+	epcs := e.EPCList
+	if len(epcs) == 0 && sscc != "" {
+		epcs = packed[sscc]
+	}
+	var drugsID []string
+	for _, epc := range epcs {
+		if unit := SSCCFromEPC(epc); unit != "" {
+			children, ok := packed[unit]
+			if !ok {
+				return Submission{}, fmt.Errorf("shipping unit %s was not packed earlier in the document", unit)
+			}
+			if sscc == "" {
+				sscc = unit
+			}
+			for _, child := range children {
+				id, err := DrugIDFromEPC(child)
+				if err != nil {
+					return Submission{}, err
+				}
+				drugsID = append(drugsID, id)
+			}
+			continue
+		}
+		id, err := DrugIDFromEPC(epc)
+		if err != nil {
+			return Submission{}, err
+		}
+		drugsID = append(drugsID, id)
+	}
+	if len(drugsID) == 0 {
+		return Submission{}, fmt.Errorf("shipping event has no drugs")
+	}
+	for _, bt := range e.BizTransactionList {
+		if unit, ok := unitOf[bt.BizTransaction]; ok && sscc == "" {
+			sscc = unit
+		}
+	}
+
+	return Submission{Transfer: &transfer.CreateTransferRequest{
+		DrugsID:      drugsID,
+		ReceiverID:   receiverID,
+		TransferDate: &eventTime,
+		SSCC:         sscc,
+	}}, nil
+}
+
+// vocabulary reduces a CBV value in any of its forms ("shipping", "urn:epcglobal:cbv:bizstep:shipping",
+// "https://ref.gs1.org/cbv/BizStep-shipping") to its bare name.
+func vocabulary(value string) string {
+	if i := strings.LastIndexAny(value, ":/"); i >= 0 {
+		value = value[i+1:]
+	}
+	if i := strings.Index(value, "-"); i >= 0 {
+		value = value[i+1:]
+	}
+	return value
+}
@@ -0,0 +1,223 @@
+package epcis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+)
+
+// Records is the ledger data events are derived from.
+type Records struct {
+	Batches   map[string]entity.Batch         // Batches by ID
+	Histories map[string][]entity.HistoryDrug // Key history of each drug, by drug ID
+	Transfers map[string]entity.Transfer      // Transfers by ID
+}
+
+// Events derives the EPCIS events recorded by the ledger for the given records, ordered by event time.
+//
+// Each batch yields a class-level commissioning ObjectEvent. Each drug history is walked record by record and
+// every state change becomes an event; drugs changed by the same ledger transaction share one event:
+//   - the first record commissions the drug (ObjectEvent ADD, commissioning)
+//   - isTransferred becoming true ships it (TransactionEvent ADD, shipping)
+//   - isTransferred becoming false with a new owner receives it (TransactionEvent OBSERVE, receiving)
+//   - isTransferred becoming false with the same owner voids the shipment; the disposition is "returned"
+//     for rejected drugs and "active" for cancelled or expired transfers (TransactionEvent DELETE, void_shipping)
+//   - a delete record decommissions it (ObjectEvent DELETE, decommissioning)
+//
+// Shipments of transfers with an SSCC are also packed into and unpacked from the shipping unit (AggregationEvent).
+func Events(r Records) []Event {
+	b := &builder{records: r, byKey: make(map[string]*Event)}
+	for _, batch := range r.Batches {
+		b.batchCommissioning(batch)
+	}
+	for drugID, history := range r.Histories {
+		b.drugHistory(drugID, history)
+	}
+
+	events := make([]Event, 0, len(b.order))
+	for _, key := range b.order {
+		e := b.byKey[key]
+		sort.Strings(e.EPCList)
+		sort.Strings(e.ChildEPCs)
+		events = append(events, *e)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].EventTime != events[j].EventTime {
+			return events[i].EventTime < events[j].EventTime
+		}
+		return events[i].EventID < events[j].EventID
+	})
+	return events
+}
+
+// builder accumulates events, merging drugs changed by the same transaction into one event per key.
+type builder struct {
+	records Records
+	byKey   map[string]*Event
+	order   []string
+}
+
+func (b *builder) event(key string, t time.Time, init func(e *Event)) *Event {
+	if e, ok := b.byKey[key]; ok {
+		return e
+	}
+	e := &Event{
+		EventTime:           t.UTC().Format(time.RFC3339),
+		EventTimeZoneOffset: "+00:00",
+		EventID:             urnEvent + key,
+	}
+	init(e)
+	b.byKey[key] = e
+	b.order = append(b.order, key)
+	return e
+}
+
+func (b *builder) batchCommissioning(batch entity.Batch) {
+	b.event("batch:"+batch.ID+":commissioning", batch.ProductionDate, func(e *Event) {
+		e.Type = TypeObjectEvent
+		e.Action = ActionAdd
+		e.BizStep = BizStepCommissioning
+		e.Disposition = DispositionActive
+		e.QuantityList = []Quantity{{EPCClass: BatchClass(batch.ID, batch.GTIN)}}
+		e.ILMD = batchILMD(batch)
+	})
+}
+
+func (b *builder) drugHistory(drugID string, history []entity.HistoryDrug) {
+	records := make([]entity.HistoryDrug, len(history))
+	copy(records, history)
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+
+	epc := DrugEPC(drugID)
+	var prev *entity.Drug
+	for _, rec := range records {
+		if rec.IsDelete || rec.Drug == nil {
+			e := b.event(rec.TxID+":decommissioning", rec.Timestamp, func(e *Event) {
+				e.Type = TypeObjectEvent
+				e.Action = ActionDelete
+				e.BizStep = BizStepDecommissioning
+				e.Disposition = DispositionInactive
+			})
+			e.EPCList = append(e.EPCList, epc)
+			prev = nil
+			continue
+		}
+		cur := rec.Drug
+
+		switch {
+		case prev == nil:
+			e := b.event(rec.TxID+":commissioning", rec.Timestamp, func(e *Event) {
+				e.Type = TypeObjectEvent
+				e.Action = ActionAdd
+				e.BizStep = BizStepCommissioning
+				e.Disposition = DispositionActive
+				e.ILMD = &ILMD{LotNumber: cur.BatchID}
+				if batch, ok := b.records.Batches[cur.BatchID]; ok {
+					e.ILMD = batchILMD(batch)
+				}
+			})
+			e.EPCList = append(e.EPCList, epc)
+		case !prev.IsTransferred && cur.IsTransferred:
+			b.shipment(rec, cur.TransferID, prev.OwnerID, epc)
+		case prev.IsTransferred && !cur.IsTransferred:
+			if cur.OwnerID != prev.OwnerID {
+				b.receipt(rec, prev.TransferID, prev.OwnerID, cur.OwnerID, epc)
+			} else {
+				b.voided(rec, prev.TransferID, cur.OwnerID, epc)
+			}
+		}
+		prev = cur
+	}
+}
+
+func (b *builder) shipment(rec entity.HistoryDrug, transferID, senderID, epc string) {
+	t := b.records.Transfers[transferID]
+	e := b.event(rec.TxID+":shipping:"+transferID, rec.Timestamp, func(e *Event) {
+		e.Type = TypeTransactionEvent
+		e.Action = ActionAdd
+		e.BizStep = BizStepShipping
+		e.Disposition = DispositionInTransit
+		e.BizTransactionList = transferBizTransactions(transferID)
+		e.SourceList = []Source{{Type: PartyOwning, Source: PartyID(senderID)}}
+		if t.ReceiverID != "" {
+			e.DestinationList = []Destination{{Type: PartyOwning, Destination: PartyID(t.ReceiverID)}}
+		}
+	})
+	e.EPCList = append(e.EPCList, epc)
+	b.aggregation(rec, transferID, t.SSCC, ActionAdd, BizStepPacking, epc)
+}
+
+func (b *builder) receipt(rec entity.HistoryDrug, transferID, senderID, receiverID, epc string) {
+	e := b.event(rec.TxID+":receiving:"+transferID, rec.Timestamp, func(e *Event) {
+		e.Type = TypeTransactionEvent
+		e.Action = ActionObserve
+		e.BizStep = BizStepReceiving
+		e.Disposition = DispositionActive
+		e.BizTransactionList = transferBizTransactions(transferID)
+		e.SourceList = []Source{{Type: PartyOwning, Source: PartyID(senderID)}}
+		e.DestinationList = []Destination{{Type: PartyOwning, Destination: PartyID(receiverID)}}
+	})
+	e.EPCList = append(e.EPCList, epc)
+	b.aggregation(rec, transferID, b.records.Transfers[transferID].SSCC, ActionDelete, BizStepUnpacking, epc)
+}
+
+func (b *builder) voided(rec entity.HistoryDrug, transferID, ownerID, epc string) {
+	t := b.records.Transfers[transferID]
+	disposition := DispositionReturned
+	if t.IsCancelled || t.IsExpired {
+		disposition = DispositionActive
+	}
+	e := b.event(rec.TxID+":void_shipping:"+transferID, rec.Timestamp, func(e *Event) {
+		e.Type = TypeTransactionEvent
+		e.Action = ActionDelete
+		e.BizStep = BizStepVoidShipping
+		e.Disposition = disposition
+		e.BizTransactionList = transferBizTransactions(transferID)
+		e.DestinationList = []Destination{{Type: PartyOwning, Destination: PartyID(ownerID)}}
+	})
+	e.EPCList = append(e.EPCList, epc)
+	b.aggregation(rec, transferID, t.SSCC, ActionDelete, BizStepUnpacking, epc)
+}
+
+// aggregation adds epc to the packing or unpacking event of the transfer's shipping unit, if it has one.
+func (b *builder) aggregation(rec entity.HistoryDrug, transferID, sscc, action, bizStep, epc string) {
+	if sscc == "" {
+		return
+	}
+	e := b.event(rec.TxID+":"+bizStep+":"+transferID, rec.Timestamp, func(e *Event) {
+		e.Type = TypeAggregationEvent
+		e.ParentID = SSCCEPC(sscc)
+		e.Action = action
+		e.BizStep = bizStep
+		e.BizTransactionList = transferBizTransactions(transferID)
+	})
+	e.ChildEPCs = append(e.ChildEPCs, epc)
+}
+
+func transferBizTransactions(transferID string) []BizTransaction {
+	return []BizTransaction{{Type: BizTransactionDespatchAdvice, BizTransaction: TransferBizTransaction(transferID)}}
+}
+
+func batchILMD(batch entity.Batch) *ILMD {
+	ilmd := &ILMD{LotNumber: batch.ID, RegulatedProductName: batch.DrugName}
+	if !batch.ExpiryDate.IsZero() {
+		ilmd.ItemExpirationDate = batch.ExpiryDate.Format("2006-01-02")
+	}
+	return ilmd
+}
+
+// ForTransfer keeps only the events that belong to the given transfer.
+func ForTransfer(events []Event, transferID string) []Event {
+	want := TransferBizTransaction(transferID)
+	var kept []Event
+	for _, e := range events {
+		for _, bt := range e.BizTransactionList {
+			if bt.BizTransaction == want {
+				kept = append(kept, e)
+				break
+			}
+		}
+	}
+	return kept
+}
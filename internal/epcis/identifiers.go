@@ -0,0 +1,114 @@
+package epcis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+)
+
+// CanonicalDigitalLinkBase is the GS1 resolver used for identifiers in exported events, as recommended by EPCIS 2.0.
+const CanonicalDigitalLinkBase = "https://id.gs1.org"
+
+// Private URN prefixes for MedTrace objects that have no GS1 key.
+const (
+	urnDrug     = "urn:medtrace:drug:"
+	urnBatch    = "urn:medtrace:batch:"
+	urnOrg      = "urn:medtrace:org:"
+	urnTransfer = "urn:medtrace:transfer:"
+	urnEvent    = "urn:medtrace:event:"
+	urnSGTIN    = "urn:epc:id:sgtin:"
+)
+
+// DrugEPC returns the instance identifier of a drug: a GS1 Digital Link URI for SGTIN drug IDs,
+// otherwise a MedTrace URN.
+func DrugEPC(drugID string) string {
+	if sgtin, err := gs1.ParseSGTIN(drugID); err == nil {
+		return gs1.Identifiers{GTIN: sgtin.GTIN, Serial: sgtin.Serial}.DigitalLink(CanonicalDigitalLinkBase)
+	}
+	return urnDrug + drugID
+}
+
+// BatchClass returns the class identifier of a batch: GTIN plus lot when the batch has a GTIN, otherwise a MedTrace URN.
+func BatchClass(batchID, gtin string) string {
+	if gtin != "" {
+		return gs1.Identifiers{GTIN: gtin, Lot: batchID}.DigitalLink(CanonicalDigitalLinkBase)
+	}
+	return urnBatch + batchID
+}
+
+// SSCCEPC returns the Digital Link URI of a shipping unit.
+func SSCCEPC(sscc string) string {
+	return CanonicalDigitalLinkBase + "/" + gs1.AISSCC + "/" + sscc
+}
+
+// PartyID returns the owning party identifier of an organization.
+func PartyID(orgID string) string {
+	return urnOrg + orgID
+}
+
+// TransferBizTransaction returns the business transaction identifier of a transfer.
+func TransferBizTransaction(transferID string) string {
+	return urnTransfer + transferID
+}
+
+// DrugIDFromEPC maps an instance identifier back to a drug ID. It accepts MedTrace URNs,
+// GS1 Digital Link URIs with GTIN and serial, and SGTIN EPC URNs such as "urn:epc:id:sgtin:0614141.812345.6789".
+func DrugIDFromEPC(epc string) (string, error) {
+	switch {
+	case strings.HasPrefix(epc, urnDrug):
+		return strings.TrimPrefix(epc, urnDrug), nil
+	case strings.HasPrefix(epc, urnSGTIN):
+		return parseSGTINURN(epc)
+	case strings.HasPrefix(epc, "http://") || strings.HasPrefix(epc, "https://"):
+		ids, err := gs1.ParseDigitalLink(epc)
+		if err != nil {
+			return "", err
+		}
+		sgtin, ok := ids.SGTIN()
+		if !ok {
+			return "", fmt.Errorf("EPC '%s' does not identify a single pack", epc)
+		}
+		return sgtin.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported EPC '%s'", epc)
+	}
+}
+
+// SSCCFromEPC returns the SSCC of a shipping unit identifier, or "" if epc is not one.
+func SSCCFromEPC(epc string) string {
+	if !strings.HasPrefix(epc, "http://") && !strings.HasPrefix(epc, "https://") {
+		return ""
+	}
+	ids, err := gs1.ParseDigitalLink(epc)
+	if err != nil {
+		return ""
+	}
+	return ids.SSCC
+}
+
+// OrgIDFromParty maps an owning party identifier back to an organization ID.
+func OrgIDFromParty(party string) (string, error) {
+	if !strings.HasPrefix(party, urnOrg) || party == urnOrg {
+		return "", fmt.Errorf("unsupported party '%s', expected %s<organization ID>", party, urnOrg)
+	}
+	return strings.TrimPrefix(party, urnOrg), nil
+}
+
+// parseSGTINURN converts "urn:epc:id:sgtin:<company prefix>.<indicator><item reference>.<serial>" to an SGTIN drug ID.
+func parseSGTINURN(epc string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(epc, urnSGTIN), ".", 3)
+	if len(parts) != 3 || len(parts[1]) == 0 || len(parts[0])+len(parts[1]) != 13 {
+		return "", fmt.Errorf("invalid SGTIN EPC URN '%s'", epc)
+	}
+	digits := parts[1][:1] + parts[0] + parts[1][1:]
+	check, err := gs1.CheckDigit(digits)
+	if err != nil {
+		return "", fmt.Errorf("invalid SGTIN EPC URN '%s': %w", epc, err)
+	}
+	sgtin, err := gs1.NewSGTIN(digits+string(check), parts[2])
+	if err != nil {
+		return "", err
+	}
+	return sgtin.String(), nil
+}
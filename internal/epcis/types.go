@@ -0,0 +1,126 @@
+package epcis
+
+import (
+	"time"
+)
+
+// Document and event constants from EPCIS 2.0 and the Core Business Vocabulary (CBV) 2.0.
+const (
+	ContextURL    = "https://ref.gs1.org/standards/epcis/2.0.0/epcis-context.jsonld"
+	XMLNamespace  = "urn:epcglobal:epcis:xsd:2"
+	SchemaVersion = "2.0"
+
+	TypeObjectEvent      = "ObjectEvent"
+	TypeAggregationEvent = "AggregationEvent"
+	TypeTransactionEvent = "TransactionEvent"
+
+	ActionAdd     = "ADD"
+	ActionObserve = "OBSERVE"
+	ActionDelete  = "DELETE"
+
+	BizStepCommissioning   = "commissioning"
+	BizStepDecommissioning = "decommissioning"
+	BizStepPacking         = "packing"
+	BizStepUnpacking       = "unpacking"
+	BizStepShipping        = "shipping"
+	BizStepReceiving       = "receiving"
+	BizStepVoidShipping    = "void_shipping"
+
+	DispositionActive    = "active"
+	DispositionInTransit = "in_transit"
+	DispositionInactive  = "inactive"
+	DispositionReturned  = "returned"
+
+	BizTransactionDespatchAdvice = "desadv"
+	PartyOwning                  = "owning_party"
+)
+
+// Document is an EPCIS 2.0 document in its JSON-LD form.
+type Document struct {
+	Context       []string `json:"@context"`
+	Type          string   `json:"type"`
+	SchemaVersion string   `json:"schemaVersion"`
+	CreationDate  string   `json:"creationDate"`
+	Body          Body     `json:"epcisBody"`
+}
+
+// Body holds the events of a document.
+type Body struct {
+	EventList []Event `json:"eventList"`
+}
+
+// NewDocument wraps events in an EPCIS 2.0 document created now.
+func NewDocument(events []Event) Document {
+	if events == nil {
+		events = []Event{}
+	}
+	return Document{
+		Context:       []string{ContextURL},
+		Type:          "EPCISDocument",
+		SchemaVersion: SchemaVersion,
+		CreationDate:  time.Now().UTC().Format(time.RFC3339),
+		Body:          Body{EventList: events},
+	}
+}
+
+// Event is an ObjectEvent, AggregationEvent or TransactionEvent; Type selects which fields apply.
+type Event struct {
+	Type                string           `json:"type"`
+	EventTime           string           `json:"eventTime"`
+	EventTimeZoneOffset string           `json:"eventTimeZoneOffset"`
+	EventID             string           `json:"eventID,omitempty"`
+	ParentID            string           `json:"parentID,omitempty"`
+	EPCList             []string         `json:"epcList,omitempty"`
+	ChildEPCs           []string         `json:"childEPCs,omitempty"`
+	Action              string           `json:"action"`
+	BizStep             string           `json:"bizStep,omitempty"`
+	Disposition         string           `json:"disposition,omitempty"`
+	ReadPoint           *Location        `json:"readPoint,omitempty"`
+	BizLocation         *Location        `json:"bizLocation,omitempty"`
+	BizTransactionList  []BizTransaction `json:"bizTransactionList,omitempty"`
+	QuantityList        []Quantity       `json:"quantityList,omitempty"`
+	SourceList          []Source         `json:"sourceList,omitempty"`
+	DestinationList     []Destination    `json:"destinationList,omitempty"`
+	ILMD                *ILMD            `json:"ilmd,omitempty"`
+}
+
+// Location is a read point or business location.
+type Location struct {
+	ID string `json:"id"`
+}
+
+// BizTransaction links an event to a business transaction such as a MedTrace transfer.
+type BizTransaction struct {
+	Type           string `json:"type,omitempty"`
+	BizTransaction string `json:"bizTransaction"`
+}
+
+// Quantity is a class-level quantity, e.g. the units of a batch.
+type Quantity struct {
+	EPCClass string  `json:"epcClass"`
+	Quantity float64 `json:"quantity,omitempty"`
+}
+
+// Source is the party or location goods come from.
+type Source struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+}
+
+// Destination is the party or location goods go to.
+type Destination struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+}
+
+// ILMD is the instance/lot master data recorded at commissioning.
+type ILMD struct {
+	LotNumber            string `json:"cbvmda:lotNumber,omitempty"`
+	ItemExpirationDate   string `json:"cbvmda:itemExpirationDate,omitempty"`
+	RegulatedProductName string `json:"cbvmda:regulatedProductName,omitempty"`
+}
+
+// Time parses the event time.
+func (e Event) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, e.EventTime)
+}
@@ -0,0 +1,313 @@
+package epcis
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// The EPCIS 2.0 XML binding fixes the order of child elements, which differs between event types,
+// so each type has its own encoding struct. Decoding is order-independent and uses xmlEvent for all of them.
+
+type xmlLocation struct {
+	ID string `xml:"id"`
+}
+
+type xmlTyped struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type xmlQuantity struct {
+	EPCClass string  `xml:"epcClass"`
+	Quantity float64 `xml:"quantity,omitempty"`
+}
+
+type xmlBizTransactionList struct {
+	Items []xmlTyped `xml:"bizTransaction"`
+}
+
+type xmlQuantityList struct {
+	Items []xmlQuantity `xml:"quantityElement"`
+}
+
+type xmlSourceList struct {
+	Items []xmlTyped `xml:"source"`
+}
+
+type xmlDestinationList struct {
+	Items []xmlTyped `xml:"destination"`
+}
+
+type xmlILMD struct {
+	LotNumber            string `xml:"urn:epcglobal:cbv:mda lotNumber,omitempty"`
+	ItemExpirationDate   string `xml:"urn:epcglobal:cbv:mda itemExpirationDate,omitempty"`
+	RegulatedProductName string `xml:"urn:epcglobal:cbv:mda regulatedProductName,omitempty"`
+}
+
+type xmlHead struct {
+	EventTime           string `xml:"eventTime"`
+	EventTimeZoneOffset string `xml:"eventTimeZoneOffset"`
+	EventID             string `xml:"eventID,omitempty"`
+}
+
+type xmlWhy struct {
+	Action      string       `xml:"action"`
+	BizStep     string       `xml:"bizStep,omitempty"`
+	Disposition string       `xml:"disposition,omitempty"`
+	ReadPoint   *xmlLocation `xml:"readPoint,omitempty"`
+	BizLocation *xmlLocation `xml:"bizLocation,omitempty"`
+}
+
+type xmlObjectEvent struct {
+	xmlHead
+	EPCList []string `xml:"epcList>epc"`
+	xmlWhy
+	BizTransactionList *xmlBizTransactionList `xml:"bizTransactionList,omitempty"`
+	QuantityList       *xmlQuantityList       `xml:"quantityList,omitempty"`
+	SourceList         *xmlSourceList         `xml:"sourceList,omitempty"`
+	DestinationList    *xmlDestinationList    `xml:"destinationList,omitempty"`
+	ILMD               *xmlILMD               `xml:"ilmd,omitempty"`
+}
+
+type xmlAggregationEvent struct {
+	xmlHead
+	ParentID  string   `xml:"parentID,omitempty"`
+	ChildEPCs []string `xml:"childEPCs>epc"`
+	xmlWhy
+	BizTransactionList *xmlBizTransactionList `xml:"bizTransactionList,omitempty"`
+	SourceList         *xmlSourceList         `xml:"sourceList,omitempty"`
+	DestinationList    *xmlDestinationList    `xml:"destinationList,omitempty"`
+}
+
+type xmlTransactionEvent struct {
+	xmlHead
+	BizTransactionList *xmlBizTransactionList `xml:"bizTransactionList"`
+	ParentID           string                 `xml:"parentID,omitempty"`
+	EPCList            []string               `xml:"epcList>epc"`
+	xmlWhy
+	QuantityList    *xmlQuantityList    `xml:"quantityList,omitempty"`
+	SourceList      *xmlSourceList      `xml:"sourceList,omitempty"`
+	DestinationList *xmlDestinationList `xml:"destinationList,omitempty"`
+}
+
+// xmlEvent accepts the children of any event type when decoding.
+type xmlEvent struct {
+	xmlHead
+	ParentID           string        `xml:"parentID"`
+	EPCList            []string      `xml:"epcList>epc"`
+	ChildEPCs          []string      `xml:"childEPCs>epc"`
+	BizTransactionList []xmlTyped    `xml:"bizTransactionList>bizTransaction"`
+	QuantityList       []xmlQuantity `xml:"quantityList>quantityElement"`
+	SourceList         []xmlTyped    `xml:"sourceList>source"`
+	DestinationList    []xmlTyped    `xml:"destinationList>destination"`
+	ILMD               *xmlILMD      `xml:"ilmd"`
+	xmlWhy
+}
+
+type xmlDocument struct {
+	XMLName       xml.Name     `xml:"EPCISDocument"`
+	SchemaVersion string       `xml:"schemaVersion,attr"`
+	CreationDate  string       `xml:"creationDate,attr"`
+	EventList     xmlEventList `xml:"EPCISBody>EventList"`
+}
+
+type xmlEventList []Event
+
+// EncodeXML renders doc in the EPCIS 2.0 XML binding.
+func EncodeXML(doc Document) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+
+	root := xml.StartElement{
+		// The root element is namespace-qualified while its children are not, so the prefix is written literally.
+		Name: xml.Name{Local: "epcis:EPCISDocument"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns:epcis"}, Value: XMLNamespace},
+			{Name: xml.Name{Local: "schemaVersion"}, Value: doc.SchemaVersion},
+			{Name: xml.Name{Local: "creationDate"}, Value: doc.CreationDate},
+		},
+	}
+	body := struct {
+		EventList xmlEventList `xml:"EventList"`
+	}{EventList: doc.Body.EventList}
+
+	if err := enc.EncodeToken(root); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeElement(body, xml.StartElement{Name: xml.Name{Local: "EPCISBody"}}); err != nil {
+		return nil, fmt.Errorf("failed to encode EPCIS events: %w", err)
+	}
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeXML parses an EPCIS 2.0 XML document. Events of unsupported types keep their element name as Type.
+func DecodeXML(data []byte) (Document, error) {
+	var doc xmlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return Document{}, fmt.Errorf("invalid EPCIS XML document: %w", err)
+	}
+	return Document{
+		Context:       []string{ContextURL},
+		Type:          "EPCISDocument",
+		SchemaVersion: doc.SchemaVersion,
+		CreationDate:  doc.CreationDate,
+		Body:          Body{EventList: doc.EventList},
+	}, nil
+}
+
+// MarshalXML writes each event as an element named after its type.
+func (l xmlEventList) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, e := range l {
+		var v any
+		head := xmlHead{EventTime: e.EventTime, EventTimeZoneOffset: e.EventTimeZoneOffset, EventID: e.EventID}
+		why := xmlWhy{Action: e.Action, BizStep: e.BizStep, Disposition: e.Disposition,
+			ReadPoint: toXMLLocation(e.ReadPoint), BizLocation: toXMLLocation(e.BizLocation)}
+		switch e.Type {
+		case TypeObjectEvent:
+			v = xmlObjectEvent{xmlHead: head, EPCList: e.EPCList, xmlWhy: why,
+				BizTransactionList: bizTransactionsToXML(e.BizTransactionList), QuantityList: quantitiesToXML(e.QuantityList),
+				SourceList: sourcesToXML(e.SourceList), DestinationList: destinationsToXML(e.DestinationList), ILMD: ilmdToXML(e.ILMD)}
+		case TypeAggregationEvent:
+			v = xmlAggregationEvent{xmlHead: head, ParentID: e.ParentID, ChildEPCs: e.ChildEPCs, xmlWhy: why,
+				BizTransactionList: bizTransactionsToXML(e.BizTransactionList),
+				SourceList:         sourcesToXML(e.SourceList), DestinationList: destinationsToXML(e.DestinationList)}
+		case TypeTransactionEvent:
+			v = xmlTransactionEvent{xmlHead: head, BizTransactionList: bizTransactionsToXML(e.BizTransactionList),
+				ParentID: e.ParentID, EPCList: e.EPCList, xmlWhy: why, QuantityList: quantitiesToXML(e.QuantityList),
+				SourceList: sourcesToXML(e.SourceList), DestinationList: destinationsToXML(e.DestinationList)}
+		default:
+			return fmt.Errorf("unsupported EPCIS event type '%s'", e.Type)
+		}
+		if err := enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: e.Type}}); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads events of any type, recording the element name as the event type.
+func (l *xmlEventList) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var x xmlEvent
+			if err := dec.DecodeElement(&x, &t); err != nil {
+				return err
+			}
+			*l = append(*l, fromXMLEvent(t.Name.Local, x))
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func fromXMLEvent(eventType string, x xmlEvent) Event {
+	e := Event{
+		Type:                eventType,
+		EventTime:           x.EventTime,
+		EventTimeZoneOffset: x.EventTimeZoneOffset,
+		EventID:             x.EventID,
+		ParentID:            x.ParentID,
+		EPCList:             x.EPCList,
+		ChildEPCs:           x.ChildEPCs,
+		Action:              x.Action,
+		BizStep:             x.BizStep,
+		Disposition:         x.Disposition,
+	}
+	if x.ReadPoint != nil {
+		e.ReadPoint = &Location{ID: x.ReadPoint.ID}
+	}
+	if x.BizLocation != nil {
+		e.BizLocation = &Location{ID: x.BizLocation.ID}
+	}
+	for _, bt := range x.BizTransactionList {
+		e.BizTransactionList = append(e.BizTransactionList, BizTransaction{Type: bt.Type, BizTransaction: bt.Value})
+	}
+	for _, q := range x.QuantityList {
+		e.QuantityList = append(e.QuantityList, Quantity{EPCClass: q.EPCClass, Quantity: q.Quantity})
+	}
+	for _, s := range x.SourceList {
+		e.SourceList = append(e.SourceList, Source{Type: s.Type, Source: s.Value})
+	}
+	for _, d := range x.DestinationList {
+		e.DestinationList = append(e.DestinationList, Destination{Type: d.Type, Destination: d.Value})
+	}
+	if x.ILMD != nil {
+		e.ILMD = &ILMD{LotNumber: x.ILMD.LotNumber, ItemExpirationDate: x.ILMD.ItemExpirationDate, RegulatedProductName: x.ILMD.RegulatedProductName}
+	}
+	return e
+}
+
+func toXMLLocation(l *Location) *xmlLocation {
+	if l == nil {
+		return nil
+	}
+	return &xmlLocation{ID: l.ID}
+}
+
+func bizTransactionsToXML(list []BizTransaction) *xmlBizTransactionList {
+	if len(list) == 0 {
+		return nil
+	}
+	out := &xmlBizTransactionList{}
+	for _, bt := range list {
+		out.Items = append(out.Items, xmlTyped{Type: bt.Type, Value: bt.BizTransaction})
+	}
+	return out
+}
+
+func sourcesToXML(list []Source) *xmlSourceList {
+	if len(list) == 0 {
+		return nil
+	}
+	out := &xmlSourceList{}
+	for _, s := range list {
+		out.Items = append(out.Items, xmlTyped{Type: s.Type, Value: s.Source})
+	}
+	return out
+}
+
+func destinationsToXML(list []Destination) *xmlDestinationList {
+	if len(list) == 0 {
+		return nil
+	}
+	out := &xmlDestinationList{}
+	for _, d := range list {
+		out.Items = append(out.Items, xmlTyped{Type: d.Type, Value: d.Destination})
+	}
+	return out
+}
+
+func quantitiesToXML(list []Quantity) *xmlQuantityList {
+	if len(list) == 0 {
+		return nil
+	}
+	out := &xmlQuantityList{}
+	for _, q := range list {
+		out.Items = append(out.Items, xmlQuantity{EPCClass: q.EPCClass, Quantity: q.Quantity})
+	}
+	return out
+}
+
+func ilmdToXML(ilmd *ILMD) *xmlILMD {
+	if ilmd == nil {
+		return nil
+	}
+	return &xmlILMD{LotNumber: ilmd.LotNumber, ItemExpirationDate: ilmd.ItemExpirationDate, RegulatedProductName: ilmd.RegulatedProductName}
+}
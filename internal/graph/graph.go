@@ -0,0 +1,119 @@
+// Package graph serves a read-only GraphQL schema over organizations, batches, drugs, transfers and their
+// ledger history, so that clients can follow custody relationships in one request instead of chaining REST
+// calls. Related records are loaded through per-request loaders that collect the keys requested by every
+// item of a list and query the ledger for them together, once per key.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Services are the services the resolvers read the ledger with.
+type Services struct {
+	Organizations *services.OrganizationService
+	Batches       *services.BatchService
+	Drugs         *services.DrugService
+	Transfers     *services.TransferService
+}
+
+// Limits guard the peers against expensive queries.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields a query may select.
+	MaxDepth int
+	// MaxComplexity is the number of ledger queries a request may make. Records are queried at most once
+	// per request, so this bounds the number of distinct records and lists a query can reach.
+	MaxComplexity int
+	// MaxQueryLength is the longest query document accepted, in bytes.
+	MaxQueryLength int
+}
+
+// DefaultLimits are used for the limits not set by GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY.
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 500, MaxQueryLength: 16 << 10}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string         `json:"query" validate:"required"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Schema executes GraphQL requests against the services.
+type Schema struct {
+	schema *graphql.Schema
+	svc    Services
+	limits Limits
+}
+
+// NewSchema parses the GraphQL schema and binds it to the resolvers.
+func NewSchema(svc Services, limits Limits) (*Schema, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &queryResolver{},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(limits.MaxDepth),
+		graphql.MaxQueryLength(limits.MaxQueryLength),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema, svc: svc, limits: limits}, nil
+}
+
+// Exec runs a request as orgID through contract.
+func (s *Schema) Exec(ctx context.Context, contract *client.Contract, orgID string, req *Request) *graphql.Response {
+	r := newRequest(s.svc, contract, orgID, s.limits.MaxComplexity)
+	return s.schema.Exec(context.WithValue(ctx, requestContextKey{}, r), req.Query, req.OperationName, req.Variables)
+}
+
+type requestContextKey struct{}
+
+// request is the state of one GraphQL request: the caller's connection, its loaders and its complexity budget.
+type request struct {
+	svc      Services
+	contract *client.Contract
+	orgID    string
+	loaders  *loaders
+	budget   *budget
+}
+
+func newRequest(svc Services, contract *client.Contract, orgID string, maxComplexity int) *request {
+	r := &request{svc: svc, contract: contract, orgID: orgID, budget: newBudget(maxComplexity)}
+	r.loaders = newLoaders(r)
+	return r
+}
+
+func requestFromContext(ctx context.Context) *request {
+	return ctx.Value(requestContextKey{}).(*request)
+}
+
+// queryError is an API error reported in the errors of a GraphQL response, with its stable error code
+// as the "code" extension.
+type queryError struct {
+	*response.ErrorInfo
+}
+
+func (e queryError) Extensions() map[string]any {
+	code := e.ErrorCode
+	if code == "" {
+		code = response.DefaultErrorCode(e.Code)
+	}
+	return map[string]any{"code": code}
+}
+
+// graphError converts an error from a service response.
+func graphError(errInfo *response.ErrorInfo) error {
+	return queryError{errInfo}
+}
+
+// complexityError is returned once a request has used up its ledger queries.
+func complexityError(limit int) error {
+	return graphError(response.NewError(http.StatusBadRequest, "Query exceeds the complexity limit of %d ledger queries", limit))
+}
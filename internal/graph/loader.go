@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+const (
+	// batchWait is how long a loader collects keys from concurrently running resolvers before querying them.
+	batchWait = 2 * time.Millisecond
+	// fetchParallelism is how many ledger queries of one batch run at the same time.
+	fetchParallelism = 8
+)
+
+// budget counts the ledger queries of a request against its complexity limit.
+type budget struct {
+	limit int
+	used  atomic.Int64
+}
+
+func newBudget(limit int) *budget {
+	return &budget{limit: limit}
+}
+
+// spend records one ledger query, failing once the limit is exceeded. A limit of 0 disables the check.
+func (b *budget) spend() error {
+	if b.limit > 0 && b.used.Add(1) > int64(b.limit) {
+		return complexityError(b.limit)
+	}
+	return nil
+}
+
+// loader batches and caches the loads of one kind of record during a request. Keys requested while a batch is
+// being collected are fetched together, and every key is fetched at most once.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) ([]V, []error)
+
+	mu      sync.Mutex
+	results map[string]*result[V]
+	pending []string
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) ([]V, []error)) *loader[V] {
+	return &loader[V]{fetch: fetch, results: map[string]*result[V]{}}
+}
+
+// load returns the value of key, waiting for the batch it is fetched in.
+func (l *loader[V]) load(ctx context.Context, key string) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		l.pending = append(l.pending, key)
+		if len(l.pending) == 1 {
+			time.AfterFunc(batchWait, func() { l.dispatch(ctx) })
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch fetches the pending keys.
+func (l *loader[V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	batch := make([]*result[V], len(keys))
+	for i, key := range keys {
+		batch[i] = l.results[key]
+	}
+	l.mu.Unlock()
+
+	values, errs := l.fetch(ctx, keys)
+	for i, r := range batch {
+		r.value, r.err = values[i], errs[i]
+		close(r.done)
+	}
+}
+
+// perKey builds the fetch function of records the chaincode can only read one key at a time.
+// The keys of a batch are queried concurrently, fetchParallelism at a time.
+func perKey[V any](b *budget, get func(ctx context.Context, key string) (V, *response.ErrorInfo)) func(ctx context.Context, keys []string) ([]V, []error) {
+	return func(ctx context.Context, keys []string) ([]V, []error) {
+		values := make([]V, len(keys))
+		errs := make([]error, len(keys))
+		sem := make(chan struct{}, fetchParallelism)
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			if errs[i] = b.spend(); errs[i] != nil {
+				continue
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() { <-sem; wg.Done() }()
+				value, errInfo := get(ctx, key)
+				if errInfo != nil {
+					errs[i] = graphError(errInfo)
+					return
+				}
+				values[i] = value
+			}()
+		}
+		wg.Wait()
+		return values, errs
+	}
+}
+
+// loaders are the loaders of one request.
+type loaders struct {
+	organizations     *loader[*entity.Organization]
+	batches           *loader[*entity.Batch]
+	drugs             *loader[*entity.Drug]
+	transfers         *loader[*entity.Transfer]
+	drugsByBatch      *loader[[]*entity.Drug]
+	drugsByTransfer   *loader[[]*entity.Drug]
+	organizationsOnce sync.Once
+	organizationList  []*entity.Organization
+	organizationsByID map[string]*entity.Organization
+	organizationsErr  error
+}
+
+func newLoaders(r *request) *loaders {
+	l := &loaders{}
+	// Organizations are few and listed in one query, which then serves every organization of the request.
+	l.organizations = newLoader(func(ctx context.Context, keys []string) ([]*entity.Organization, []error) {
+		_, byID, err := l.allOrganizations(ctx, r)
+		values := make([]*entity.Organization, len(keys))
+		errs := make([]error, len(keys))
+		for i, key := range keys {
+			switch org, ok := byID[key]; {
+			case err != nil:
+				errs[i] = err
+			case !ok:
+				errs[i] = graphError(response.NewError(http.StatusNotFound, "Organization %s not found", key))
+			default:
+				values[i] = org
+			}
+		}
+		return values, errs
+	})
+	l.batches = newLoader(perKey(r.budget, func(ctx context.Context, id string) (*entity.Batch, *response.ErrorInfo) {
+		resp := r.svc.Batches.GetBatchByID(r.contract, ctx, id)
+		return resp.Value, resp.Error
+	}))
+	l.drugs = newLoader(perKey(r.budget, func(ctx context.Context, id string) (*entity.Drug, *response.ErrorInfo) {
+		resp := r.svc.Drugs.GetDrug(r.contract, ctx, id)
+		return resp.Value, resp.Error
+	}))
+	l.transfers = newLoader(perKey(r.budget, func(ctx context.Context, id string) (*entity.Transfer, *response.ErrorInfo) {
+		resp := r.svc.Transfers.GetTransfer(r.contract, ctx, id)
+		return resp.Value, resp.Error
+	}))
+	l.drugsByBatch = newLoader(perKey(r.budget, func(ctx context.Context, batchID string) ([]*entity.Drug, *response.ErrorInfo) {
+		resp := r.svc.Drugs.GetDrugByBatch(r.contract, ctx, batchID)
+		return resp.List, resp.Error
+	}))
+	l.drugsByTransfer = newLoader(perKey(r.budget, func(ctx context.Context, transferID string) ([]*entity.Drug, *response.ErrorInfo) {
+		resp := r.svc.Drugs.GetDrugByTransfer(r.contract, ctx, transferID)
+		return resp.List, resp.Error
+	}))
+	return l
+}
+
+// allOrganizations lists the organizations once per request, also indexed by ID.
+func (l *loaders) allOrganizations(ctx context.Context, r *request) ([]*entity.Organization, map[string]*entity.Organization, error) {
+	l.organizationsOnce.Do(func() {
+		if l.organizationsErr = r.budget.spend(); l.organizationsErr != nil {
+			return
+		}
+		resp := r.svc.Organizations.GetOrganizations(r.contract, ctx)
+		if !resp.Success {
+			l.organizationsErr = graphError(resp.Error)
+			return
+		}
+		l.organizationList = resp.List
+		l.organizationsByID = make(map[string]*entity.Organization, len(resp.List))
+		for _, org := range resp.List {
+			l.organizationsByID[org.ID] = org
+		}
+	})
+	return l.organizationList, l.organizationsByID, l.organizationsErr
+}
@@ -0,0 +1,389 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// queryResolver resolves the fields of Query.
+type queryResolver struct{}
+
+func (q *queryResolver) Organization(ctx context.Context, args struct{ ID graphql.ID }) (*organizationResolver, error) {
+	r := requestFromContext(ctx)
+	org, err := r.loaders.organizations.load(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &organizationResolver{r, org}, nil
+}
+
+func (q *queryResolver) Organizations(ctx context.Context) ([]*organizationResolver, error) {
+	r := requestFromContext(ctx)
+	orgs, _, err := r.loaders.allOrganizations(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return resolvers(r, orgs, newOrganizationResolver), nil
+}
+
+func (q *queryResolver) Batch(ctx context.Context, args struct{ ID graphql.ID }) (*batchResolver, error) {
+	r := requestFromContext(ctx)
+	b, err := r.loaders.batches.load(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &batchResolver{r, b}, nil
+}
+
+func (q *queryResolver) Batches(ctx context.Context) ([]*batchResolver, error) {
+	r := requestFromContext(ctx)
+	list, err := listFrom(r, func() response.BaseListResponse[entity.Batch] {
+		return r.svc.Batches.GetAllBatches(r.contract, ctx)
+	})
+	return resolvers(r, list, newBatchResolver), err
+}
+
+func (q *queryResolver) Drug(ctx context.Context, args struct{ ID graphql.ID }) (*drugResolver, error) {
+	r := requestFromContext(ctx)
+	d, err := r.loaders.drugs.load(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &drugResolver{r, d}, nil
+}
+
+func (q *queryResolver) MyDrugs(ctx context.Context, args struct{ AvailableOnly bool }) ([]*drugResolver, error) {
+	r := requestFromContext(ctx)
+	list, err := listFrom(r, func() response.BaseListResponse[entity.Drug] {
+		if args.AvailableOnly {
+			return r.svc.Drugs.GetMyAvailDrugs(r.contract, ctx)
+		}
+		return r.svc.Drugs.GetMyDrugs(r.contract, ctx)
+	})
+	return resolvers(r, list, newDrugResolver), err
+}
+
+func (q *queryResolver) Transfer(ctx context.Context, args struct{ ID graphql.ID }) (*transferResolver, error) {
+	r := requestFromContext(ctx)
+	t, err := r.loaders.transfers.load(ctx, string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &transferResolver{r, t}, nil
+}
+
+func (q *queryResolver) MyTransfers(ctx context.Context, args struct{ Direction string }) ([]*transferResolver, error) {
+	r := requestFromContext(ctx)
+	list, err := listFrom(r, func() response.BaseListResponse[entity.Transfer] {
+		switch args.Direction {
+		case "INCOMING":
+			return r.svc.Transfers.GetMyInTransfer(r.contract, ctx)
+		case "OUTGOING":
+			return r.svc.Transfers.GetMyOutTransfer(r.contract, ctx)
+		default:
+			return r.svc.Transfers.GetMyTransfers(r.contract, ctx)
+		}
+	})
+	return resolvers(r, list, newTransferResolver), err
+}
+
+// listFrom makes a list query that no loader caches, counting it against the complexity budget.
+func listFrom[T any](r *request, query func() response.BaseListResponse[T]) ([]*T, error) {
+	if err := r.budget.spend(); err != nil {
+		return nil, err
+	}
+	resp := query()
+	if !resp.Success {
+		return nil, graphError(resp.Error)
+	}
+	return resp.List, nil
+}
+
+// resolvers wraps the records of a list in resolvers.
+func resolvers[T, R any](r *request, list []*T, newResolver func(*request, *T) R) []R {
+	out := make([]R, 0, len(list))
+	for _, item := range list {
+		if item != nil {
+			out = append(out, newResolver(r, item))
+		}
+	}
+	return out
+}
+
+// optional returns nil for an empty string, which the schema reports as null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalID returns nil for an empty ID, which the schema reports as null.
+func optionalID(s string) *graphql.ID {
+	if s == "" {
+		return nil
+	}
+	id := graphql.ID(s)
+	return &id
+}
+
+// optionalTime returns nil for the zero time, which the schema reports as null.
+func optionalTime(t time.Time) *graphql.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
+
+// organizationOrNil resolves a link to an organization, which is null when the ID is empty.
+func organizationOrNil(ctx context.Context, r *request, id string) (*organizationResolver, error) {
+	if id == "" {
+		return nil, nil
+	}
+	org, err := r.loaders.organizations.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &organizationResolver{r, org}, nil
+}
+
+type organizationResolver struct {
+	r   *request
+	org *entity.Organization
+}
+
+func newOrganizationResolver(r *request, org *entity.Organization) *organizationResolver {
+	return &organizationResolver{r, org}
+}
+
+func (o *organizationResolver) ID() graphql.ID      { return graphql.ID(o.org.ID) }
+func (o *organizationResolver) Name() string        { return o.org.Name }
+func (o *organizationResolver) Type() string        { return o.org.Type }
+func (o *organizationResolver) Location() string    { return o.org.Location }
+func (o *organizationResolver) MspId() string       { return o.org.MSPID }
+func (o *organizationResolver) IsDeactivated() bool { return o.org.IsDeactivated }
+
+func (o *organizationResolver) History(ctx context.Context) ([]*organizationVersionResolver, error) {
+	r := o.r
+	list, err := listFrom(r, func() response.BaseListResponse[entity.HistoryOrganization] {
+		return r.svc.Organizations.GetHistoryOrganization(r.contract, ctx, o.org.ID)
+	})
+	return resolvers(r, list, func(r *request, h *entity.HistoryOrganization) *organizationVersionResolver {
+		return &organizationVersionResolver{r, h}
+	}), err
+}
+
+type batchResolver struct {
+	r     *request
+	batch *entity.Batch
+}
+
+func newBatchResolver(r *request, b *entity.Batch) *batchResolver {
+	return &batchResolver{r, b}
+}
+
+func (b *batchResolver) ID() graphql.ID              { return graphql.ID(b.batch.ID) }
+func (b *batchResolver) DrugName() string            { return b.batch.DrugName }
+func (b *batchResolver) ManufacturerName() string    { return b.batch.ManufacturerName }
+func (b *batchResolver) ManufactureLocation() string { return b.batch.ManufactureLocation }
+func (b *batchResolver) ProductionDate() graphql.Time {
+	return graphql.Time{Time: b.batch.ProductionDate}
+}
+func (b *batchResolver) ExpiryDate() graphql.Time { return graphql.Time{Time: b.batch.ExpiryDate} }
+func (b *batchResolver) Gtin() *string            { return optional(b.batch.GTIN) }
+
+func (b *batchResolver) Drugs(ctx context.Context) ([]*drugResolver, error) {
+	drugs, err := b.r.loaders.drugsByBatch.load(ctx, b.batch.ID)
+	return resolvers(b.r, drugs, newDrugResolver), err
+}
+
+func (b *batchResolver) History(ctx context.Context) ([]*batchVersionResolver, error) {
+	r := b.r
+	list, err := listFrom(r, func() response.BaseListResponse[entity.HistoryBatch] {
+		return r.svc.Batches.GetHistoryBatch(r.contract, ctx, b.batch.ID)
+	})
+	return resolvers(r, list, func(r *request, h *entity.HistoryBatch) *batchVersionResolver {
+		return &batchVersionResolver{r, h}
+	}), err
+}
+
+type drugResolver struct {
+	r    *request
+	drug *entity.Drug
+}
+
+func newDrugResolver(r *request, d *entity.Drug) *drugResolver {
+	return &drugResolver{r, d}
+}
+
+func (d *drugResolver) ID() graphql.ID          { return graphql.ID(d.drug.ID) }
+func (d *drugResolver) BatchId() graphql.ID     { return graphql.ID(d.drug.BatchID) }
+func (d *drugResolver) OwnerId() graphql.ID     { return graphql.ID(d.drug.OwnerID) }
+func (d *drugResolver) Location() string        { return d.drug.Location }
+func (d *drugResolver) IsTransferred() bool     { return d.drug.IsTransferred }
+func (d *drugResolver) TransferId() *graphql.ID { return optionalID(d.drug.TransferID) }
+
+func (d *drugResolver) Batch(ctx context.Context) (*batchResolver, error) {
+	if d.drug.BatchID == "" {
+		return nil, nil
+	}
+	b, err := d.r.loaders.batches.load(ctx, d.drug.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	return &batchResolver{d.r, b}, nil
+}
+
+func (d *drugResolver) Owner(ctx context.Context) (*organizationResolver, error) {
+	return organizationOrNil(ctx, d.r, d.drug.OwnerID)
+}
+
+func (d *drugResolver) Transfer(ctx context.Context) (*transferResolver, error) {
+	if d.drug.TransferID == "" {
+		return nil, nil
+	}
+	t, err := d.r.loaders.transfers.load(ctx, d.drug.TransferID)
+	if err != nil {
+		return nil, err
+	}
+	return &transferResolver{d.r, t}, nil
+}
+
+func (d *drugResolver) History(ctx context.Context) ([]*drugVersionResolver, error) {
+	r := d.r
+	list, err := listFrom(r, func() response.BaseListResponse[entity.HistoryDrug] {
+		return r.svc.Drugs.GetHistoryDrug(r.contract, ctx, d.drug.ID)
+	})
+	return resolvers(r, list, func(r *request, h *entity.HistoryDrug) *drugVersionResolver {
+		return &drugVersionResolver{r, h}
+	}), err
+}
+
+type transferResolver struct {
+	r        *request
+	transfer *entity.Transfer
+}
+
+func newTransferResolver(r *request, t *entity.Transfer) *transferResolver {
+	return &transferResolver{r, t}
+}
+
+func (t *transferResolver) ID() graphql.ID         { return graphql.ID(t.transfer.ID) }
+func (t *transferResolver) SenderId() graphql.ID   { return graphql.ID(t.transfer.SenderID) }
+func (t *transferResolver) ReceiverId() graphql.ID { return graphql.ID(t.transfer.ReceiverID) }
+func (t *transferResolver) TransferDate() graphql.Time {
+	return graphql.Time{Time: t.transfer.TransferDate}
+}
+func (t *transferResolver) AcceptDeadline() *graphql.Time {
+	return optionalTime(t.transfer.AcceptDeadline.Time)
+}
+func (t *transferResolver) ReceiveDate() *graphql.Time {
+	return optionalTime(t.transfer.ReceiveDate.Time)
+}
+func (t *transferResolver) IsAccepted() bool  { return t.transfer.IsAccepted }
+func (t *transferResolver) IsCancelled() bool { return t.transfer.IsCancelled }
+func (t *transferResolver) IsExpired() bool   { return t.transfer.IsExpired }
+func (t *transferResolver) Sscc() *string     { return optional(t.transfer.SSCC) }
+func (t *transferResolver) Status() string    { return services.TransferStatus(*t.transfer) }
+
+func (t *transferResolver) Sender(ctx context.Context) (*organizationResolver, error) {
+	return organizationOrNil(ctx, t.r, t.transfer.SenderID)
+}
+
+func (t *transferResolver) Receiver(ctx context.Context) (*organizationResolver, error) {
+	return organizationOrNil(ctx, t.r, t.transfer.ReceiverID)
+}
+
+func (t *transferResolver) Drugs(ctx context.Context) ([]*drugResolver, error) {
+	drugs, err := t.r.loaders.drugsByTransfer.load(ctx, t.transfer.ID)
+	return resolvers(t.r, drugs, newDrugResolver), err
+}
+
+func (t *transferResolver) History(ctx context.Context) ([]*transferVersionResolver, error) {
+	r := t.r
+	list, err := listFrom(r, func() response.BaseListResponse[entity.HistoryTransfer] {
+		return r.svc.Transfers.GetHistoryTransfer(r.contract, ctx, t.transfer.ID)
+	})
+	return resolvers(r, list, func(r *request, h *entity.HistoryTransfer) *transferVersionResolver {
+		return &transferVersionResolver{r, h}
+	}), err
+}
+
+type transactionResolver struct {
+	txID      string
+	timestamp time.Time
+	isDelete  bool
+}
+
+func (t *transactionResolver) TxId() graphql.ID        { return graphql.ID(t.txID) }
+func (t *transactionResolver) Timestamp() graphql.Time { return graphql.Time{Time: t.timestamp} }
+func (t *transactionResolver) IsDelete() bool          { return t.isDelete }
+
+type organizationVersionResolver struct {
+	r *request
+	h *entity.HistoryOrganization
+}
+
+func (v *organizationVersionResolver) Transaction() *transactionResolver {
+	return &transactionResolver{v.h.TxID, v.h.Timestamp, v.h.IsDelete}
+}
+
+func (v *organizationVersionResolver) Organization() *organizationResolver {
+	if v.h.Organization == nil {
+		return nil
+	}
+	return &organizationResolver{v.r, v.h.Organization}
+}
+
+type batchVersionResolver struct {
+	r *request
+	h *entity.HistoryBatch
+}
+
+func (v *batchVersionResolver) Transaction() *transactionResolver {
+	return &transactionResolver{v.h.TxID, v.h.Timestamp, v.h.IsDelete}
+}
+
+func (v *batchVersionResolver) Batch() *batchResolver {
+	if v.h.Batch == nil {
+		return nil
+	}
+	return &batchResolver{v.r, v.h.Batch}
+}
+
+type drugVersionResolver struct {
+	r *request
+	h *entity.HistoryDrug
+}
+
+func (v *drugVersionResolver) Transaction() *transactionResolver {
+	return &transactionResolver{v.h.TxID, v.h.Timestamp, v.h.IsDelete}
+}
+
+func (v *drugVersionResolver) Drug() *drugResolver {
+	if v.h.Drug == nil {
+		return nil
+	}
+	return &drugResolver{v.r, v.h.Drug}
+}
+
+type transferVersionResolver struct {
+	r *request
+	h *entity.HistoryTransfer
+}
+
+func (v *transferVersionResolver) Transaction() *transactionResolver {
+	return &transactionResolver{v.h.TxID, v.h.Timestamp, v.h.IsDelete}
+}
+
+func (v *transferVersionResolver) Transfer() *transferResolver {
+	if v.h.Transfer == nil {
+		return nil
+	}
+	return &transferResolver{v.r, v.h.Transfer}
+}
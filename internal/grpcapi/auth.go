@@ -0,0 +1,118 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var logger = logging.Logger("grpcapi")
+
+// adminMethods are restricted to admin organizations, like the REST routes guarded by auth.RequireAdmin.
+var adminMethods = map[string]bool{
+	medtracev1.OrganizationService_RegisterOrganization_FullMethodName:   true,
+	medtracev1.OrganizationService_UpdateOrganization_FullMethodName:     true,
+	medtracev1.OrganizationService_DeactivateOrganization_FullMethodName: true,
+}
+
+// sharedGatewayMethods read the ledger through a poller shared by every call of an organization, so the
+// interceptors authenticate them without opening a gateway per call.
+var sharedGatewayMethods = map[string]bool{
+	medtracev1.TransferService_WatchTransfers_FullMethodName: true,
+}
+
+// caller is the authenticated organization of a call and its connection to the Fabric network.
+// contract is nil for sharedGatewayMethods.
+type caller struct {
+	orgID     string
+	contract  *client.Contract
+	expiresAt time.Time // Expiry of the access token; streams end when it passes
+}
+
+type callerContextKey struct{}
+
+// callerFromContext returns the caller set by the auth interceptors.
+func callerFromContext(ctx context.Context) caller {
+	c, _ := ctx.Value(callerContextKey{}).(caller)
+	return c
+}
+
+// authenticate validates the access token in the metadata of a call, checks that the organization may call
+// the method and connects to the Fabric network as that organization. The returned close function releases
+// the gateway and must be called when the call is done.
+func authenticate(ctx context.Context, fullMethod string) (context.Context, func(), error) {
+	header := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	claims, errInfo := auth.AuthenticateBearerClaims(header)
+	if errInfo != nil {
+		return nil, nil, statusError(errInfo)
+	}
+	orgID := claims.OrgID
+	c := caller{orgID: orgID}
+	if claims.ExpiresAt != nil {
+		c.expiresAt = claims.ExpiresAt.Time
+	}
+	if adminMethods[fullMethod] && !auth.IsAdmin(orgID) {
+		return nil, nil, statusError(response.NewError(http.StatusForbidden, "Organization %s is not an administrator", orgID))
+	}
+
+	ctx = logging.With(ctx, slog.String(logging.OrgKey, orgID))
+	if sharedGatewayMethods[fullMethod] {
+		return context.WithValue(ctx, callerContextKey{}, c), func() {}, nil
+	}
+	contract, closeGateway, err := auth.NewContractForOrg(orgID)
+	if err != nil {
+		logger.ErrorContext(ctx, "gRPC "+fullMethod+": Failed to connect to network", "error", err)
+		return nil, nil, statusError(response.NewError(http.StatusInternalServerError, "Failed to connect to network for organization %s", orgID))
+	}
+	closeFunc := func() {
+		if errClose := closeGateway(); errClose != nil {
+			logger.ErrorContext(ctx, "gRPC "+fullMethod+": Error closing Fabric gateway", "error", errClose)
+		}
+	}
+	c.contract = contract
+	return context.WithValue(ctx, callerContextKey{}, c), closeFunc, nil
+}
+
+// unaryAuthInterceptor authenticates unary calls.
+func unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, closeFunc, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFunc()
+	return handler(ctx, req)
+}
+
+// streamAuthInterceptor authenticates streaming calls. The Fabric connection, if any, is kept for the whole stream.
+func streamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, closeFunc, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	defer closeFunc()
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream is a server stream whose context carries the caller.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
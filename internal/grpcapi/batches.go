@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+)
+
+type batchServer struct {
+	medtracev1.UnimplementedBatchServiceServer
+	service *services.BatchService
+}
+
+func (s *batchServer) CreateBatch(ctx context.Context, req *medtracev1.CreateBatchRequest) (*medtracev1.Batch, error) {
+	dto := batch.CreateBatch{
+		ID:             req.GetId(),
+		DrugName:       req.GetDrugName(),
+		Amount:         int(req.GetAmount()),
+		ProductionDate: timeOrZero(req.GetProductionDate()),
+		ExpiryDate:     timeOrZero(req.GetExpiryDate()),
+		GTIN:           req.GetGtin(),
+	}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	if dto.GTIN != "" {
+		gtin, err := gs1.NormalizeGTIN(dto.GTIN)
+		if err != nil {
+			return nil, invalidArgument("%v", err)
+		}
+		dto.GTIN = gtin
+	}
+	resp := s.service.CreateBatch(callerFromContext(ctx).contract, ctx, &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return batchToProto(resp.Value), nil
+}
+
+func (s *batchServer) GetBatch(ctx context.Context, req *medtracev1.GetBatchRequest) (*medtracev1.Batch, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Batch ID is required")
+	}
+	resp := s.service.GetBatchByID(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return batchToProto(resp.Value), nil
+}
+
+func (s *batchServer) ListBatches(ctx context.Context, req *medtracev1.ListBatchesRequest) (*medtracev1.ListBatchesResponse, error) {
+	resp := s.service.GetAllBatches(callerFromContext(ctx).contract, ctx)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.ListBatchesResponse{Batches: mapList(resp.List, batchToProto)}, nil
+}
+
+func (s *batchServer) UpdateBatch(ctx context.Context, req *medtracev1.UpdateBatchRequest) (*medtracev1.Batch, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Batch ID is required")
+	}
+	dto := batch.UpdateBatch{
+		DrugName:       req.GetDrugName(),
+		ProductionDate: timeOrZero(req.GetProductionDate()),
+		ExpiryDate:     timeOrZero(req.GetExpiryDate()),
+	}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	resp := s.service.UpdateBatch(callerFromContext(ctx).contract, ctx, req.GetId(), &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return batchToProto(resp.Value), nil
+}
+
+func (s *batchServer) BatchExists(ctx context.Context, req *medtracev1.BatchExistsRequest) (*medtracev1.BatchExistsResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Batch ID is required")
+	}
+	resp := s.service.BatchExists(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.BatchExistsResponse{Exists: *resp.Value}, nil
+}
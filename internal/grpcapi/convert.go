@@ -0,0 +1,136 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp converts a time to a protobuf timestamp, leaving the zero time unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeOf converts an optional protobuf timestamp to a time.
+func timeOf(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// timeOrZero converts a protobuf timestamp to a time, the zero time when it is unset.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func organizationToProto(o *entity.Organization) *medtracev1.Organization {
+	if o == nil {
+		return nil
+	}
+	return &medtracev1.Organization{
+		Id:            o.ID,
+		Name:          o.Name,
+		Type:          o.Type,
+		Location:      o.Location,
+		MspId:         o.MSPID,
+		IsDeactivated: o.IsDeactivated,
+	}
+}
+
+func batchToProto(b *entity.Batch) *medtracev1.Batch {
+	if b == nil {
+		return nil
+	}
+	return &medtracev1.Batch{
+		Id:                  b.ID,
+		DrugName:            b.DrugName,
+		ManufacturerName:    b.ManufacturerName,
+		ManufactureLocation: b.ManufactureLocation,
+		ProductionDate:      timestamp(b.ProductionDate),
+		ExpiryDate:          timestamp(b.ExpiryDate),
+		Gtin:                b.GTIN,
+	}
+}
+
+func drugToProto(d *entity.Drug) *medtracev1.Drug {
+	if d == nil {
+		return nil
+	}
+	return &medtracev1.Drug{
+		Id:            d.ID,
+		BatchId:       d.BatchID,
+		OwnerId:       d.OwnerID,
+		Location:      d.Location,
+		IsTransferred: d.IsTransferred,
+		TransferId:    d.TransferID,
+	}
+}
+
+var transferStatuses = map[string]medtracev1.TransferStatus{
+	entity.TransferPending:   medtracev1.TransferStatus_TRANSFER_STATUS_PENDING,
+	entity.TransferAccepted:  medtracev1.TransferStatus_TRANSFER_STATUS_ACCEPTED,
+	entity.TransferRejected:  medtracev1.TransferStatus_TRANSFER_STATUS_REJECTED,
+	entity.TransferCancelled: medtracev1.TransferStatus_TRANSFER_STATUS_CANCELLED,
+	entity.TransferExpired:   medtracev1.TransferStatus_TRANSFER_STATUS_EXPIRED,
+}
+
+func transferToProto(t *entity.Transfer) *medtracev1.Transfer {
+	if t == nil {
+		return nil
+	}
+	return &medtracev1.Transfer{
+		Id:             t.ID,
+		SenderId:       t.SenderID,
+		ReceiverId:     t.ReceiverID,
+		TransferDate:   timestamp(t.TransferDate),
+		AcceptDeadline: timestamp(t.AcceptDeadline.Time),
+		ReceiveDate:    timestamp(t.ReceiveDate.Time),
+		IsAccepted:     t.IsAccepted,
+		IsCancelled:    t.IsCancelled,
+		IsExpired:      t.IsExpired,
+		Sscc:           t.SSCC,
+		Status:         transferStatuses[services.TransferStatus(*t)],
+	}
+}
+
+func transactionToProto(txID string, ts time.Time, isDelete bool) *medtracev1.Transaction {
+	return &medtracev1.Transaction{TxId: txID, Timestamp: timestamp(ts), IsDelete: isDelete}
+}
+
+func historyChangeToProto(h *entity.HistoryChange) *medtracev1.HistoryChange {
+	changes := make([]*medtracev1.FieldChange, len(h.Changes))
+	for i, c := range h.Changes {
+		changes[i] = &medtracev1.FieldChange{Field: c.Field, Old: value(c.Old), New: value(c.New)}
+	}
+	return &medtracev1.HistoryChange{Transaction: transactionToProto(h.TxID, h.Timestamp, h.IsDelete), Changes: changes}
+}
+
+// value converts a decoded JSON value to a protobuf Value, leaving a missing value unset.
+func value(v any) *structpb.Value {
+	if v == nil {
+		return nil
+	}
+	pv, _ := structpb.NewValue(v) // Every value decoded from JSON has a protobuf equivalent
+	return pv
+}
+
+// mapList converts the items of a service list response.
+func mapList[T, P any](list []*T, convert func(*T) P) []P {
+	out := make([]P, len(list))
+	for i, item := range list {
+		out[i] = convert(item)
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package grpcapi
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"google.golang.org/grpc"
+)
+
+type drugServer struct {
+	medtracev1.UnimplementedDrugServiceServer
+	service *services.DrugService
+}
+
+func (s *drugServer) CreateDrug(ctx context.Context, req *medtracev1.CreateDrugRequest) (*medtracev1.CreateDrugResponse, error) {
+	dto := drug.CreateDrugRequest{
+		OwnerID:      req.GetOwnerId(),
+		BatchID:      req.GetBatchId(),
+		DrugID:       req.GetDrugId(),
+		GTIN:         req.GetGtin(),
+		SerialNumber: req.GetSerialNumber(),
+	}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	resp := s.service.CreateDrug(callerFromContext(ctx).contract, ctx, &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.CreateDrugResponse{Id: *resp.Value}, nil
+}
+
+func (s *drugServer) GetDrug(ctx context.Context, req *medtracev1.GetDrugRequest) (*medtracev1.Drug, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Drug ID is required")
+	}
+	resp := s.service.GetDrug(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return drugToProto(resp.Value), nil
+}
+
+func (s *drugServer) ListMyDrugs(req *medtracev1.ListMyDrugsRequest, stream grpc.ServerStreamingServer[medtracev1.Drug]) error {
+	ctx := stream.Context()
+	if req.GetAvailableOnly() {
+		return sendDrugs(stream, s.service.StreamMyAvailDrugs(callerFromContext(ctx).contract, ctx))
+	}
+	return sendDrugs(stream, s.service.StreamMyDrugs(callerFromContext(ctx).contract, ctx))
+}
+
+func (s *drugServer) ListDrugsByBatch(req *medtracev1.ListDrugsByBatchRequest, stream grpc.ServerStreamingServer[medtracev1.Drug]) error {
+	if req.GetBatchId() == "" {
+		return invalidArgument("Batch ID is required")
+	}
+	ctx := stream.Context()
+	return sendDrugs(stream, s.service.StreamDrugByBatch(callerFromContext(ctx).contract, ctx, req.GetBatchId()))
+}
+
+func (s *drugServer) ListDrugsByTransfer(req *medtracev1.ListDrugsByTransferRequest, stream grpc.ServerStreamingServer[medtracev1.Drug]) error {
+	if req.GetTransferId() == "" {
+		return invalidArgument("Transfer ID is required")
+	}
+	ctx := stream.Context()
+	return sendDrugs(stream, s.service.StreamDrugByTransfer(callerFromContext(ctx).contract, ctx, req.GetTransferId()))
+}
+
+// sendDrugs streams drugs one message at a time, as the service decodes them.
+func sendDrugs(stream grpc.ServerStreamingServer[medtracev1.Drug], drugs iter.Seq2[*entity.Drug, *response.ErrorInfo]) error {
+	for d, errInfo := range drugs {
+		if errInfo != nil {
+			return statusError(errInfo)
+		}
+		if err := stream.Send(drugToProto(d)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package grpcapi
+
+import (
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details attached to error statuses.
+const errorDomain = "medtrace"
+
+// grpcCodes maps the HTTP statuses of API errors to gRPC codes.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.Aborted,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+}
+
+// statusError converts an API error into a gRPC status. The stable error code is attached as an ErrorInfo
+// detail and validation problems as a BadRequest detail, so clients can branch on them like REST clients do.
+func statusError(errInfo *response.ErrorInfo) error {
+	code, ok := grpcCodes[errInfo.Code]
+	if !ok {
+		code = codes.Internal
+		if errInfo.Code < http.StatusInternalServerError {
+			code = codes.InvalidArgument
+		}
+	}
+	errorCode := errInfo.ErrorCode
+	if errorCode == "" {
+		errorCode = response.DefaultErrorCode(errInfo.Code)
+	}
+
+	st := status.New(code, errInfo.Message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: errorCode, Domain: errorDomain}}
+	if len(errInfo.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(errInfo.Fields))
+		for i, f := range errInfo.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// invalidArgument is the status of a request missing a required value.
+func invalidArgument(format string, args ...any) error {
+	return statusError(response.NewError(http.StatusBadRequest, format, args...))
+}
+
+// validate checks a request DTO built from a gRPC request against its validate tags.
+func validate(req any) error {
+	if err := validator.Validate(req); err != nil {
+		return statusError(validation.BindError(err))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+)
+
+type historyServer struct {
+	medtracev1.UnimplementedHistoryServiceServer
+	svc Services
+}
+
+func (s *historyServer) GetOrganizationHistory(ctx context.Context, req *medtracev1.GetHistoryRequest) (*medtracev1.OrganizationHistory, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Organization ID is required")
+	}
+	resp := s.svc.Organizations.GetHistoryOrganization(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.OrganizationHistory{Entries: mapList(resp.List, func(h *entity.HistoryOrganization) *medtracev1.OrganizationHistory_Entry {
+		return &medtracev1.OrganizationHistory_Entry{
+			Transaction:  transactionToProto(h.TxID, h.Timestamp, h.IsDelete),
+			Organization: organizationToProto(h.Organization),
+		}
+	})}, nil
+}
+
+func (s *historyServer) GetBatchHistory(ctx context.Context, req *medtracev1.GetHistoryRequest) (*medtracev1.BatchHistory, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Batch ID is required")
+	}
+	resp := s.svc.Batches.GetHistoryBatch(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.BatchHistory{Entries: mapList(resp.List, func(h *entity.HistoryBatch) *medtracev1.BatchHistory_Entry {
+		return &medtracev1.BatchHistory_Entry{
+			Transaction: transactionToProto(h.TxID, h.Timestamp, h.IsDelete),
+			Batch:       batchToProto(h.Batch),
+		}
+	})}, nil
+}
+
+func (s *historyServer) GetDrugHistory(ctx context.Context, req *medtracev1.GetHistoryRequest) (*medtracev1.DrugHistory, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Drug ID is required")
+	}
+	resp := s.svc.Drugs.GetHistoryDrug(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.DrugHistory{Entries: mapList(resp.List, func(h *entity.HistoryDrug) *medtracev1.DrugHistory_Entry {
+		return &medtracev1.DrugHistory_Entry{
+			Transaction: transactionToProto(h.TxID, h.Timestamp, h.IsDelete),
+			Drug:        drugToProto(h.Drug),
+		}
+	})}, nil
+}
+
+func (s *historyServer) GetTransferHistory(ctx context.Context, req *medtracev1.GetHistoryRequest) (*medtracev1.TransferHistory, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Transfer ID is required")
+	}
+	resp := s.svc.Transfers.GetHistoryTransfer(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.TransferHistory{Entries: mapList(resp.List, func(h *entity.HistoryTransfer) *medtracev1.TransferHistory_Entry {
+		return &medtracev1.TransferHistory_Entry{
+			Transaction: transactionToProto(h.TxID, h.Timestamp, h.IsDelete),
+			Transfer:    transferToProto(h.Transfer),
+		}
+	})}, nil
+}
+
+func (s *historyServer) GetChanges(ctx context.Context, req *medtracev1.GetChangesRequest) (*medtracev1.GetChangesResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Record ID is required")
+	}
+	contract := callerFromContext(ctx).contract
+	var resp response.BaseListResponse[entity.HistoryChange]
+	switch req.GetType() {
+	case medtracev1.RecordType_RECORD_TYPE_ORGANIZATION:
+		resp = s.svc.Organizations.GetOrganizationChanges(contract, ctx, req.GetId())
+	case medtracev1.RecordType_RECORD_TYPE_BATCH:
+		resp = s.svc.Batches.GetBatchChanges(contract, ctx, req.GetId())
+	case medtracev1.RecordType_RECORD_TYPE_DRUG:
+		resp = s.svc.Drugs.GetDrugChanges(contract, ctx, req.GetId())
+	case medtracev1.RecordType_RECORD_TYPE_TRANSFER:
+		resp = s.svc.Transfers.GetTransferChanges(contract, ctx, req.GetId())
+	default:
+		return nil, invalidArgument("Record type is required")
+	}
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.GetChangesResponse{Changes: mapList(resp.List, historyChangeToProto)}, nil
+}
@@ -0,0 +1,80 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/organization"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+)
+
+type organizationServer struct {
+	medtracev1.UnimplementedOrganizationServiceServer
+	service *services.OrganizationService
+}
+
+func (s *organizationServer) GetOrganization(ctx context.Context, req *medtracev1.GetOrganizationRequest) (*medtracev1.Organization, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Organization ID is required")
+	}
+	resp := s.service.GetOrganizationByID(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return organizationToProto(resp.Value), nil
+}
+
+func (s *organizationServer) ListOrganizations(ctx context.Context, req *medtracev1.ListOrganizationsRequest) (*medtracev1.ListOrganizationsResponse, error) {
+	resp := s.service.GetOrganizations(callerFromContext(ctx).contract, ctx)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.ListOrganizationsResponse{Organizations: mapList(resp.List, organizationToProto)}, nil
+}
+
+func (s *organizationServer) RegisterOrganization(ctx context.Context, req *medtracev1.RegisterOrganizationRequest) (*medtracev1.Organization, error) {
+	dto := organization.RegisterOrganization{
+		ID:           req.GetId(),
+		Name:         req.GetName(),
+		Type:         req.GetType(),
+		Location:     req.GetLocation(),
+		MSPID:        req.GetMspId(),
+		PeerEndpoint: req.GetPeerEndpoint(),
+		GatewayPeer:  req.GetGatewayPeer(),
+		CryptoPath:   req.GetCryptoPath(),
+	}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	resp := s.service.RegisterOrganization(callerFromContext(ctx).contract, ctx, &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return organizationToProto(resp.Value), nil
+}
+
+func (s *organizationServer) UpdateOrganization(ctx context.Context, req *medtracev1.UpdateOrganizationRequest) (*medtracev1.Organization, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Organization ID is required")
+	}
+	dto := organization.UpdateOrganization{Name: req.GetName(), Type: req.GetType(), Location: req.GetLocation()}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	resp := s.service.UpdateOrganization(callerFromContext(ctx).contract, ctx, req.GetId(), &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return organizationToProto(resp.Value), nil
+}
+
+func (s *organizationServer) DeactivateOrganization(ctx context.Context, req *medtracev1.DeactivateOrganizationRequest) (*medtracev1.Organization, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Organization ID is required")
+	}
+	resp := s.service.DeactivateOrganization(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return organizationToProto(resp.Value), nil
+}
@@ -0,0 +1,40 @@
+// Package grpcapi serves the MedTrace API over gRPC next to the REST API. It implements the services of
+// proto/medtrace/v1 with the same service layer as the REST handlers, and authenticates calls with the same
+// access tokens, sent as "authorization: Bearer <token>" metadata.
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"google.golang.org/grpc"
+)
+
+// DefaultWatchInterval is used if GRPC_TRANSFER_WATCH_INTERVAL env var is not set.
+const DefaultWatchInterval = 5 * time.Second
+
+// Services are the services the gRPC API is implemented with.
+type Services struct {
+	Organizations *services.OrganizationService
+	Batches       *services.BatchService
+	Drugs         *services.DrugService
+	Transfers     *services.TransferService
+}
+
+// validator checks request DTOs with the same rules the REST API applies when binding them.
+var validator = validation.New()
+
+// NewServer creates a gRPC server with every MedTrace service registered. WatchTransfers polls the ledger
+// for transfer changes every watchInterval, once per organization and direction.
+func NewServer(svc Services, watchInterval time.Duration, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ChainUnaryInterceptor(unaryAuthInterceptor), grpc.ChainStreamInterceptor(streamAuthInterceptor))
+	s := grpc.NewServer(opts...)
+	medtracev1.RegisterOrganizationServiceServer(s, &organizationServer{service: svc.Organizations})
+	medtracev1.RegisterBatchServiceServer(s, &batchServer{service: svc.Batches})
+	medtracev1.RegisterDrugServiceServer(s, &drugServer{service: svc.Drugs})
+	medtracev1.RegisterTransferServiceServer(s, &transferServer{service: svc.Transfers, watches: newWatchHub(svc.Transfers, watchInterval)})
+	medtracev1.RegisterHistoryServiceServer(s, &historyServer{svc: svc})
+	return s
+}
@@ -0,0 +1,190 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type transferServer struct {
+	medtracev1.UnimplementedTransferServiceServer
+	service *services.TransferService
+	watches *watchHub
+}
+
+func (s *transferServer) CreateTransfer(ctx context.Context, req *medtracev1.CreateTransferRequest) (*medtracev1.Transfer, error) {
+	dto := transfer.CreateTransferRequest{
+		DrugsID:        req.GetDrugIds(),
+		ReceiverID:     req.GetReceiverId(),
+		TransferDate:   timeOf(req.GetTransferDate()),
+		AcceptDeadline: timeOf(req.GetAcceptDeadline()),
+		SSCC:           req.GetSscc(),
+	}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	if dto.TransferDate == nil {
+		now := time.Now()
+		dto.TransferDate = &now
+	}
+	if dto.AcceptDeadline != nil && !dto.AcceptDeadline.After(*dto.TransferDate) {
+		return nil, invalidArgument("AcceptDeadline must be after TransferDate")
+	}
+	c := callerFromContext(ctx)
+	resp := s.service.CreateTransfer(c.contract, ctx, c.orgID, &dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return transferToProto(resp.Value), nil
+}
+
+func (s *transferServer) GetTransfer(ctx context.Context, req *medtracev1.GetTransferRequest) (*medtracev1.Transfer, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument("Transfer ID is required")
+	}
+	resp := s.service.GetTransfer(callerFromContext(ctx).contract, ctx, req.GetId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return transferToProto(resp.Value), nil
+}
+
+func (s *transferServer) ListMyTransfers(ctx context.Context, req *medtracev1.ListMyTransfersRequest) (*medtracev1.ListTransfersResponse, error) {
+	resp := listTransfers(s.service, callerFromContext(ctx).contract, ctx, req.GetDirection())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return &medtracev1.ListTransfersResponse{Transfers: mapList(resp.List, transferToProto)}, nil
+}
+
+func (s *transferServer) AcceptTransfer(ctx context.Context, req *medtracev1.ProcessTransferRequest) (*medtracev1.Transfer, error) {
+	dto, err := processTransferRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp := s.service.AcceptTransfer(callerFromContext(ctx).contract, ctx, dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return transferToProto(resp.Value), nil
+}
+
+func (s *transferServer) RejectTransfer(ctx context.Context, req *medtracev1.ProcessTransferRequest) (*medtracev1.Transfer, error) {
+	dto, err := processTransferRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp := s.service.RejectTransfer(callerFromContext(ctx).contract, ctx, dto)
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return transferToProto(resp.Value), nil
+}
+
+// processTransferRequest converts an accept or reject request, which is received now unless it says otherwise.
+func processTransferRequest(req *medtracev1.ProcessTransferRequest) (*transfer.ProcessTransferRequest, error) {
+	dto := transfer.ProcessTransferRequest{TransferID: req.GetTransferId(), ReceiveDate: timeOf(req.GetReceiveDate())}
+	if err := validate(&dto); err != nil {
+		return nil, err
+	}
+	if dto.ReceiveDate == nil {
+		now := time.Now()
+		dto.ReceiveDate = &now
+	}
+	return &dto, nil
+}
+
+func (s *transferServer) CancelTransfer(ctx context.Context, req *medtracev1.CancelTransferRequest) (*medtracev1.Transfer, error) {
+	if req.GetTransferId() == "" {
+		return nil, invalidArgument("Transfer ID is required")
+	}
+	resp := s.service.CancelTransfer(callerFromContext(ctx).contract, ctx, req.GetTransferId())
+	if !resp.Success {
+		return nil, statusError(resp.Error)
+	}
+	return transferToProto(resp.Value), nil
+}
+
+// WatchTransfers sends an event for every transfer of the caller that appeared or changed status since the
+// previous poll. The chaincode does not emit events for transfers, so the ledger is polled every watchInterval
+// through the same service calls as ListMyTransfers, by one poller shared by the organization's streams in the
+// same direction. A failed poll ends the stream, and so does the expiry of the caller's access token.
+func (s *transferServer) WatchTransfers(req *medtracev1.WatchTransfersRequest, stream grpc.ServerStreamingServer[medtracev1.TransferEvent]) error {
+	ctx := stream.Context()
+	c := callerFromContext(ctx)
+	polls, unsubscribe, errInfo := s.watches.subscribe(c.orgID, req.GetDirection())
+	if errInfo != nil {
+		return statusError(errInfo)
+	}
+	defer unsubscribe()
+
+	var expired <-chan time.Time
+	if !c.expiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(c.expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	statuses := map[string]medtracev1.TransferStatus{}
+	first := true
+	for {
+		var poll transferPoll
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-expired:
+			return statusError(response.NewError(http.StatusUnauthorized, "Access token has expired"))
+		case poll = <-polls:
+		}
+		if poll.err != nil {
+			return statusError(poll.err)
+		}
+
+		observedAt := timestamppb.Now()
+		for _, t := range poll.transfers {
+			pb := transferToProto(t)
+			previous, known := statuses[t.ID]
+			statuses[t.ID] = pb.Status
+
+			event := &medtracev1.TransferEvent{Transfer: pb, ObservedAt: observedAt}
+			switch {
+			case first && !req.GetIncludeExisting():
+				continue
+			case first:
+				event.Type = medtracev1.TransferEventType_TRANSFER_EVENT_TYPE_EXISTING
+			case !known:
+				event.Type = medtracev1.TransferEventType_TRANSFER_EVENT_TYPE_CREATED
+			case previous != pb.Status:
+				event.Type = medtracev1.TransferEventType_TRANSFER_EVENT_TYPE_STATUS_CHANGED
+				event.PreviousStatus = previous
+			default:
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		first = false
+	}
+}
+
+// listTransfers lists the transfers of the contract's organization in a direction.
+func listTransfers(service *services.TransferService, contract *client.Contract, ctx context.Context, direction medtracev1.TransferDirection) response.BaseListResponse[entity.Transfer] {
+	switch direction {
+	case medtracev1.TransferDirection_TRANSFER_DIRECTION_INCOMING:
+		return service.GetMyInTransfer(contract, ctx)
+	case medtracev1.TransferDirection_TRANSFER_DIRECTION_OUTGOING:
+		return service.GetMyOutTransfer(contract, ctx)
+	default:
+		return service.GetMyTransfers(contract, ctx)
+	}
+}
@@ -0,0 +1,155 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	medtracev1 "github.com/AryaJayadi/MedTrace_api/pkg/pb/medtrace/v1"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// MaxWatchesPerOrg caps the WatchTransfers streams an organization may hold open at once.
+const MaxWatchesPerOrg = 100
+
+// transferPoll is the outcome of one poll of an organization's transfers.
+type transferPoll struct {
+	transfers []*entity.Transfer
+	err       *response.ErrorInfo
+}
+
+type watchKey struct {
+	orgID     string
+	direction medtracev1.TransferDirection
+}
+
+// watchHub shares one poller per organization and direction between the WatchTransfers streams, so that the
+// ledger is read, and a gateway held, once per poll whatever the number of subscribers.
+type watchHub struct {
+	service  *services.TransferService
+	interval time.Duration
+
+	mu      sync.Mutex
+	pollers map[watchKey]*transferPoller
+	watches map[string]int // Open streams by organization
+}
+
+func newWatchHub(service *services.TransferService, interval time.Duration) *watchHub {
+	return &watchHub{service: service, interval: interval, pollers: map[watchKey]*transferPoller{}, watches: map[string]int{}}
+}
+
+// transferPoller polls the transfers of one organization in one direction while it has subscribers.
+type transferPoller struct {
+	hub    *watchHub
+	key    watchKey
+	cancel context.CancelFunc
+	subs   map[chan transferPoll]bool // Guarded by hub.mu
+	last   *transferPoll              // Latest successful poll, guarded by hub.mu
+}
+
+// subscribe returns a channel receiving every poll of the organization's transfers in a direction, starting with
+// the latest one, and the function that ends the subscription. Slow subscribers only get the latest poll.
+func (h *watchHub) subscribe(orgID string, direction medtracev1.TransferDirection) (<-chan transferPoll, func(), *response.ErrorInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watches[orgID] >= MaxWatchesPerOrg {
+		return nil, nil, response.NewError(http.StatusTooManyRequests, "Organization %s already has %d transfer watches open", orgID, MaxWatchesPerOrg)
+	}
+	key := watchKey{orgID: orgID, direction: direction}
+	p := h.pollers[key]
+	if p == nil {
+		ctx, cancel := context.WithCancel(logging.With(context.Background(), slog.String(logging.OrgKey, orgID)))
+		p = &transferPoller{hub: h, key: key, cancel: cancel, subs: map[chan transferPoll]bool{}}
+		h.pollers[key] = p
+		go p.run(ctx)
+	}
+	ch := make(chan transferPoll, 1)
+	if p.last != nil {
+		ch <- *p.last
+	}
+	p.subs[ch] = true
+	h.watches[orgID]++
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if !p.subs[ch] {
+			return
+		}
+		delete(p.subs, ch)
+		h.watches[orgID]--
+		if h.watches[orgID] == 0 {
+			delete(h.watches, orgID)
+		}
+		if len(p.subs) == 0 {
+			p.cancel()
+			delete(h.pollers, key)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// run polls every hub interval until the last subscriber leaves. The gateway is opened on the first poll and
+// held until then; if it cannot be opened, the next poll tries again.
+func (p *transferPoller) run(ctx context.Context) {
+	var contract *client.Contract
+	closeGateway := func() error { return nil }
+	defer func() {
+		if errClose := closeGateway(); errClose != nil {
+			logger.ErrorContext(ctx, "WatchTransfers: Error closing Fabric gateway", "error", errClose)
+		}
+	}()
+
+	ticker := time.NewTicker(p.hub.interval)
+	defer ticker.Stop()
+	for {
+		if contract == nil {
+			var err error
+			contract, closeGateway, err = auth.NewContractForOrg(p.key.orgID)
+			if err != nil {
+				logger.ErrorContext(ctx, "WatchTransfers: Failed to connect to network", "error", err)
+				closeGateway = func() error { return nil }
+				p.publish(transferPoll{err: response.NewError(http.StatusInternalServerError, "Failed to connect to network for organization %s", p.key.orgID)})
+			}
+		}
+		if contract != nil {
+			resp := listTransfers(p.hub.service, contract, ctx, p.key.direction)
+			poll := transferPoll{transfers: resp.List, err: resp.Error}
+			if !resp.Success && poll.err == nil {
+				poll.err = response.NewError(http.StatusInternalServerError, "Failed to list transfers")
+			}
+			p.publish(poll)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish hands a poll to every subscriber, replacing a poll the subscriber has not received yet.
+func (p *transferPoller) publish(poll transferPoll) {
+	p.hub.mu.Lock()
+	defer p.hub.mu.Unlock()
+	if poll.err == nil {
+		p.last = &poll
+	} else {
+		p.last = nil
+	}
+	for ch := range p.subs {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- poll
+	}
+}
@@ -0,0 +1,253 @@
+package gs1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Application identifiers understood by MedTrace.
+const (
+	AISSCC           = "00"
+	AIGTIN           = "01"
+	AILot            = "10"
+	AIProductionDate = "11"
+	AIExpiryDate     = "17"
+	AISerial         = "21"
+)
+
+// groupSeparator (ASCII GS) terminates variable-length fields in unbracketed element strings; it stands in for FNC1.
+const groupSeparator = '\x1d'
+
+type aiSpec struct {
+	fixed int // Exact length of a fixed-length field, or 0
+	max   int // Maximum length of a variable-length field
+}
+
+var applicationIdentifiers = map[string]aiSpec{
+	AISSCC:           {fixed: 18},
+	AIGTIN:           {fixed: 14},
+	AILot:            {max: 20},
+	AIProductionDate: {fixed: 6},
+	AIExpiryDate:     {fixed: 6},
+	AISerial:         {max: 20},
+}
+
+// Identifiers holds the GS1 data carried by a scanned code.
+type Identifiers struct {
+	GTIN           string    // AI (01), normalized to 14 digits
+	Serial         string    // AI (21)
+	Lot            string    // AI (10), the batch ID
+	SSCC           string    // AI (00)
+	ExpiryDate     time.Time // AI (17), zero if absent
+	ProductionDate time.Time // AI (11), zero if absent
+}
+
+// SGTIN returns the serialized GTIN if the code identifies a single pack.
+func (ids Identifiers) SGTIN() (SGTIN, bool) {
+	if ids.GTIN == "" || ids.Serial == "" {
+		return SGTIN{}, false
+	}
+	return SGTIN{GTIN: ids.GTIN, Serial: ids.Serial}, true
+}
+
+// Parse reads a scanned GS1 code: a Digital Link URI or an element string, bracketed or with GS separators.
+func Parse(code string) (Identifiers, error) {
+	code = strings.TrimSpace(code)
+	if strings.HasPrefix(code, "http://") || strings.HasPrefix(code, "https://") {
+		return ParseDigitalLink(code)
+	}
+	return ParseElementString(code)
+}
+
+var bracketedAI = regexp.MustCompile(`\((\d{2,4})\)([^(]*)`)
+
+// ParseElementString parses a GS1 element string such as "(01)09506000134352(17)271231(10)LOT1(21)S1"
+// or its scanner form "01095060001343521727123110LOT1<GS>21S1", optionally prefixed by a symbology identifier like "]d2".
+func ParseElementString(s string) (Identifiers, error) {
+	if len(s) >= 3 && s[0] == ']' {
+		s = s[3:]
+	}
+	s = strings.TrimLeft(s, string(groupSeparator))
+	if s == "" {
+		return Identifiers{}, fmt.Errorf("empty GS1 element string")
+	}
+
+	values := make(map[string]string)
+	if s[0] == '(' {
+		matches := bracketedAI.FindAllStringSubmatch(s, -1)
+		if len(matches) == 0 || strings.Join(flattenMatches(matches), "") != s {
+			return Identifiers{}, fmt.Errorf("malformed GS1 element string '%s'", s)
+		}
+		for _, m := range matches {
+			if _, ok := applicationIdentifiers[m[1]]; !ok {
+				return Identifiers{}, fmt.Errorf("unsupported GS1 application identifier (%s)", m[1])
+			}
+			values[m[1]] = m[2]
+		}
+		return fromValues(values)
+	}
+
+	for s != "" {
+		if len(s) < 2 {
+			return Identifiers{}, fmt.Errorf("truncated GS1 element string")
+		}
+		ai := s[:2]
+		spec, ok := applicationIdentifiers[ai]
+		if !ok {
+			return Identifiers{}, fmt.Errorf("unsupported GS1 application identifier (%s)", ai)
+		}
+		s = s[2:]
+		var value string
+		if spec.fixed > 0 {
+			if len(s) < spec.fixed {
+				return Identifiers{}, fmt.Errorf("GS1 field (%s) must have %d characters", ai, spec.fixed)
+			}
+			value, s = s[:spec.fixed], s[spec.fixed:]
+		} else {
+			end := strings.IndexByte(s, groupSeparator)
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		s = strings.TrimPrefix(s, string(groupSeparator))
+		values[ai] = value
+	}
+	return fromValues(values)
+}
+
+func flattenMatches(matches [][]string) []string {
+	parts := make([]string, len(matches))
+	for i, m := range matches {
+		parts[i] = m[0]
+	}
+	return parts
+}
+
+// digitalLinkKeys maps the short names allowed in Digital Link paths to their application identifiers.
+var digitalLinkKeys = map[string]string{
+	"gtin":  AIGTIN,
+	"sscc":  AISSCC,
+	"lot":   AILot,
+	"ser":   AISerial,
+	"exp":   AIExpiryDate,
+	"prodd": AIProductionDate,
+}
+
+// ParseDigitalLink parses a GS1 Digital Link URI such as
+// "https://id.gs1.org/01/09506000134352/10/LOT1/21/S1?17=271231". Any path prefix before the primary key is ignored;
+// every path segment after it must be a supported application identifier. Query parameters never override the path.
+func ParseDigitalLink(uri string) (Identifiers, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return Identifiers{}, fmt.Errorf("invalid GS1 Digital Link URI: %w", err)
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	start := -1
+	for i, seg := range segments {
+		if key := dlKey(seg); key == AIGTIN || key == AISSCC {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return Identifiers{}, fmt.Errorf("GS1 Digital Link URI '%s' has no GTIN or SSCC", uri)
+	}
+	if (len(segments)-start)%2 != 0 {
+		return Identifiers{}, fmt.Errorf("GS1 Digital Link URI '%s' has an incomplete path", uri)
+	}
+
+	values := make(map[string]string)
+	for i := start; i < len(segments); i += 2 {
+		ai := dlKey(segments[i])
+		if ai == "" {
+			return Identifiers{}, fmt.Errorf("unsupported GS1 application identifier '%s' in Digital Link path", segments[i])
+		}
+		if _, dup := values[ai]; dup {
+			return Identifiers{}, fmt.Errorf("GS1 application identifier (%s) appears twice in Digital Link path", ai)
+		}
+		value, err := url.PathUnescape(segments[i+1])
+		if err != nil {
+			return Identifiers{}, fmt.Errorf("invalid GS1 Digital Link path value '%s': %w", segments[i+1], err)
+		}
+		values[ai] = value
+	}
+	for key, vals := range u.Query() {
+		ai := dlKey(key)
+		if _, inPath := values[ai]; ai == "" || inPath || len(vals) == 0 {
+			continue
+		}
+		values[ai] = vals[0]
+	}
+	return fromValues(values)
+}
+
+// dlKey resolves a Digital Link path or query key to a supported application identifier, or "".
+func dlKey(key string) string {
+	if _, ok := applicationIdentifiers[key]; ok {
+		return key
+	}
+	return digitalLinkKeys[key]
+}
+
+// fromValues validates raw application identifier values and converts them into Identifiers.
+func fromValues(values map[string]string) (Identifiers, error) {
+	var ids Identifiers
+	var err error
+	for ai, value := range values {
+		spec := applicationIdentifiers[ai]
+		// GTINs are normalized below, so shorter GTIN-8/12/13 values are accepted as well.
+		if spec.fixed > 0 && ai != AIGTIN && len(value) != spec.fixed {
+			return Identifiers{}, fmt.Errorf("GS1 field (%s) must have %d characters", ai, spec.fixed)
+		}
+		switch ai {
+		case AIGTIN:
+			ids.GTIN, err = NormalizeGTIN(value)
+		case AISSCC:
+			ids.SSCC, err = value, ValidateSSCC(value)
+		case AILot:
+			ids.Lot, err = value, validateAlphanumeric("lot", value, spec.max)
+		case AISerial:
+			ids.Serial, err = value, validateAlphanumeric("serial", value, spec.max)
+		case AIExpiryDate:
+			ids.ExpiryDate, err = parseDate(value)
+		case AIProductionDate:
+			ids.ProductionDate, err = parseDate(value)
+		}
+		if err != nil {
+			return Identifiers{}, err
+		}
+	}
+	return ids, nil
+}
+
+// parseDate parses a GS1 YYMMDD date. A day of "00" means the last day of the month.
+func parseDate(value string) (time.Time, error) {
+	if !isDigits(value) {
+		return time.Time{}, fmt.Errorf("GS1 date '%s' must be YYMMDD", value)
+	}
+	lastDay := value[4:] == "00"
+	if lastDay {
+		value = value[:4] + "01"
+	}
+	t, err := time.Parse("060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GS1 date '%s' must be YYMMDD: %w", value, err)
+	}
+	if t.Year() < 2000 {
+		t = t.AddDate(100, 0, 0)
+	}
+	if lastDay {
+		t = t.AddDate(0, 1, -1)
+	}
+	return t, nil
+}
+
+// FormatDate formats t as a GS1 YYMMDD date.
+func FormatDate(t time.Time) string {
+	return t.Format("060102")
+}
@@ -0,0 +1,235 @@
+package gs1
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testGTIN = "09506000134352"
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseElementString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Identifiers
+		err  string // Substring of the error; empty if the string parses
+	}{
+		{
+			name: "bracketed",
+			in:   "(01)09506000134352(17)271231(10)LOT1(21)S1",
+			want: Identifiers{GTIN: testGTIN, ExpiryDate: date(2027, 12, 31), Lot: "LOT1", Serial: "S1"},
+		},
+		{
+			name: "bracketed GTIN-13",
+			in:   "(01)4006381333931(21)S1",
+			want: Identifiers{GTIN: "04006381333931", Serial: "S1"},
+		},
+		{
+			name: "group separator after variable-length field",
+			in:   "01095060001343521727123110LOT1\x1d21S1",
+			want: Identifiers{GTIN: testGTIN, ExpiryDate: date(2027, 12, 31), Lot: "LOT1", Serial: "S1"},
+		},
+		{
+			name: "symbology identifier and leading FNC1",
+			in:   "]d2\x1d010950600013435221S1",
+			want: Identifiers{GTIN: testGTIN, Serial: "S1"},
+		},
+		{
+			name: "variable-length field without separator takes the rest",
+			in:   "010950600013435210LOT121S1",
+			want: Identifiers{GTIN: testGTIN, Lot: "LOT121S1"},
+		},
+		{
+			name: "SSCC and production date",
+			in:   "(00)106141412345678908(11)260115",
+			want: Identifiers{SSCC: "106141412345678908", ProductionDate: date(2026, 1, 15)},
+		},
+		{
+			name: "day 00 is the last day of the month",
+			in:   "(01)09506000134352(17)270200",
+			want: Identifiers{GTIN: testGTIN, ExpiryDate: date(2027, 2, 28)},
+		},
+		{
+			name: "invalid GTIN check digit",
+			in:   "(01)09506000134353",
+			err:  "invalid check digit",
+		},
+		{
+			name: "unsupported application identifier",
+			in:   "(01)09506000134352(99)X",
+			err:  "unsupported GS1 application identifier (99)",
+		},
+		{
+			name: "unbracketed unsupported application identifier",
+			in:   "9912345",
+			err:  "unsupported GS1 application identifier (99)",
+		},
+		{
+			name: "truncated fixed-length field",
+			in:   "0109506000",
+			err:  "must have 14 characters",
+		},
+		{
+			name: "unclosed bracket",
+			in:   "(01)09506000134352(21",
+			err:  "malformed GS1 element string",
+		},
+		{
+			name: "invalid date",
+			in:   "(17)271332",
+			err:  "must be YYMMDD",
+		},
+		{
+			name: "serial outside CSET 82",
+			in:   "(21)S 1",
+			err:  "invalid character",
+		},
+		{
+			name: "serial too long",
+			in:   "(21)" + strings.Repeat("9", 21),
+			err:  "longer than 20 characters",
+		},
+		{
+			name: "empty",
+			in:   "]d2",
+			err:  "empty GS1 element string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseElementString(tt.in)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseElementString error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseElementString: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseElementString = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDigitalLink(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Identifiers
+		err  string // Substring of the error; empty if the URI parses
+	}{
+		{
+			name: "path and query",
+			in:   "https://id.gs1.org/01/09506000134352/10/LOT1/21/S1?17=271231",
+			want: Identifiers{GTIN: testGTIN, ExpiryDate: date(2027, 12, 31), Lot: "LOT1", Serial: "S1"},
+		},
+		{
+			name: "path prefix and short names",
+			in:   "https://example.com/medtrace/gtin/09506000134352/lot/LOT1/ser/S1?exp=271231",
+			want: Identifiers{GTIN: testGTIN, ExpiryDate: date(2027, 12, 31), Lot: "LOT1", Serial: "S1"},
+		},
+		{
+			name: "escaped path value",
+			in:   "https://id.gs1.org/01/09506000134352/21/A%2FB%25",
+			want: Identifiers{GTIN: testGTIN, Serial: "A/B%"},
+		},
+		{
+			name: "query does not override the path",
+			in:   "https://id.gs1.org/01/09506000134352/21/S1?21=S2&10=LOT1",
+			want: Identifiers{GTIN: testGTIN, Lot: "LOT1", Serial: "S1"},
+		},
+		{
+			name: "unknown query parameters are ignored",
+			in:   "https://id.gs1.org/01/09506000134352?linkType=all",
+			want: Identifiers{GTIN: testGTIN},
+		},
+		{
+			name: "SSCC",
+			in:   "https://id.gs1.org/00/106141412345678908",
+			want: Identifiers{SSCC: "106141412345678908"},
+		},
+		{
+			name: "unknown path application identifier",
+			in:   "https://id.gs1.org/01/09506000134352/22/CPV1",
+			err:  "unsupported GS1 application identifier '22'",
+		},
+		{
+			name: "repeated path application identifier",
+			in:   "https://id.gs1.org/01/09506000134352/21/S1/ser/S2",
+			err:  "appears twice",
+		},
+		{
+			name: "incomplete path",
+			in:   "https://id.gs1.org/01/09506000134352/21",
+			err:  "incomplete path",
+		},
+		{
+			name: "no primary key",
+			in:   "https://id.gs1.org/10/LOT1",
+			err:  "has no GTIN or SSCC",
+		},
+		{
+			name: "invalid query value",
+			in:   "https://id.gs1.org/01/09506000134352?17=2712",
+			err:  "must have 6 characters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDigitalLink(tt.in)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseDigitalLink error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDigitalLink: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDigitalLink = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  Identifiers
+	}{
+		{name: "GTIN only", ids: Identifiers{GTIN: testGTIN}},
+		{name: "SGTIN", ids: Identifiers{GTIN: testGTIN, Serial: "S1"}},
+		{
+			name: "every field of a pack",
+			ids:  Identifiers{GTIN: testGTIN, Lot: "LOT-1/A", Serial: "S&1?", ExpiryDate: date(2027, 12, 31), ProductionDate: date(2025, 6, 1)},
+		},
+		{name: "lot and serial", ids: Identifiers{GTIN: testGTIN, Lot: "L1", Serial: "S1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encodings := map[string]string{
+				"human readable": tt.ids.HumanReadable(),
+				"element string": tt.ids.ElementString(string(groupSeparator)),
+				"Digital Link":   tt.ids.DigitalLink("https://id.example.com/"),
+			}
+			for form, code := range encodings {
+				got, err := Parse(code)
+				if err != nil {
+					t.Fatalf("Parse %s %q: %v", form, code, err)
+				}
+				if got != tt.ids {
+					t.Errorf("Parse %s %q = %+v, want %+v", form, code, got, tt.ids)
+				}
+			}
+		})
+	}
+}
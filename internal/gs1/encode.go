@@ -0,0 +1,85 @@
+package gs1
+
+import (
+	"net/url"
+	"strings"
+)
+
+// field is one application identifier and its value, in the order it is encoded.
+type field struct {
+	ai    string
+	value string
+}
+
+// fields lists the populated identifiers with fixed-length fields first, so that only
+// variable-length fields (lot, serial) ever need a separator.
+func (ids Identifiers) fields() []field {
+	var fs []field
+	if ids.SSCC != "" {
+		fs = append(fs, field{AISSCC, ids.SSCC})
+	}
+	if ids.GTIN != "" {
+		fs = append(fs, field{AIGTIN, ids.GTIN})
+	}
+	if !ids.ProductionDate.IsZero() {
+		fs = append(fs, field{AIProductionDate, FormatDate(ids.ProductionDate)})
+	}
+	if !ids.ExpiryDate.IsZero() {
+		fs = append(fs, field{AIExpiryDate, FormatDate(ids.ExpiryDate)})
+	}
+	if ids.Lot != "" {
+		fs = append(fs, field{AILot, ids.Lot})
+	}
+	if ids.Serial != "" {
+		fs = append(fs, field{AISerial, ids.Serial})
+	}
+	return fs
+}
+
+// HumanReadable returns the bracketed element string printed under a barcode, e.g. "(01)09506000134352(21)S1".
+func (ids Identifiers) HumanReadable() string {
+	var b strings.Builder
+	for _, f := range ids.fields() {
+		b.WriteString("(" + f.ai + ")" + f.value)
+	}
+	return b.String()
+}
+
+// ElementString returns the unbracketed element string with sep after every variable-length field that is not last.
+// Barcode encoders pass FNC1 as sep; "\x1d" yields what a scanner transmits.
+func (ids Identifiers) ElementString(sep string) string {
+	fs := ids.fields()
+	var b strings.Builder
+	for i, f := range fs {
+		b.WriteString(f.ai + f.value)
+		if applicationIdentifiers[f.ai].fixed == 0 && i < len(fs)-1 {
+			b.WriteString(sep)
+		}
+	}
+	return b.String()
+}
+
+// DigitalLink returns the GS1 Digital Link URI for ids under base, e.g.
+// "https://example.com/01/09506000134352/10/LOT1/21/S1?17=271231". ids must carry a GTIN.
+func (ids Identifiers) DigitalLink(base string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(base, "/"))
+	b.WriteString("/" + AIGTIN + "/" + ids.GTIN)
+	if ids.Lot != "" {
+		b.WriteString("/" + AILot + "/" + url.PathEscape(ids.Lot))
+	}
+	if ids.Serial != "" {
+		b.WriteString("/" + AISerial + "/" + url.PathEscape(ids.Serial))
+	}
+	q := url.Values{}
+	if !ids.ExpiryDate.IsZero() {
+		q.Set(AIExpiryDate, FormatDate(ids.ExpiryDate))
+	}
+	if !ids.ProductionDate.IsZero() {
+		q.Set(AIProductionDate, FormatDate(ids.ProductionDate))
+	}
+	if len(q) > 0 {
+		b.WriteString("?" + q.Encode())
+	}
+	return b.String()
+}
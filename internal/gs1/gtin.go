@@ -0,0 +1,71 @@
+package gs1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckDigit computes the GS1 mod-10 check digit for a string of digits that does not yet include one.
+func CheckDigit(digits string) (byte, error) {
+	if !isDigits(digits) {
+		return 0, fmt.Errorf("'%s' contains non-digit characters", digits)
+	}
+	sum := 0
+	// Weights alternate 3,1,3,... starting from the rightmost digit.
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// validCheckDigit reports whether the last digit of s is the correct check digit for the rest.
+func validCheckDigit(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	want, err := CheckDigit(s[:len(s)-1])
+	return err == nil && s[len(s)-1] == want
+}
+
+// NormalizeGTIN validates a GTIN-8, GTIN-12, GTIN-13 or GTIN-14 and returns it as a 14-digit GTIN.
+func NormalizeGTIN(gtin string) (string, error) {
+	switch len(gtin) {
+	case 8, 12, 13, 14:
+	default:
+		return "", fmt.Errorf("GTIN '%s' must have 8, 12, 13 or 14 digits", gtin)
+	}
+	if !isDigits(gtin) {
+		return "", fmt.Errorf("GTIN '%s' must contain only digits", gtin)
+	}
+	if !validCheckDigit(gtin) {
+		return "", fmt.Errorf("GTIN '%s' has an invalid check digit", gtin)
+	}
+	return strings.Repeat("0", 14-len(gtin)) + gtin, nil
+}
+
+// ValidateSSCC checks that sscc is an 18-digit Serial Shipping Container Code with a valid check digit.
+func ValidateSSCC(sscc string) error {
+	if len(sscc) != 18 || !isDigits(sscc) {
+		return fmt.Errorf("SSCC '%s' must have exactly 18 digits", sscc)
+	}
+	if !validCheckDigit(sscc) {
+		return fmt.Errorf("SSCC '%s' has an invalid check digit", sscc)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package gs1
+
+import "testing"
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+		err    bool
+	}{
+		{digits: "0950600013435", want: '2'},
+		{digits: "400638133393", want: '1'},
+		{digits: "9638507", want: '4'},
+		{digits: "10614141234567890", want: '8'},
+		{digits: "000000000000", want: '0'},
+		{digits: "", err: true},
+		{digits: "12A4", err: true},
+	}
+	for _, tt := range tests {
+		got, err := CheckDigit(tt.digits)
+		if tt.err {
+			if err == nil {
+				t.Errorf("CheckDigit(%q) = %c, want an error", tt.digits, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("CheckDigit(%q) = %c, %v, want %c", tt.digits, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeGTIN(t *testing.T) {
+	tests := []struct {
+		gtin string
+		want string // Empty if the GTIN is invalid
+	}{
+		{gtin: "96385074", want: "00000096385074"},
+		{gtin: "036000291452", want: "00036000291452"},
+		{gtin: "4006381333931", want: "04006381333931"},
+		{gtin: "09506000134352", want: "09506000134352"},
+		{gtin: "09506000134353"},
+		{gtin: "0950600013435"},
+		{gtin: "095060001343521"},
+		{gtin: "0950600013435X"},
+		{gtin: ""},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeGTIN(tt.gtin)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("NormalizeGTIN(%q) = %s, want an error", tt.gtin, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeGTIN(%q) = %s, %v, want %s", tt.gtin, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateSSCC(t *testing.T) {
+	tests := []struct {
+		sscc  string
+		valid bool
+	}{
+		{sscc: "106141412345678908", valid: true},
+		{sscc: "106141412345678909"},
+		{sscc: "10614141234567890"},
+		{sscc: "10614141234567890A"},
+	}
+	for _, tt := range tests {
+		if err := ValidateSSCC(tt.sscc); (err == nil) != tt.valid {
+			t.Errorf("ValidateSSCC(%q) = %v, want valid %v", tt.sscc, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gs1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sgtinSeparator joins GTIN and serial in the drug IDs used on the ledger, e.g. "09506000134352.ABC123".
+// A GTIN never contains a dot, so the first dot always marks the start of the serial.
+const sgtinSeparator = "."
+
+// SGTIN is a serialized GTIN: the identity of a single saleable pack.
+type SGTIN struct {
+	GTIN   string // 14-digit GTIN
+	Serial string // Serial number, AI (21)
+}
+
+// NewSGTIN validates gtin and serial and returns the SGTIN with the GTIN normalized to 14 digits.
+func NewSGTIN(gtin, serial string) (SGTIN, error) {
+	normalized, err := NormalizeGTIN(gtin)
+	if err != nil {
+		return SGTIN{}, err
+	}
+	if err := validateAlphanumeric("serial", serial, 20); err != nil {
+		return SGTIN{}, err
+	}
+	return SGTIN{GTIN: normalized, Serial: serial}, nil
+}
+
+// ParseSGTIN parses a drug ID in the "<GTIN-14>.<serial>" form produced by SGTIN.String.
+func ParseSGTIN(id string) (SGTIN, error) {
+	gtin, serial, ok := strings.Cut(id, sgtinSeparator)
+	if !ok || len(gtin) != 14 {
+		return SGTIN{}, fmt.Errorf("'%s' is not an SGTIN drug ID", id)
+	}
+	return NewSGTIN(gtin, serial)
+}
+
+// String returns the drug ID used on the ledger for this SGTIN.
+func (s SGTIN) String() string {
+	return s.GTIN + sgtinSeparator + s.Serial
+}
+
+// ValidateLot checks that lot fits a GS1 batch or lot number, AI (10): at most 20 CSET 82 characters.
+func ValidateLot(lot string) error {
+	return validateAlphanumeric("lot", lot, applicationIdentifiers[AILot].max)
+}
+
+// validateAlphanumeric checks a variable-length GS1 field against the CSET 82 character set and a maximum length.
+func validateAlphanumeric(name, value string, maxLen int) error {
+	if value == "" {
+		return fmt.Errorf("%s cannot be empty", name)
+	}
+	if len(value) > maxLen {
+		return fmt.Errorf("%s '%s' is longer than %d characters", name, value, maxLen)
+	}
+	for i := 0; i < len(value); i++ {
+		if !isCSET82(value[i]) {
+			return fmt.Errorf("%s '%s' contains the invalid character %q", name, value, value[i])
+		}
+	}
+	return nil
+}
+
+// isCSET82 reports whether c belongs to GS1 character set 82 (a printable subset of ASCII).
+func isCSET82(c byte) bool {
+	switch {
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte(`!"%&'()*+,-./:;<=>?_`, c) >= 0
+}
@@ -4,8 +4,11 @@ import (
 	"net/http"
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -33,24 +36,27 @@ func NewBatchHandler(service *services.BatchService) *BatchHandler {
 func (h *BatchHandler) CreateBatch(c echo.Context) error {
 	var req batch.CreateBatch
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Invalid request payload: " + err.Error()}})
+		return validation.BindError(err)
+	}
+	if req.GTIN != "" {
+		gtin, err := gs1.NormalizeGTIN(req.GTIN)
+		if err != nil {
+			return response.NewError(http.StatusBadRequest, "%v", err)
+		}
+		req.GTIN = gtin
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler CreateBatch: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler CreateBatch: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.CreateBatch(contract, c.Request().Context(), &req)
-	status := http.StatusCreated
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusCreated, resp)
 }
 
 // GetBatchByID godoc
@@ -69,24 +75,20 @@ func (h *BatchHandler) CreateBatch(c echo.Context) error {
 func (h *BatchHandler) GetBatchByID(c echo.Context) error {
 	batchID := c.Param("id")
 	if batchID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Batch ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetBatchByID: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetBatchByID: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetBatchByID(contract, c.Request().Context(), batchID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetAllBatches godoc
@@ -94,6 +96,8 @@ func (h *BatchHandler) GetBatchByID(c echo.Context) error {
 // @Description Retrieve all batches from the ledger.
 // @Tags batches
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Batch]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -102,19 +106,15 @@ func (h *BatchHandler) GetBatchByID(c echo.Context) error {
 func (h *BatchHandler) GetAllBatches(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetAllBatches: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetAllBatches: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetAllBatches(contract, c.Request().Context())
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
 
 // UpdateBatch godoc
@@ -123,40 +123,37 @@ func (h *BatchHandler) GetAllBatches(c echo.Context) error {
 // @Tags batches
 // @Accept json
 // @Produce json
+// @Param id path string true "Batch ID"
 // @Param batch body batch.UpdateBatch true "Batch update details"
 // @Success 200 {object} response.BaseValueResponse[entity.Batch]
 // @Failure 400 {object} response.BaseResponse "Invalid request payload"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 404 {object} response.BaseResponse "Batch not found to update"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
-// @Router /batches [patch]  // Consider /batches/{id} if updating a specific batch by ID in path
+// @Router /batches/{id} [patch]
 // @Security BearerAuth
 func (h *BatchHandler) UpdateBatch(c echo.Context) error {
 	batchID := c.Param("id")
 	if batchID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Batch ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
 	}
 
 	var req batch.UpdateBatch
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Invalid request payload: " + err.Error()}})
+		return validation.BindError(err)
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler UpdateBatch: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler UpdateBatch: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.UpdateBatch(contract, c.Request().Context(), batchID, &req)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // BatchExists godoc
@@ -174,22 +171,84 @@ func (h *BatchHandler) UpdateBatch(c echo.Context) error {
 func (h *BatchHandler) BatchExists(c echo.Context) error {
 	batchID := c.Param("id")
 	if batchID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Batch ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler BatchExists: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler BatchExists: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.BatchExists(contract, c.Request().Context(), batchID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetHistoryBatch godoc
+// @Summary Get the history of a batch
+// @Description Retrieve the ledger key history of a batch, one record per transaction that wrote it, including deletion.
+// @Tags batches
+// @Produce json
+// @Param id path string true "Batch ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryBatch]
+// @Failure 400 {object} response.BaseResponse "Invalid Batch ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /batches/{id}/history [get]
+// @Security BearerAuth
+func (h *BatchHandler) GetHistoryBatch(c echo.Context) error {
+	batchID := c.Param("id")
+	if batchID == "" {
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetHistoryBatch: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetHistoryBatch(contract, c.Request().Context(), batchID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetBatchChanges godoc
+// @Summary Get the field changes of a batch
+// @Description Compare each version in the key history of a batch with the one before it and list the fields that changed, with old and new values.
+// @Tags batches
+// @Produce json
+// @Param id path string true "Batch ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryChange]
+// @Failure 400 {object} response.BaseResponse "Invalid Batch ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /batches/{id}/history/changes [get]
+// @Security BearerAuth
+func (h *BatchHandler) GetBatchChanges(c echo.Context) error {
+	batchID := c.Param("id")
+	if batchID == "" {
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetBatchChanges: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetBatchChanges(contract, c.Request().Context(), batchID)
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// docsPage renders the OpenAPI document with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>MedTrace API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui", persistAuthorization: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+type DocsHandler struct {
+	Spec []byte
+}
+
+// NewDocsHandler creates a new DocsHandler serving the given OpenAPI document
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{Spec: spec}
+}
+
+// GetSpec godoc
+// @Summary Get the OpenAPI document
+// @Description The OpenAPI 3.1 document of this API, generated from the handlers and request and response types.
+// @Tags docs
+// @Produce json
+// @Success 200 {object} any "OpenAPI document"
+// @Router /openapi.json [get]
+func (h *DocsHandler) GetSpec(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, h.Spec)
+}
+
+// GetDocs godoc
+// @Summary Browse the API documentation
+// @Description Interactive documentation of the OpenAPI document, where requests can be tried out with a JWT.
+// @Tags docs
+// @Produce html
+// @Success 200 {file} binary "Swagger UI page"
+// @Router /docs [get]
+func (h *DocsHandler) GetDocs(c echo.Context) error {
+	return c.HTML(http.StatusOK, docsPage)
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
-	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -37,28 +41,20 @@ func NewDrugHandler(drugService *services.DrugService) *DrugHandler {
 func (h *DrugHandler) CreateDrug(c echo.Context) error {
 	var req drug.CreateDrugRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Invalid request payload: " + err.Error()}})
-	}
-
-	if req.OwnerID == "" || req.BatchID == "" || req.DrugID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "OwnerID, BatchID, and DrugID are required"}})
+		return validation.BindError(err)
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler CreateDrug: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler CreateDrug: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.CreateDrug(contract, c.Request().Context(), &req)
-	status := http.StatusCreated
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusCreated, resp)
 }
 
 // GetDrug godoc
@@ -66,7 +62,7 @@ func (h *DrugHandler) CreateDrug(c echo.Context) error {
 // @Description Retrieve a specific drug asset from the ledger
 // @Tags drugs
 // @Produce json
-// @Param drugID path string true "Drug ID"
+// @Param drugID path string true "Drug ID, or a bracketed GS1 element string or URL-encoded Digital Link URI identifying a single pack"
 // @Success 200 {object} response.BaseValueResponse[entity.Drug]
 // @Failure 400 {object} response.BaseResponse "Invalid Drug ID"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
@@ -75,26 +71,22 @@ func (h *DrugHandler) CreateDrug(c echo.Context) error {
 // @Router /drugs/{drugID} [get]
 // @Security BearerAuth
 func (h *DrugHandler) GetDrug(c echo.Context) error {
-	drugID := c.Param("drugID")
-	if drugID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Drug ID parameter is required"}})
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetDrug: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrug: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetDrug(contract, c.Request().Context(), drugID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetMyDrugs godoc
@@ -102,6 +94,8 @@ func (h *DrugHandler) GetDrug(c echo.Context) error {
 // @Description Retrieve all drug assets owned by the transaction submitter from the ledger
 // @Tags drugs
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Drug]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -110,19 +104,15 @@ func (h *DrugHandler) GetDrug(c echo.Context) error {
 func (h *DrugHandler) GetMyDrugs(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetMyDrugs: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetMyDrugs: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetMyDrugs(contract, c.Request().Context())
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
 
 // GetDrugByBatch godoc
@@ -131,6 +121,8 @@ func (h *DrugHandler) GetMyDrugs(c echo.Context) error {
 // @Tags drugs
 // @Produce json
 // @Param batchID path string true "Batch ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Drug]
 // @Failure 400 {object} response.BaseResponse "Invalid Batch ID"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
@@ -140,24 +132,20 @@ func (h *DrugHandler) GetMyDrugs(c echo.Context) error {
 func (h *DrugHandler) GetDrugByBatch(c echo.Context) error {
 	batchID := c.Param("batchID")
 	if batchID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Batch ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Batch ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetDrugByBatch: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrugByBatch: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetDrugByBatch(contract, c.Request().Context(), batchID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
 
 // GetDrugByTransfer godoc
@@ -166,6 +154,8 @@ func (h *DrugHandler) GetDrugByBatch(c echo.Context) error {
 // @Tags drugs
 // @Produce json
 // @Param transferID path string true "Transfer ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Drug]
 // @Failure 400 {object} response.BaseResponse "Invalid Batch ID"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
@@ -175,24 +165,20 @@ func (h *DrugHandler) GetDrugByBatch(c echo.Context) error {
 func (h *DrugHandler) GetDrugByTransfer(c echo.Context) error {
 	transferID := c.Param("transferID")
 	if transferID == "" {
-		return c.JSON(http.StatusBadRequest, response.ErrorListResponse[entity.Drug](400, "Transfer ID parameter is required"))
+		return response.NewError(400, "Transfer ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetDrugByTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.ErrorListResponse[entity.Drug](500, "Failed to access network resources"))
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrugByTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(500, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetDrugByTransfer(contract, c.Request().Context(), transferID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
 
 // GetMyAvailDrugs godoc
@@ -200,6 +186,8 @@ func (h *DrugHandler) GetDrugByTransfer(c echo.Context) error {
 // @Description Retrieve all drug assets owned by the transaction submitter that are not currently in a pending transfer.
 // @Tags drugs
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Drug]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -208,19 +196,15 @@ func (h *DrugHandler) GetDrugByTransfer(c echo.Context) error {
 func (h *DrugHandler) GetMyAvailDrugs(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetMyAvailDrugs: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetMyAvailDrugs: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetMyAvailDrugs(contract, c.Request().Context())
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
 
 // GetHistoryDrug godoc
@@ -228,29 +212,114 @@ func (h *DrugHandler) GetMyAvailDrugs(c echo.Context) error {
 // @Description Retrieve history records for all drugs, including creation and deletion events.
 // @Tags drugs
 // @Produce json
+// @Param drugID path string true "Drug ID, or a bracketed GS1 element string or URL-encoded Digital Link URI identifying a single pack"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.HistoryDrug]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
 // @Router /history/drug/{drugID} [get]
+// @Router /drugs/history/{drugID} [get]
 func (h *DrugHandler) GetHistoryDrug(c echo.Context) error {
-	drugID := c.Param("drugID")
-	if drugID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Drug ID parameter is required"}})
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetHistoryDrug: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetHistoryDrug: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetHistoryDrug(contract, c.Request().Context(), drugID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetDrugChanges godoc
+// @Summary Get the field changes of a drug
+// @Description Compare each version in the key history of a drug with the one before it and list the fields that changed, with old and new values.
+// @Tags drugs
+// @Produce json
+// @Param drugID path string true "Drug ID, or a bracketed GS1 element string or URL-encoded Digital Link URI identifying a single pack"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryChange]
+// @Failure 400 {object} response.BaseResponse "Invalid drug ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /drugs/history/{drugID}/changes [get]
+// @Security BearerAuth
+func (h *DrugHandler) GetDrugChanges(c echo.Context) error {
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrugChanges: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetDrugChanges(contract, c.Request().Context(), drugID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// resolveDrugID turns the drugID path parameter into a ledger drug ID. Explicit GS1 codes, either bracketed element
+// strings like "(01)09506000134352(21)S1" or URL-encoded Digital Link URIs, that identify a single pack resolve to
+// their SGTIN drug ID; anything else is taken as a plain drug ID.
+func resolveDrugID(c echo.Context) (string, error) {
+	raw := c.Param("drugID")
+	// Echo matches on the raw path, leaving parameters escaped, only when the request path has one; otherwise
+	// the parameter is already decoded and must not be decoded again.
+	if c.Request().URL.RawPath != "" {
+		unescaped, err := url.PathUnescape(raw)
+		if err != nil {
+			return "", fmt.Errorf("Invalid drug ID '%s'", raw)
 		}
+		raw = unescaped
+	}
+	if raw == "" {
+		return "", fmt.Errorf("Drug ID parameter is required")
+	}
+
+	if !strings.HasPrefix(raw, "(") && !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return raw, nil
+	}
+	ids, err := gs1.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("Invalid GS1 code: %v", err)
+	}
+	sgtin, ok := ids.SGTIN()
+	if !ok {
+		return "", fmt.Errorf("GS1 code does not identify a single pack: GTIN (01) and serial (21) are required")
+	}
+	return sgtin.String(), nil
+}
+
+// ResolveDigitalLink godoc
+// @Summary Resolve a GS1 Digital Link
+// @Description Public GS1 Digital Link resolver for the QR codes printed on pack labels. Redirects to the public history of the identified drug.
+// @Tags drugs
+// @Param gtin path string true "GTIN"
+// @Success 302 "Redirect to /history/drug/{drugID}"
+// @Failure 400 {object} response.BaseResponse "Not a Digital Link for a single pack"
+// @Router /01/{gtin} [get]
+func (h *DrugHandler) ResolveDigitalLink(c echo.Context) error {
+	ids, err := gs1.ParseDigitalLink(c.Request().URL.String())
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+	sgtin, ok := ids.SGTIN()
+	if !ok {
+		return response.NewError(http.StatusBadRequest, "Digital Link does not identify a single pack: serial (21) is required")
 	}
-	return c.JSON(status, resp)
+	return c.Redirect(http.StatusFound, "/history/drug/"+url.PathEscape(sgtin.String()))
 }
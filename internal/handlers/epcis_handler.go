@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/epcis"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// EPCIS document media types
+const (
+	mimeJSONLD = "application/ld+json"
+	mimeXML    = "application/xml"
+)
+
+// MaxCaptureSize is the largest EPCIS document accepted by CaptureEvents, in the format of Echo's BodyLimit middleware.
+const MaxCaptureSize = "10M"
+
+// EPCISHandler handles HTTP requests for EPCIS 2.0 event export and capture
+type EPCISHandler struct {
+	Service *services.EPCISService
+}
+
+// NewEPCISHandler creates a new EPCISHandler
+func NewEPCISHandler(service *services.EPCISService) *EPCISHandler {
+	return &EPCISHandler{Service: service}
+}
+
+// ExportDrugEvents godoc
+// @Summary Export the EPCIS events of a drug
+// @Description Export commissioning, shipping, receiving and disposition events of a drug as an EPCIS 2.0 document, derived from its ledger history.
+// @Tags epcis
+// @Produce application/ld+json
+// @Produce application/xml
+// @Param drugID path string true "Drug ID, or a bracketed GS1 element string or URL-encoded Digital Link URI with GTIN and serial"
+// @Param format query string false "json (default) or xml; an Accept header of application/xml also selects XML"
+// @Success 200 {object} epcis.Document
+// @Failure 400 {object} response.BaseResponse "Invalid drug ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Drug not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /epcis/events/drugs/{drugID} [get]
+// @Security BearerAuth
+func (h *EPCISHandler) ExportDrugEvents(c echo.Context) error {
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ExportDrugEvents: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.ExportDrug(contract, c.Request().Context(), drugID)
+	return sendEPCISDocument(c, resp)
+}
+
+// ExportBatchEvents godoc
+// @Summary Export the EPCIS events of a batch
+// @Description Export the commissioning of a batch and the events of all its drugs as an EPCIS 2.0 document.
+// @Tags epcis
+// @Produce application/ld+json
+// @Produce application/xml
+// @Param id path string true "Batch ID"
+// @Param format query string false "json (default) or xml; an Accept header of application/xml also selects XML"
+// @Success 200 {object} epcis.Document
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Batch not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /epcis/events/batches/{id} [get]
+// @Security BearerAuth
+func (h *EPCISHandler) ExportBatchEvents(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ExportBatchEvents: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.ExportBatch(contract, c.Request().Context(), c.Param("id"))
+	return sendEPCISDocument(c, resp)
+}
+
+// ExportTransferEvents godoc
+// @Summary Export the EPCIS events of a transfer
+// @Description Export the shipping, receiving and voiding events of a transfer as an EPCIS 2.0 document.
+// @Tags epcis
+// @Produce application/ld+json
+// @Produce application/xml
+// @Param id path string true "Transfer ID"
+// @Param format query string false "json (default) or xml; an Accept header of application/xml also selects XML"
+// @Success 200 {object} epcis.Document
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Transfer not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /epcis/events/transfers/{id} [get]
+// @Security BearerAuth
+func (h *EPCISHandler) ExportTransferEvents(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ExportTransferEvents: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.ExportTransfer(contract, c.Request().Context(), c.Param("id"))
+	return sendEPCISDocument(c, resp)
+}
+
+// CaptureEvents godoc
+// @Summary Capture an EPCIS document
+// @Description Apply a partner's EPCIS 2.0 document (JSON-LD, or XML when sent as application/xml) to the ledger as the caller's organization. Commissioning events create batches and drugs, shipping events create transfers; other events are skipped. Each resulting batch, drug list and transfer is validated like the matching REST request before anything is submitted; events that fail are reported as failed. The outcome is reported per event.
+// @Tags epcis
+// @Accept application/ld+json
+// @Accept application/xml
+// @Produce json
+// @Param document body epcis.Document true "EPCIS 2.0 document"
+// @Success 200 {object} response.BaseListResponse[entity.EPCISCaptureResult]
+// @Failure 400 {object} response.BaseResponse "Invalid EPCIS document"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 413 {object} response.BaseResponse "EPCIS document larger than 10 MB"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /epcis/capture [post]
+// @Security BearerAuth
+func (h *EPCISHandler) CaptureEvents(c echo.Context) error {
+	// The route is limited to MaxCaptureSize by middleware.BodyLimit, whose reader fails with 413.
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return err
+		}
+		return response.NewError(http.StatusBadRequest, "Failed to read request body: %v", err)
+	}
+
+	var doc epcis.Document
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if strings.Contains(contentType, "xml") {
+		doc, err = epcis.DecodeXML(body)
+	} else {
+		err = json.Unmarshal(body, &doc)
+	}
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "Invalid EPCIS document: %v", err)
+	}
+	if len(doc.Body.EventList) == 0 {
+		return response.NewError(http.StatusBadRequest, "EPCIS document has no events")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CaptureEvents: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CaptureEvents: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.Capture(contract, c.Request().Context(), orgID, doc)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// sendEPCISDocument writes an exported document as JSON-LD or XML, or returns the error of the response.
+func sendEPCISDocument(c echo.Context, resp response.BaseValueResponse[epcis.Document]) error {
+	if !resp.Success {
+		return resp.Error
+	}
+
+	format := c.QueryParam("format")
+	if format == "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), "xml") {
+		format = "xml"
+	}
+	if format == "xml" {
+		data, err := epcis.EncodeXML(*resp.Value)
+		if err != nil {
+			return response.NewError(http.StatusInternalServerError, "Failed to encode EPCIS document: %v", err)
+		}
+		return c.Blob(http.StatusOK, mimeXML, data)
+	}
+	data, err := json.Marshal(resp.Value)
+	if err != nil {
+		return response.NewError(http.StatusInternalServerError, "Failed to encode EPCIS document: %v", err)
+	}
+	return c.Blob(http.StatusOK, mimeJSONLD, data)
+}
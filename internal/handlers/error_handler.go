@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+var logger = logging.Logger("handlers")
+
+// MIMEProblemJSON is the media type of RFC 7807 problem details.
+const MIMEProblemJSON = "application/problem+json"
+
+// MaxRequestSize is the largest request body accepted by the JSON routes, in the format of Echo's BodyLimit
+// middleware. It fits a transfer or bulk request listing services.MaxBulkDrugs drug IDs.
+const MaxRequestSize = "8M"
+
+// ErrorHandler is the server's echo.HTTPErrorHandler. Handlers and middleware return a *response.ErrorInfo
+// for every failure; it is written as the BaseResponse envelope or, when the client prefers
+// application/problem+json, as RFC 7807 problem details. Either form carries the stable error code and the request ID.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	info := toErrorInfo(err)
+	if info.Code == 0 {
+		info.Code = http.StatusInternalServerError
+	}
+	if info.ErrorCode == "" {
+		info.ErrorCode = response.DefaultErrorCode(info.Code)
+	}
+	info.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	if info.Code >= http.StatusInternalServerError {
+		logger.ErrorContext(c.Request().Context(), "Request failed", "method", c.Request().Method, "path", c.Request().URL.Path, "error", err)
+	}
+
+	var writeErr error
+	switch {
+	case c.Request().Method == http.MethodHead:
+		writeErr = c.NoContent(info.Code)
+	case prefersProblem(c.Request().Header.Get(echo.HeaderAccept)):
+		data, err := json.Marshal(info.ToProblem(c.Request().URL.Path))
+		if err != nil {
+			writeErr = err
+			break
+		}
+		writeErr = c.Blob(info.Code, MIMEProblemJSON, data)
+	default:
+		writeErr = c.JSON(info.Code, response.BaseResponse{Success: false, Error: &info})
+	}
+	if writeErr != nil {
+		logger.ErrorContext(c.Request().Context(), "Failed to write error response", "error", writeErr)
+	}
+}
+
+// toErrorInfo converts any error a handler or middleware returns. Errors other than *response.ErrorInfo,
+// validation failures and Echo's HTTP errors are internal, and their text is not sent to the client.
+func toErrorInfo(err error) response.ErrorInfo {
+	var info *response.ErrorInfo
+	if errors.As(err, &info) {
+		return *info
+	}
+	var problems validation.Errors
+	if errors.As(err, &problems) {
+		return *validation.BindError(err)
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = fmt.Sprint(httpErr.Message)
+		}
+		return response.ErrorInfo{Code: httpErr.Code, Message: message}
+	}
+	return response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Internal server error"}
+}
+
+// prefersProblem reports whether an Accept header ranks application/problem+json above plain JSON.
+func prefersProblem(accept string) bool {
+	problemQ, jsonQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch mediaType {
+		case MIMEProblemJSON:
+			problemQ = max(problemQ, q)
+		case echo.MIMEApplicationJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
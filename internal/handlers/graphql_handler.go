@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/graph"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+// GraphQLHandler handles GraphQL requests
+type GraphQLHandler struct {
+	Schema *graph.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(schema *graph.Schema) *GraphQLHandler {
+	return &GraphQLHandler{Schema: schema}
+}
+
+// ServeGraphQL godoc
+// @Summary Run a GraphQL query
+// @Description Query organizations, batches, drugs, transfers and their history, following the links between them in one request. Related records are loaded in batches. Queries deeper than the depth limit or making more ledger queries than the complexity limit fail. Errors are reported in the errors of the GraphQL response, with the stable error code as the "code" extension.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "GraphQL query, operation name and variables"
+// @Success 200 {object} any "GraphQL response with data and errors"
+// @Failure 400 {object} response.BaseResponse "Invalid request payload"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /graphql [post]
+// @Security BearerAuth
+func (h *GraphQLHandler) ServeGraphQL(c echo.Context) error {
+	var req graph.Request
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ServeGraphQL: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ServeGraphQL: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	return c.JSON(http.StatusOK, h.Schema.Exec(c.Request().Context(), contract, orgID, &req))
+}
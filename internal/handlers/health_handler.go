@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// HealthHandler handles HTTP requests for liveness, readiness and health details
+type HealthHandler struct {
+	Service      *services.HealthService
+	Certificates *services.CertificateService
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(service *services.HealthService, certificates *services.CertificateService) *HealthHandler {
+	return &HealthHandler{Service: service, Certificates: certificates}
+}
+
+// GetHealthz godoc
+// @Summary Check liveness
+// @Description Report that the server process is up and serving requests. It does not contact the Fabric network.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.BaseValueResponse[entity.HealthReport] "The server is up"
+// @Router /healthz [get]
+func (h *HealthHandler) GetHealthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, response.SuccessValueResponse(entity.HealthReport{Status: entity.HealthUp, CheckedAt: time.Now()}))
+}
+
+// GetReadyz godoc
+// @Summary Check readiness
+// @Description Check that the crypto material of every configured organization loads and that each organization's gateway peer answers a query for the channel's chain info.
+// @Description Only the status of each organization is reported; administrators get the causes from /health/details.
+// @Description A check is reused for 5 seconds and reports organizations that take longer than 10 seconds to answer as down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.BaseValueResponse[entity.HealthReport] "Every organization is up"
+// @Failure 503 {object} response.BaseValueResponse[entity.HealthReport] "An organization is down"
+// @Router /readyz [get]
+func (h *HealthHandler) GetReadyz(c echo.Context) error {
+	report := h.Service.Check(c.Request().Context())
+
+	summary := entity.HealthReport{Status: report.Status, CheckedAt: report.CheckedAt}
+	for _, org := range report.Organizations {
+		summary.Organizations = append(summary.Organizations, entity.OrgHealth{OrgID: org.OrgID, Status: org.Status})
+		if org.Status != entity.HealthUp {
+			logger.WarnContext(c.Request().Context(), "Handler GetReadyz: organization is down", logging.OrgKey, org.OrgID, "error", org.Error)
+		}
+	}
+
+	resp := response.SuccessValueResponse(summary)
+	if report.Status != entity.HealthUp {
+		resp.Success = false
+		resp.Error = &response.ErrorInfo{
+			Code:      http.StatusServiceUnavailable,
+			ErrorCode: response.CodeUnavailable,
+			Message:   "Not ready: an organization cannot reach the network",
+			RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+		}
+		return c.JSON(http.StatusServiceUnavailable, resp)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetHealthDetails godoc
+// @Summary Get health details
+// @Description Check every configured organization like /readyz and report, for each, why it is down, its peer endpoint and query latency,
+// @Description the channel's block height at its peer, the expiry of its identity and TLS CA certificates and its last transaction committed since the server started.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.BaseValueResponse[entity.HealthReport] "Health of every organization, whether up or down"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "The caller is not an administrator"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /health/details [get]
+// @Security BearerAuth
+func (h *HealthHandler) GetHealthDetails(c echo.Context) error {
+	return c.JSON(http.StatusOK, response.SuccessValueResponse(h.Service.Check(c.Request().Context())))
+}
+
+// GetCertificates godoc
+// @Summary Get certificate expiry
+// @Description Get the identity and TLS CA certificates of every configured organization with their expiry and status:
+// @Description VALID, EXPIRING within the warning period set by CERT_EXPIRY_WARNING, EXPIRED or UNREADABLE.
+// @Tags health
+// @Produce json
+// @Success 200 {object} response.BaseListResponse[entity.CertificateStatus] "Certificates of every organization"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "The caller is not an administrator"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /health/certificates [get]
+// @Security BearerAuth
+func (h *HealthHandler) GetCertificates(c echo.Context) error {
+	resp := h.Certificates.GetCertificates()
+	if !resp.Success {
+		logger.ErrorContext(c.Request().Context(), "Handler GetCertificates: Failed to check certificates", "error", resp.Error.Message)
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// IntegrityHandler handles HTTP requests for verifiable ledger histories
+type IntegrityHandler struct {
+	Service *services.IntegrityService
+}
+
+// NewIntegrityHandler creates a new IntegrityHandler
+func NewIntegrityHandler(service *services.IntegrityService) *IntegrityHandler {
+	return &IntegrityHandler{Service: service}
+}
+
+// GetVerifiableHistoryDrug godoc
+// @Summary Get the verifiable history of a drug
+// @Description Retrieve the key history of a drug with the signed envelope of every transaction behind it and the block holding it: header, envelopes, orderer signatures and transactions filter. Use the verifier package or `go run ./cmd/verify` with the MSP certificates of the organizations and the ordering service to check the response offline.
+// @Description The blocks disclose every other transaction ordered with the drug's: their read-write sets, arguments and creator and endorser certificates, whatever organization submitted them.
+// @Description The unauthenticated /history/drug/{drugID}/verification route is therefore only served when the server sets PUBLIC_HISTORY_VERIFICATION=true.
+// @Tags drugs
+// @Produce json
+// @Param drugID path string true "Drug ID, or a bracketed GS1 element string or URL-encoded Digital Link URI identifying a single pack"
+// @Success 200 {object} response.BaseValueResponse[entity.VerifiableHistory]
+// @Failure 400 {object} response.BaseResponse "Invalid drug ID"
+// @Failure 404 {object} response.BaseResponse "No history for the drug"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /history/drug/{drugID}/verification [get]
+// @Router /drugs/history/{drugID}/verification [get]
+func (h *IntegrityHandler) GetVerifiableHistoryDrug(c echo.Context) error {
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+
+	network, err := auth.GetNetworkFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetVerifiableHistoryDrug: Failed to get network from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetVerifiableHistoryDrug: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetVerifiableHistoryDrug(network, contract, c.Request().Context(), drugID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
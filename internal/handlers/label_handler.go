@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/labels"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// LabelHandler handles HTTP requests for GS1 barcode labels
+type LabelHandler struct {
+	Service *services.LabelService
+}
+
+// NewLabelHandler creates a new LabelHandler
+func NewLabelHandler(service *services.LabelService) *LabelHandler {
+	return &LabelHandler{Service: service}
+}
+
+// GetDrugLabel godoc
+// @Summary Render the label of a drug
+// @Description Render a GS1 DataMatrix (element string) or QR code (Digital Link URI) with GTIN, serial, lot and expiry for a drug created with an SGTIN.
+// @Tags labels
+// @Produce png
+// @Produce image/svg+xml
+// @Param drugID path string true "Drug ID"
+// @Param symbology query string false "datamatrix (default) or qr"
+// @Param format query string false "png (default) or svg"
+// @Param size query int false "PNG module size in pixels, 1 to 20 (default 8)"
+// @Success 200 {file} binary
+// @Failure 400 {object} response.BaseResponse "Drug has no GS1 identity or invalid options"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Drug not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /drugs/{drugID}/label [get]
+// @Security BearerAuth
+func (h *LabelHandler) GetDrugLabel(c echo.Context) error {
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrugLabel: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	symbology, format, size, err := labelOptions(c)
+	if err != nil {
+		return err
+	}
+	resp := h.Service.DrugLabel(contract, c.Request().Context(), drugID, symbology, format, size)
+	return sendLabel(c, resp)
+}
+
+// GetBatchLabel godoc
+// @Summary Render the label of a batch
+// @Description Render a GS1 DataMatrix or QR code with the batch GTIN, lot and expiry.
+// @Tags labels
+// @Produce png
+// @Produce image/svg+xml
+// @Param id path string true "Batch ID"
+// @Param symbology query string false "datamatrix (default) or qr"
+// @Param format query string false "png (default) or svg"
+// @Param size query int false "PNG module size in pixels, 1 to 20 (default 8)"
+// @Success 200 {file} binary
+// @Failure 400 {object} response.BaseResponse "Batch has no GTIN or invalid options"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Batch not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /batches/{id}/label [get]
+// @Security BearerAuth
+func (h *LabelHandler) GetBatchLabel(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetBatchLabel: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	symbology, format, size, err := labelOptions(c)
+	if err != nil {
+		return err
+	}
+	resp := h.Service.BatchLabel(contract, c.Request().Context(), c.Param("id"), symbology, format, size)
+	return sendLabel(c, resp)
+}
+
+// GetBatchLabelSheet godoc
+// @Summary Render a label sheet for a batch
+// @Description Render a print-ready A4 PDF with one pack label for every drug of the batch, ordered by drug ID.
+// @Description A sheet holds at most 500 labels: larger batches are printed in pages selected with offset and limit.
+// @Tags labels
+// @Produce application/pdf
+// @Param id path string true "Batch ID"
+// @Param symbology query string false "datamatrix (default) or qr"
+// @Param offset query int false "Number of drugs to skip, in drug ID order"
+// @Param limit query int false "Number of labels on the sheet, at most 500 (default)"
+// @Success 200 {file} binary
+// @Failure 400 {object} response.BaseResponse "Drugs without GS1 identity, invalid options, or more than 500 labels without paging"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Batch not found or empty"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /batches/{id}/labels [get]
+// @Security BearerAuth
+func (h *LabelHandler) GetBatchLabelSheet(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetBatchLabelSheet: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	symbology, _, _, err := labelOptions(c)
+	if err != nil {
+		return err
+	}
+	offset, limit, _, err := pageParams(c, services.MaxSheetLabels, services.MaxSheetLabels)
+	if err != nil {
+		return err
+	}
+	resp := h.Service.BatchLabelSheet(contract, c.Request().Context(), c.Param("id"), symbology, offset, limit)
+	return sendLabel(c, resp)
+}
+
+// labelOptions reads the symbology, format and PNG module size query parameters, applying defaults.
+// It fails when size is not a whole number between 1 and labels.MaxModuleSize.
+func labelOptions(c echo.Context) (string, string, int, error) {
+	symbology := c.QueryParam("symbology")
+	if symbology == "" {
+		symbology = labels.SymbologyDataMatrix
+	}
+	format := c.QueryParam("format")
+	if format == "" {
+		format = labels.FormatPNG
+	}
+	size := 0
+	if raw := c.QueryParam("size"); raw != "" {
+		var err error
+		size, err = strconv.Atoi(raw)
+		if err != nil || size < 1 || size > labels.MaxModuleSize {
+			return "", "", 0, response.NewError(http.StatusBadRequest, "Invalid size '%s', expected a module size between 1 and %d pixels", raw, labels.MaxModuleSize)
+		}
+	}
+	return symbology, format, size, nil
+}
+
+func sendLabel(c echo.Context, resp response.BaseValueResponse[labels.Label]) error {
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.Blob(http.StatusOK, resp.Value.ContentType, resp.Value.Data)
+}
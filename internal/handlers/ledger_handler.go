@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/ledger"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
 	"github.com/labstack/echo/v4"
 )
@@ -20,29 +27,78 @@ func NewLedgerHandler(service *services.LedgerService) *LedgerHandler {
 
 // InitLedger godoc
 // @Summary Initialize the ledger
-// @Description Run the InitLedger chaincode function. This is typically a one-time setup.
+// @Description Write initial data to the ledger. This is typically a one-time setup and is only available when the server runs in development mode.
+// @Description The request body may carry a seed of organizations, batches and drugs as JSON or YAML; without one the server's seed file, or else the chaincode's built-in data, is used.
+// @Description Every attempt is recorded with the calling organization and time.
 // @Tags ledger
+// @Accept json,application/yaml
 // @Produce json
-// @Success 200 {object} response.BaseValueResponse[string] "Successfully initialized ledger"
+// @Param seed body ledger.Seed false "Optional seed data"
+// @Success 200 {object} response.BaseValueResponse[entity.LedgerInitRecord] "Successfully initialized ledger"
+// @Failure 400 {object} response.BaseResponse "Invalid seed"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Ledger initialization is disabled, or the caller is not an administrator"
+// @Failure 413 {object} response.BaseResponse "Seed larger than 8 MB"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
 // @Router /ledger/init [post]
 // @Security BearerAuth
 func (h *LedgerHandler) InitLedger(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler InitLedger: Failed to get contract from context: %v", err)
+		logger.ErrorContext(c.Request().Context(), "Handler InitLedger: Failed to get contract from context", "error", err)
 		// Assuming response.BaseValueResponse structure for error consistency
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler InitLedger: Failed to get orgID from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
-	resp := h.Service.InitLedger(contract, c.Request().Context())
-	status := http.StatusOK
-	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
+	// The route is limited to MaxRequestSize by middleware.BodyLimit, whose reader fails with 413.
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return err
 		}
+		return response.NewError(http.StatusBadRequest, "Failed to read request body: %v", err)
+	}
+	var seed *ledger.Seed
+	if len(bytes.TrimSpace(body)) > 0 {
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		seed, err = services.ParseSeed(body, strings.HasSuffix(mediaType, "yaml"))
+		if err != nil {
+			return response.NewError(http.StatusBadRequest, "Invalid seed: %v", err)
+		}
+	}
+
+	resp := h.Service.InitLedger(contract, c.Request().Context(), orgID, c.RealIP(), seed)
+	if !resp.Success {
+		logger.WarnContext(c.Request().Context(), "Handler InitLedger: denied or failed", "error", resp.Error.Message)
+		return resp.Error
+	}
+	logger.InfoContext(c.Request().Context(), "Handler InitLedger: ledger initialized", "source", resp.Value.Source)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetInitAudit godoc
+// @Summary List ledger initialization requests
+// @Description Get the audit log of requests to initialize the ledger, most recent first.
+// @Tags ledger
+// @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.LedgerInitRecord] "Successfully retrieved audit log"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "The caller is not an administrator"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /ledger/init/audit [get]
+// @Security BearerAuth
+func (h *LedgerHandler) GetInitAudit(c echo.Context) error {
+	resp := h.Service.GetInitAudit()
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return sendList(c, resp)
 }
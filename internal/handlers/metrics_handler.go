@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsHandler struct {
+	Handler http.Handler
+	Token   string // Bearer token scrapers must send; empty leaves the metrics public
+}
+
+// NewMetricsHandler creates a new MetricsHandler exposing the default Prometheus registry.
+// Scrapers don't hold organization access tokens, so the metrics are protected by their own static token.
+func NewMetricsHandler(token string) *MetricsHandler {
+	return &MetricsHandler{Handler: promhttp.Handler(), Token: token}
+}
+
+// GetMetrics godoc
+// @Summary Get Prometheus metrics
+// @Description Metrics in the Prometheus text format: HTTP latency per route and status, Fabric evaluate, endorse, submit and commit latency and errors per organization and chaincode function, open gateways, the expiry of organization certificates, and counters of transfers and serialized drugs. When the server sets METRICS_TOKEN, scrapers must send it as a Bearer token.
+// @Tags metrics
+// @Produce plain
+// @Success 200 {file} binary "Metrics in the Prometheus text format"
+// @Failure 401 {object} response.BaseResponse "METRICS_TOKEN is set and the request does not carry it as Bearer token"
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(c echo.Context) error {
+	if h.Token != "" {
+		got := c.Request().Header.Get(echo.HeaderAuthorization)
+		if subtle.ConstantTimeCompare([]byte(got), []byte("Bearer "+h.Token)) != 1 {
+			return response.NewError(http.StatusUnauthorized, "Invalid or missing metrics token")
+		}
+	}
+	h.Handler.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
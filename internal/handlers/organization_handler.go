@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/organization"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -32,24 +35,20 @@ func NewOrganizationHandler(service *services.OrganizationService) *Organization
 func (h *OrganizationHandler) GetOrganizationByID(c echo.Context) error {
 	orgID := c.Param("id")
 	if orgID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusBadRequest, "message": "Organization ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Organization ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetOrganizationByID: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetOrganizationByID: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetOrganizationByID(contract, c.Request().Context(), orgID)
-	status := http.StatusOK
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
-	return c.JSON(status, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetOrganizations godoc
@@ -57,6 +56,8 @@ func (h *OrganizationHandler) GetOrganizationByID(c echo.Context) error {
 // @Description Retrieve all organizations from the ledger
 // @Tags organizations
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Organization]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -65,19 +66,185 @@ func (h *OrganizationHandler) GetOrganizationByID(c echo.Context) error {
 func (h *OrganizationHandler) GetOrganizations(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetOrganizations: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"success": false, "error": map[string]interface{}{"code": http.StatusInternalServerError, "message": "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetOrganizations: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetOrganizations(contract, c.Request().Context())
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetHistoryOrganization godoc
+// @Summary Get the history of an organization
+// @Description Retrieve the ledger key history of an organization, one record per transaction that wrote it, including deletion.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryOrganization]
+// @Failure 400 {object} response.BaseResponse "Invalid Organization ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /organizations/{id}/history [get]
+// @Security BearerAuth
+func (h *OrganizationHandler) GetHistoryOrganization(c echo.Context) error {
+	orgID := c.Param("id")
+	if orgID == "" {
+		return response.NewError(http.StatusBadRequest, "Organization ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetHistoryOrganization: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetHistoryOrganization(contract, c.Request().Context(), orgID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetOrganizationChanges godoc
+// @Summary Get the field changes of an organization
+// @Description Compare each version in the key history of an organization with the one before it and list the fields that changed, with old and new values.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryChange]
+// @Failure 400 {object} response.BaseResponse "Invalid Organization ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /organizations/{id}/history/changes [get]
+// @Security BearerAuth
+func (h *OrganizationHandler) GetOrganizationChanges(c echo.Context) error {
+	orgID := c.Param("id")
+	if orgID == "" {
+		return response.NewError(http.StatusBadRequest, "Organization ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetOrganizationChanges: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetOrganizationChanges(contract, c.Request().Context(), orgID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// RegisterOrganization godoc
+// @Summary Register a new organization
+// @Description Create an organization on the ledger and map it to its Fabric MSP and gateway peer so it can log in. Admin only.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param organization body organization.RegisterOrganization true "Organization details and MSP mapping"
+// @Success 201 {object} response.BaseValueResponse[entity.Organization]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Caller is not an administrator"
+// @Failure 409 {object} response.BaseResponse "Organization already configured"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /organizations [post]
+// @Security BearerAuth
+func (h *OrganizationHandler) RegisterOrganization(c echo.Context) error {
+	var req organization.RegisterOrganization
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler RegisterOrganization: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
 
-	status := http.StatusOK
+	resp := h.Service.RegisterOrganization(contract, c.Request().Context(), &req)
 	if !resp.Success {
-		status = resp.Error.Code
-		if status == 0 {
-			status = http.StatusInternalServerError
-		}
+		return resp.Error
 	}
+	return c.JSON(http.StatusCreated, resp)
+}
 
-	return c.JSON(status, resp)
+// UpdateOrganization godoc
+// @Summary Update an organization profile
+// @Description Update the name, type and location of an organization. Admin only.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param organization body organization.UpdateOrganization true "Organization profile"
+// @Success 200 {object} response.BaseValueResponse[entity.Organization]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Caller is not an administrator"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /organizations/{id} [patch]
+// @Security BearerAuth
+func (h *OrganizationHandler) UpdateOrganization(c echo.Context) error {
+	orgID := c.Param("id")
+	if orgID == "" {
+		return response.NewError(http.StatusBadRequest, "Organization ID parameter is required")
+	}
+	var req organization.UpdateOrganization
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler UpdateOrganization: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.UpdateOrganization(contract, c.Request().Context(), orgID, &req)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeactivateOrganization godoc
+// @Summary Deactivate an organization
+// @Description Mark an organization as deactivated. It keeps its history and drugs but can no longer receive transfers. Admin only.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} response.BaseValueResponse[entity.Organization]
+// @Failure 400 {object} response.BaseResponse "Invalid organization ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Caller is not an administrator"
+// @Failure 404 {object} response.BaseResponse "Organization not found"
+// @Failure 409 {object} response.BaseResponse "Organization already deactivated"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /organizations/{id}/deactivate [post]
+// @Security BearerAuth
+func (h *OrganizationHandler) DeactivateOrganization(c echo.Context) error {
+	orgID := c.Param("id")
+	if orgID == "" {
+		return response.NewError(http.StatusBadRequest, "Organization ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler DeactivateOrganization: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.DeactivateOrganization(contract, c.Request().Context(), orgID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultPageLimit is the size of a page requested with an offset but no limit.
+	DefaultPageLimit = 100
+	// MaxPageLimit is the largest page a client may request.
+	MaxPageLimit = 1000
+)
+
+// sendList writes a successful list response. When the request has a limit or offset query parameter
+// only that page of the list is sent, along with its position in the whole list.
+func sendList[T any](c echo.Context, resp response.BaseListResponse[T]) error {
+	offset, limit, paged, err := pageParams(c, DefaultPageLimit, MaxPageLimit)
+	if err != nil {
+		return err
+	}
+	if !paged {
+		return c.JSON(http.StatusOK, resp)
+	}
+	return c.JSON(http.StatusOK, response.PageListResponse(resp, offset, limit))
+}
+
+// pageParams reads the offset and limit query parameters. paged is false when neither is set; a missing limit
+// is defaultLimit and a limit above maxLimit is rejected.
+func pageParams(c echo.Context, defaultLimit, maxLimit int) (offset, limit int, paged bool, err error) {
+	limitParam, offsetParam := c.QueryParam("limit"), c.QueryParam("offset")
+	if limitParam == "" && offsetParam == "" {
+		return 0, 0, false, nil
+	}
+
+	limit = defaultLimit
+	if limitParam != "" {
+		n, err := strconv.Atoi(limitParam)
+		if err != nil || n < 1 || n > maxLimit {
+			return 0, 0, false, response.NewError(http.StatusBadRequest, "limit must be a number from 1 to %d", maxLimit)
+		}
+		limit = n
+	}
+	if offsetParam != "" {
+		n, err := strconv.Atoi(offsetParam)
+		if err != nil || n < 0 {
+			return 0, 0, false, response.NewError(http.StatusBadRequest, "offset must be a number of at least 0")
+		}
+		offset = n
+	}
+	return offset, limit, true, nil
+}
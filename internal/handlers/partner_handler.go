@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/partner"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+// PartnerHandler handles HTTP requests for the caller's trading partner list
+type PartnerHandler struct {
+	Service *services.PartnerService
+}
+
+// NewPartnerHandler creates a new PartnerHandler
+func NewPartnerHandler(service *services.PartnerService) *PartnerHandler {
+	return &PartnerHandler{Service: service}
+}
+
+// GetPartners godoc
+// @Summary List the caller's trading partners
+// @Description Retrieve the organizations the caller is authorized to ship to, with their license and status.
+// @Tags partners
+// @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.TradingPartner]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /partners [get]
+// @Security BearerAuth
+func (h *PartnerHandler) GetPartners(c echo.Context) error {
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetPartners: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetPartners(orgID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetPartner godoc
+// @Summary Get a trading partner
+// @Description Retrieve the license and status of one of the caller's trading partners.
+// @Tags partners
+// @Produce json
+// @Param partnerID path string true "Partner organization ID"
+// @Success 200 {object} response.BaseValueResponse[entity.TradingPartner]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Not a trading partner of the caller"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /partners/{partnerID} [get]
+// @Security BearerAuth
+func (h *PartnerHandler) GetPartner(c echo.Context) error {
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetPartner: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetPartner(orgID, c.Param("partnerID"))
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpsertPartner godoc
+// @Summary Add or update a trading partner
+// @Description Authorize the caller to ship to an organization under the given license, or update its license and status. Transfers are refused once the license expires or the status is not ACTIVE.
+// @Tags partners
+// @Accept json
+// @Produce json
+// @Param partnerID path string true "Partner organization ID"
+// @Param partner body partner.UpsertPartner true "License and status"
+// @Success 200 {object} response.BaseValueResponse[entity.TradingPartner]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Organization not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /partners/{partnerID} [put]
+// @Security BearerAuth
+func (h *PartnerHandler) UpsertPartner(c echo.Context) error {
+	var req partner.UpsertPartner
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler UpsertPartner: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler UpsertPartner: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.UpsertPartner(contract, c.Request().Context(), orgID, c.Param("partnerID"), &req)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RemovePartner godoc
+// @Summary Remove a trading partner
+// @Description Remove an organization from the caller's trading partners. Pending transfers to it are not affected.
+// @Tags partners
+// @Produce json
+// @Param partnerID path string true "Partner organization ID"
+// @Success 200 {object} response.BaseValueResponse[entity.TradingPartner]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Not a trading partner of the caller"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /partners/{partnerID} [delete]
+// @Security BearerAuth
+func (h *PartnerHandler) RemovePartner(c echo.Context) error {
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler RemovePartner: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.RemovePartner(orgID, c.Param("partnerID"))
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
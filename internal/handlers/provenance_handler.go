@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/documents"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEGraphviz is the media type of Graphviz DOT documents
+const MIMEGraphviz = "text/vnd.graphviz"
+
+// ProvenanceHandler handles HTTP requests for composed provenance graphs
+type ProvenanceHandler struct {
+	Service *services.ProvenanceService
+}
+
+// NewProvenanceHandler creates a new ProvenanceHandler
+func NewProvenanceHandler(service *services.ProvenanceService) *ProvenanceHandler {
+	return &ProvenanceHandler{Service: service}
+}
+
+// GetDrugProvenance godoc
+// @Summary Get the provenance of a drug
+// @Description Compose the key history of a drug into its batch, each custody hop with sender, receiver, transfer and acceptance dates, and its current owner and status. Organizations are nodes and transfers are edges. The drug ID may also be a bracketed GS1 element string or Digital Link.
+// @Tags drugs
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Param drugID path string true "Drug ID"
+// @Param format query string false "json (default) or dot; an Accept header of text/vnd.graphviz also selects DOT"
+// @Success 200 {object} response.BaseValueResponse[entity.Provenance]
+// @Failure 400 {object} response.BaseResponse "Invalid drug ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Drug not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /drugs/{drugID}/provenance [get]
+// @Security BearerAuth
+func (h *ProvenanceHandler) GetDrugProvenance(c echo.Context) error {
+	drugID, err := resolveDrugID(c)
+	if err != nil {
+		return response.NewError(http.StatusBadRequest, "%v", err)
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetDrugProvenance: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	return sendProvenance(c, h.Service.DrugProvenance(contract, c.Request().Context(), drugID))
+}
+
+// GetBatchProvenance godoc
+// @Summary Get the provenance of a batch
+// @Description Compose the key history of every drug in a batch into a custody graph showing where each unit ended up.
+// @Tags batches
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Param id path string true "Batch ID"
+// @Param format query string false "json (default) or dot; an Accept header of text/vnd.graphviz also selects DOT"
+// @Success 200 {object} response.BaseValueResponse[entity.Provenance]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Batch not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /batches/{id}/provenance [get]
+// @Security BearerAuth
+func (h *ProvenanceHandler) GetBatchProvenance(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetBatchProvenance: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	return sendProvenance(c, h.Service.BatchProvenance(contract, c.Request().Context(), c.Param("id")))
+}
+
+// GetTransferProvenance godoc
+// @Summary Get the provenance of the drugs in a transfer
+// @Description Compose the full custody graph of the drugs linked to a transfer, including hops before and after it.
+// @Tags transfers
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Param id path string true "Transfer ID"
+// @Param format query string false "json (default) or dot; an Accept header of text/vnd.graphviz also selects DOT"
+// @Success 200 {object} response.BaseValueResponse[entity.Provenance]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Transfer not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/{id}/provenance [get]
+// @Security BearerAuth
+func (h *ProvenanceHandler) GetTransferProvenance(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransferProvenance: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	return sendProvenance(c, h.Service.TransferProvenance(contract, c.Request().Context(), c.Param("id")))
+}
+
+// sendProvenance writes a provenance response as JSON, or as Graphviz DOT when requested by format=dot or the Accept header.
+func sendProvenance(c echo.Context, resp response.BaseValueResponse[entity.Provenance]) error {
+	if !resp.Success {
+		return resp.Error
+	}
+
+	format := c.QueryParam("format")
+	if format == "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), MIMEGraphviz) {
+		format = "dot"
+	}
+	if format != "dot" {
+		return c.JSON(http.StatusOK, resp)
+	}
+	return c.Blob(http.StatusOK, MIMEGraphviz+"; charset=utf-8", documents.ProvenanceDOT(*resp.Value))
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/jobs"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+// SerializationHandler handles HTTP requests for bulk drug serialization jobs
+type SerializationHandler struct {
+	Runner *jobs.SerializationRunner
+}
+
+// NewSerializationHandler creates a new SerializationHandler
+func NewSerializationHandler(runner *jobs.SerializationRunner) *SerializationHandler {
+	return &SerializationHandler{Runner: runner}
+}
+
+// CreateDrugsBulk godoc
+// @Summary Serialize all drugs of a batch
+// @Description Start a background job that creates many drugs of one batch in bounded ledger transactions. Drug IDs come from an explicit list, a serial range or a generator pattern.
+// @Tags drugs
+// @Accept json
+// @Produce json
+// @Param drugs body drug.BulkCreateDrugRequest true "Drugs to create. OwnerID, BatchID and exactly one of drugsID, range or pattern are required."
+// @Success 202 {object} response.BaseValueResponse[entity.SerializationJob]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload or ID specification"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error"
+// @Router /drugs/bulk [post]
+// @Security BearerAuth
+func (h *SerializationHandler) CreateDrugsBulk(c echo.Context) error {
+	var req drug.BulkCreateDrugRequest
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CreateDrugsBulk: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Runner.Submit(orgID, &req)
+	return sendJobResponse(c, http.StatusAccepted, resp)
+}
+
+// GetBulkJob godoc
+// @Summary Get a bulk serialization job
+// @Description Retrieve the progress of a bulk serialization job started by the caller's organization.
+// @Tags drugs
+// @Produce json
+// @Param jobID path string true "Job ID"
+// @Success 200 {object} response.BaseValueResponse[entity.SerializationJob]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Job not found"
+// @Router /drugs/bulk/{jobID} [get]
+// @Security BearerAuth
+func (h *SerializationHandler) GetBulkJob(c echo.Context) error {
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetBulkJob: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Runner.Get(orgID, c.Param("jobID"))
+	return sendJobResponse(c, http.StatusOK, resp)
+}
+
+// ResumeBulkJob godoc
+// @Summary Resume a failed bulk serialization job
+// @Description Retry the failed chunks of a bulk serialization job. Drugs that already reached the ledger are skipped.
+// @Tags drugs
+// @Produce json
+// @Param jobID path string true "Job ID"
+// @Success 202 {object} response.BaseValueResponse[entity.SerializationJob]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Job not found"
+// @Failure 409 {object} response.BaseResponse "Job is running or already completed"
+// @Router /drugs/bulk/{jobID}/resume [post]
+// @Security BearerAuth
+func (h *SerializationHandler) ResumeBulkJob(c echo.Context) error {
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler ResumeBulkJob: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Runner.Resume(orgID, c.Param("jobID"))
+	return sendJobResponse(c, http.StatusAccepted, resp)
+}
+
+func sendJobResponse(c echo.Context, successStatus int, resp response.BaseValueResponse[entity.SerializationJob]) error {
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(successStatus, resp)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/documents"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// T3Handler handles HTTP requests for the product tracing documents of transfers
+type T3Handler struct {
+	Service *services.T3Service
+}
+
+// NewT3Handler creates a new T3Handler
+func NewT3Handler(service *services.T3Service) *T3Handler {
+	return &T3Handler{Service: service}
+}
+
+// GetTransferT3 godoc
+// @Summary Get the T3 documents of a transfer
+// @Description Retrieve the transaction information, history and statement of a transfer, signed by the sender. The sender's first retrieval issues the documents, which are then frozen and stay available to the sender and receiver for the retention period. The signature covers the document without its Signature field, encoded as compact JSON in the field order of the response; cmd/verify t3 checks it against the signer's MSP.
+// @Tags transfers
+// @Produce json
+// @Produce application/pdf
+// @Param id path string true "Transfer ID"
+// @Param format query string false "json (default) or pdf; an Accept header of application/pdf also selects PDF"
+// @Success 200 {object} response.BaseValueResponse[entity.T3Document]
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Caller is neither sender nor receiver"
+// @Failure 404 {object} response.BaseResponse "Transfer not found"
+// @Failure 409 {object} response.BaseResponse "Transfer was cancelled or expired, has no drugs, or its documents were not issued by the sender yet"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/{id}/t3 [get]
+// @Security BearerAuth
+func (h *T3Handler) GetTransferT3(c echo.Context) error {
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransferT3: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransferT3: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetT3(contract, c.Request().Context(), orgID, c.Param("id"))
+	if !resp.Success {
+		return resp.Error
+	}
+
+	format := c.QueryParam("format")
+	if format == "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), "application/pdf") {
+		format = "pdf"
+	}
+	if format != "pdf" {
+		return c.JSON(http.StatusOK, resp)
+	}
+	data, err := documents.T3PDF(*resp.Value)
+	if err != nil {
+		return response.NewError(http.StatusInternalServerError, "%v", err)
+	}
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="t3-`+resp.Value.TransferID+`.pdf"`)
+	return c.Blob(http.StatusOK, "application/pdf", data)
+}
@@ -6,9 +6,9 @@ import (
 
 	"github.com/AryaJayadi/MedTrace_api/internal/auth"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
-	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -22,33 +22,9 @@ func NewTransferHandler(service *services.TransferService) *TransferHandler {
 	return &TransferHandler{Service: service}
 }
 
-// sendResponse is a generic helper for Value responses
-func (h *TransferHandler) sendValueResponse(c echo.Context, successStatus int, resp *response.BaseValueResponse[any]) error {
-	if resp.Success {
-		return c.JSON(successStatus, resp)
-	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
-	}
-	return c.JSON(httpStatus, resp)
-}
-
-// sendListResponse is a generic helper for List responses
-func (h *TransferHandler) sendListResponse(c echo.Context, successStatus int, resp *response.BaseListResponse[any]) error {
-	if resp.Success {
-		return c.JSON(successStatus, resp)
-	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
-	}
-	return c.JSON(httpStatus, resp)
-}
-
 // CreateTransfer godoc
 // @Summary Create a new transfer
-// @Description Initiate a new transfer of drugs
+// @Description Initiate a new transfer of drugs. The receiver must be an active trading partner of the caller with an unexpired license.
 // @Tags transfers
 // @Accept json
 // @Produce json
@@ -56,37 +32,40 @@ func (h *TransferHandler) sendListResponse(c echo.Context, successStatus int, re
 // @Success 201 {object} response.BaseValueResponse[entity.Transfer]
 // @Failure 400 {object} response.BaseResponse "Invalid request payload or missing required fields"
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Receiver is not an active trading partner or its license has expired"
+// @Failure 409 {object} response.BaseResponse "Receiver is deactivated"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
 // @Router /transfers [post]
 // @Security BearerAuth
 func (h *TransferHandler) CreateTransfer(c echo.Context) error {
 	var req transfer.CreateTransferRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "Invalid request payload: " + err.Error()}})
-	}
-	if req.ReceiverID == "" || len(req.DrugsID) == 0 {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "ReceiverID and at least one DrugID are required"}})
+		return validation.BindError(err)
 	}
 	if req.TransferDate == nil {
 		now := time.Now()
 		req.TransferDate = &now
 	}
+	if req.AcceptDeadline != nil && !req.AcceptDeadline.After(*req.TransferDate) {
+		return response.NewError(http.StatusBadRequest, "AcceptDeadline must be after TransferDate")
+	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler CreateTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler CreateTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
-
-	resp := h.Service.CreateTransfer(contract, c.Request().Context(), &req)
-	if resp.Success {
-		return c.JSON(http.StatusCreated, resp)
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CreateTransfer: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
+
+	resp := h.Service.CreateTransfer(contract, c.Request().Context(), orgID, &req)
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(httpStatus, resp)
+	return c.JSON(http.StatusCreated, resp)
 }
 
 // GetTransfer godoc
@@ -105,24 +84,20 @@ func (h *TransferHandler) CreateTransfer(c echo.Context) error {
 func (h *TransferHandler) GetTransfer(c echo.Context) error {
 	transferID := c.Param("id")
 	if transferID == "" {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "Transfer ID parameter is required"}})
+		return response.NewError(http.StatusBadRequest, "Transfer ID parameter is required")
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetTransfer(contract, c.Request().Context(), transferID)
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
-	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(httpStatus, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // GetMyOutTransfer godoc
@@ -130,6 +105,8 @@ func (h *TransferHandler) GetTransfer(c echo.Context) error {
 // @Description Retrieve all outgoing transfers initiated by the transaction submitter
 // @Tags transfers
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Transfer]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -138,19 +115,15 @@ func (h *TransferHandler) GetTransfer(c echo.Context) error {
 func (h *TransferHandler) GetMyOutTransfer(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetMyOutTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseListResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetMyOutTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetMyOutTransfer(contract, c.Request().Context())
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
+	if !resp.Success {
+		return resp.Error
 	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
-	}
-	return c.JSON(httpStatus, resp)
+	return sendList(c, resp)
 }
 
 // GetMyInTransfer godoc
@@ -158,6 +131,8 @@ func (h *TransferHandler) GetMyOutTransfer(c echo.Context) error {
 // @Description Retrieve all incoming transfers destined for the transaction submitter
 // @Tags transfers
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Transfer]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -166,19 +141,15 @@ func (h *TransferHandler) GetMyOutTransfer(c echo.Context) error {
 func (h *TransferHandler) GetMyInTransfer(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetMyInTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseListResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetMyInTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetMyInTransfer(contract, c.Request().Context())
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
-	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(httpStatus, resp)
+	return sendList(c, resp)
 }
 
 // GetMyTransfers godoc
@@ -186,6 +157,8 @@ func (h *TransferHandler) GetMyInTransfer(c echo.Context) error {
 // @Description Retrieve all transfers associated with the transaction submitter
 // @Tags transfers
 // @Produce json
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
 // @Success 200 {object} response.BaseListResponse[entity.Transfer]
 // @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
 // @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
@@ -194,19 +167,15 @@ func (h *TransferHandler) GetMyInTransfer(c echo.Context) error {
 func (h *TransferHandler) GetMyTransfers(c echo.Context) error {
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler GetMyTransfers: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseListResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler GetMyTransfers: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.GetMyTransfers(contract, c.Request().Context())
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
+	if !resp.Success {
+		return resp.Error
 	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
-	}
-	return c.JSON(httpStatus, resp)
+	return sendList(c, resp)
 }
 
 // AcceptTransfer godoc
@@ -225,10 +194,7 @@ func (h *TransferHandler) GetMyTransfers(c echo.Context) error {
 func (h *TransferHandler) AcceptTransfer(c echo.Context) error {
 	var req transfer.ProcessTransferRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "Invalid request payload: " + err.Error()}})
-	}
-	if req.TransferID == "" {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "TransferID is required"}})
+		return validation.BindError(err)
 	}
 	if req.ReceiveDate == nil {
 		now := time.Now()
@@ -237,19 +203,15 @@ func (h *TransferHandler) AcceptTransfer(c echo.Context) error {
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler AcceptTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler AcceptTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.AcceptTransfer(contract, c.Request().Context(), &req)
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
+	if !resp.Success {
+		return resp.Error
 	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
-	}
-	return c.JSON(httpStatus, resp)
+	return c.JSON(http.StatusOK, resp)
 }
 
 // RejectTransfer godoc
@@ -268,25 +230,232 @@ func (h *TransferHandler) AcceptTransfer(c echo.Context) error {
 func (h *TransferHandler) RejectTransfer(c echo.Context) error {
 	var req transfer.ProcessTransferRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "Invalid request payload: " + err.Error()}})
-	}
-	if req.TransferID == "" {
-		return c.JSON(http.StatusBadRequest, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusBadRequest, Message: "TransferID is required"}})
+		return validation.BindError(err)
 	}
 
 	contract, err := auth.GetContractFromContext(c)
 	if err != nil {
-		c.Logger().Errorf("Handler RejectTransfer: Failed to get contract from context: %v", err)
-		return c.JSON(http.StatusInternalServerError, response.BaseValueResponse[entity.Transfer]{Success: false, Error: &response.ErrorInfo{Code: http.StatusInternalServerError, Message: "Failed to access network resources"}})
+		logger.ErrorContext(c.Request().Context(), "Handler RejectTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
 	}
 
 	resp := h.Service.RejectTransfer(contract, c.Request().Context(), &req)
-	if resp.Success {
-		return c.JSON(http.StatusOK, resp)
+	if !resp.Success {
+		return resp.Error
 	}
-	httpStatus := http.StatusInternalServerError
-	if resp.Error != nil && resp.Error.Code != 0 {
-		httpStatus = resp.Error.Code
+	return c.JSON(http.StatusOK, resp)
+}
+
+// AcceptTransferPartial godoc
+// @Summary Accept part of an incoming transfer
+// @Description Accept a subset of the drugs in an incoming transfer and report the rest as missing, damaged or surplus. Unaccepted drugs go back to the sender.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param transfer body transfer.PartialAcceptTransferRequest true "Accepted drugs and discrepancies. Every drug of the transfer must be accounted for."
+// @Success 200 {object} response.BaseValueResponse[entity.Transfer]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload or drugs not accounted for"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "Transfer not found"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/accept/partial [post]
+// @Security BearerAuth
+func (h *TransferHandler) AcceptTransferPartial(c echo.Context) error {
+	var req transfer.PartialAcceptTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+	if req.ReceiveDate == nil {
+		now := time.Now()
+		req.ReceiveDate = &now
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler AcceptTransferPartial: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.AcceptTransferPartial(contract, c.Request().Context(), &req)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetTransferDiscrepancy godoc
+// @Summary Get the discrepancy report of a transfer
+// @Description Retrieve the missing, damaged and surplus drugs reported when a transfer was partially accepted. Available to both sender and receiver.
+// @Tags transfers
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} response.BaseValueResponse[entity.TransferDiscrepancy]
+// @Failure 400 {object} response.BaseResponse "Invalid Transfer ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 404 {object} response.BaseResponse "No discrepancy report for the transfer"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/{id}/discrepancy [get]
+// @Security BearerAuth
+func (h *TransferHandler) GetTransferDiscrepancy(c echo.Context) error {
+	transferID := c.Param("id")
+	if transferID == "" {
+		return response.NewError(http.StatusBadRequest, "Transfer ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransferDiscrepancy: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetTransferDiscrepancy(contract, c.Request().Context(), transferID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CancelTransfer godoc
+// @Summary Cancel an outgoing transfer
+// @Description Withdraw a pending transfer created by the caller. The drugs are released back to the sender.
+// @Tags transfers
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} response.BaseValueResponse[entity.Transfer]
+// @Failure 400 {object} response.BaseResponse "Invalid Transfer ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error (e.g. not the sender, or transfer no longer pending)"
+// @Router /transfers/{id}/cancel [post]
+// @Security BearerAuth
+func (h *TransferHandler) CancelTransfer(c echo.Context) error {
+	transferID := c.Param("id")
+	if transferID == "" {
+		return response.NewError(http.StatusBadRequest, "Transfer ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CancelTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.CancelTransfer(contract, c.Request().Context(), transferID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateTransferByBatch godoc
+// @Summary Create a transfer by batch and quantity
+// @Description Initiate a transfer by batch ID or drug name instead of listing every drug ID. Units are allocated from the caller's available drugs, FEFO by default, and the allocated drug IDs are returned. Expired batches are skipped for drug name items and rejected for batch ID items.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param transfer body transfer.CreateBatchTransferRequest true "Transfer details. ReceiverID and at least one item are required."
+// @Success 201 {object} response.BaseValueResponse[entity.TransferAllocation]
+// @Failure 400 {object} response.BaseResponse "Invalid request payload, expired batch, or not enough available units"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 403 {object} response.BaseResponse "Receiver is not an active trading partner or its license has expired"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/batch [post]
+// @Security BearerAuth
+func (h *TransferHandler) CreateTransferByBatch(c echo.Context) error {
+	var req transfer.CreateBatchTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return validation.BindError(err)
+	}
+	if req.Strategy == "" {
+		req.Strategy = transfer.AllocationFEFO
+	}
+	if req.TransferDate == nil {
+		now := time.Now()
+		req.TransferDate = &now
+	}
+	if req.AcceptDeadline != nil && !req.AcceptDeadline.After(*req.TransferDate) {
+		return response.NewError(http.StatusBadRequest, "AcceptDeadline must be after TransferDate")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CreateTransferByBatch: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+	orgID, err := auth.GetOrgIDFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler CreateTransferByBatch: Failed to get organization from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.CreateTransferByBatch(contract, c.Request().Context(), orgID, &req)
+	if !resp.Success {
+		return resp.Error
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// GetHistoryTransfer godoc
+// @Summary Get the history of a transfer
+// @Description Retrieve the ledger key history of a transfer, one record per transaction that wrote it, including deletion.
+// @Tags transfers
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryTransfer]
+// @Failure 400 {object} response.BaseResponse "Invalid Transfer ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/{id}/history [get]
+// @Security BearerAuth
+func (h *TransferHandler) GetHistoryTransfer(c echo.Context) error {
+	transferID := c.Param("id")
+	if transferID == "" {
+		return response.NewError(http.StatusBadRequest, "Transfer ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetHistoryTransfer: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetHistoryTransfer(contract, c.Request().Context(), transferID)
+	if !resp.Success {
+		return resp.Error
+	}
+	return sendList(c, resp)
+}
+
+// GetTransferChanges godoc
+// @Summary Get the field changes of a transfer
+// @Description Compare each version in the key history of a transfer with the one before it and list the fields that changed, with old and new values.
+// @Tags transfers
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param limit query int false "Maximum number of items to return, from 1 to 1000; the whole list is returned when neither limit nor offset is set"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} response.BaseListResponse[entity.HistoryChange]
+// @Failure 400 {object} response.BaseResponse "Invalid Transfer ID"
+// @Failure 401 {object} response.BaseResponse "Unauthorized - JWT invalid or missing"
+// @Failure 500 {object} response.BaseResponse "Internal server error or Fabric error"
+// @Router /transfers/{id}/history/changes [get]
+// @Security BearerAuth
+func (h *TransferHandler) GetTransferChanges(c echo.Context) error {
+	transferID := c.Param("id")
+	if transferID == "" {
+		return response.NewError(http.StatusBadRequest, "Transfer ID parameter is required")
+	}
+
+	contract, err := auth.GetContractFromContext(c)
+	if err != nil {
+		logger.ErrorContext(c.Request().Context(), "Handler GetTransferChanges: Failed to get contract from context", "error", err)
+		return response.NewError(http.StatusInternalServerError, "Failed to access network resources")
+	}
+
+	resp := h.Service.GetTransferChanges(contract, c.Request().Context(), transferID)
+	if !resp.Success {
+		return resp.Error
 	}
-	return c.JSON(httpStatus, resp)
+	return sendList(c, resp)
 }
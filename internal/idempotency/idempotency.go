@@ -0,0 +1,222 @@
+// Package idempotency lets clients retry unsafe requests without repeating their effect on the ledger.
+// A POST, PUT, PATCH or DELETE request carrying an Idempotency-Key header is processed once per organization
+// and key; later requests with the same key get the recorded response again instead of being processed.
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderKey is the request header carrying the idempotency key.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses that were recorded for an earlier request with the same key.
+	HeaderReplayed = "Idempotent-Replayed"
+	// DefaultTTL is how long responses are remembered.
+	DefaultTTL = 24 * time.Hour
+	// DefaultInFlightTimeout is how long a key stays reserved by a request that has not completed.
+	DefaultInFlightTimeout = 5 * time.Minute
+	// DefaultMaxEntries is the number of keys remembered at once.
+	DefaultMaxEntries = 10000
+	// DefaultMaxResponseSize is the largest response body recorded for a key, in bytes.
+	DefaultMaxResponseSize = 1 << 20
+	// MaxKeyLength is the longest key accepted.
+	MaxKeyLength = 255
+)
+
+// entry is the outcome of the first request with a key.
+type entry struct {
+	fingerprint [sha256.Size]byte // Method, path and body of the request
+	done        bool              // False while the first request is being processed
+	status      int
+	contentType string
+	body        []byte
+	tooLarge    bool      // The body exceeded the store's MaxResponseSize and was not recorded
+	expires     time.Time // End of the TTL, or of the in-flight timeout while not done
+}
+
+// Store remembers the responses of requests with an idempotency key. Keys are kept in memory,
+// so they do not survive a restart of the server and are not shared between instances.
+type Store struct {
+	TTL             time.Duration
+	InFlightTimeout time.Duration // After it, a request that has not completed no longer blocks its key
+	MaxEntries      int           // Beyond it, the oldest recorded responses are forgotten first
+	MaxResponseSize int           // Larger response bodies are not recorded; only their status is
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	now     func() time.Time
+}
+
+// NewStore creates a Store remembering responses for ttl.
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		TTL:             ttl,
+		InFlightTimeout: DefaultInFlightTimeout,
+		MaxEntries:      DefaultMaxEntries,
+		MaxResponseSize: DefaultMaxResponseSize,
+		entries:         map[string]*entry{},
+		now:             time.Now,
+	}
+}
+
+// Middleware applies idempotency keys to the requests of an authenticated route group; it must run after
+// auth.AuthMiddleware so that keys are scoped to the calling organization, and after a body limit, since the
+// whole request body is read to fingerprint it. Responses with a 5xx status are not recorded, so that a request
+// that failed on the server side can be retried with the same key. Responses larger than MaxResponseSize are
+// not replayed: later requests with the key get 409 rather than being processed again.
+func (s *Store) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(HeaderKey)
+		if key == "" || !unsafeMethod(c.Request().Method) {
+			return next(c)
+		}
+		if len(key) > MaxKeyLength {
+			return response.NewError(http.StatusBadRequest, "%s must be at most %d characters", HeaderKey, MaxKeyLength)
+		}
+		orgID, _ := auth.GetOrgIDFromContext(c)
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				return err // e.g. 413 from a body limit applied before this middleware
+			}
+			return response.NewError(http.StatusBadRequest, "Failed to read request body: %v", err)
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := sha256.Sum256(bytes.Join([][]byte{[]byte(c.Request().Method), []byte(c.Request().URL.RequestURI()), body}, []byte{0}))
+
+		id := orgID + "\x00" + key
+		s.mu.Lock()
+		s.expire()
+		if e, ok := s.entries[id]; ok {
+			s.mu.Unlock()
+			switch {
+			case e.fingerprint != fingerprint:
+				return response.NewError(http.StatusUnprocessableEntity, "%s %q was already used for a different request", HeaderKey, key)
+			case !e.done:
+				return response.NewError(http.StatusConflict, "A request with %s %q is still being processed", HeaderKey, key)
+			case e.tooLarge:
+				return response.NewError(http.StatusConflict, "The request with %s %q was processed with status %d, but its response was too large to be replayed", HeaderKey, key, e.status)
+			}
+			c.Response().Header().Set(HeaderReplayed, "true")
+			return c.Blob(e.status, e.contentType, e.body)
+		}
+		if !s.reserve() {
+			s.mu.Unlock()
+			return response.NewError(http.StatusServiceUnavailable, "Too many requests with an %s are being processed", HeaderKey)
+		}
+		e := &entry{fingerprint: fingerprint, expires: s.now().Add(s.InFlightTimeout)}
+		s.entries[id] = e
+		s.mu.Unlock()
+
+		rec := &recorder{ResponseWriter: c.Response().Writer, limit: s.MaxResponseSize}
+		c.Response().Writer = rec
+		completed := false
+		// A handler that panics never completes; its key is released so that the request can be retried.
+		defer func() {
+			c.Response().Writer = rec.ResponseWriter
+			if completed {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.entries[id] == e {
+				delete(s.entries, id)
+			}
+		}()
+		// Errors are written here rather than by Echo afterwards, so that they are recorded too.
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+		completed = true
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.entries[id] != e {
+			return nil // Forgotten after the in-flight timeout, and possibly reserved by a retry since
+		}
+		status := c.Response().Status
+		if status >= http.StatusInternalServerError {
+			delete(s.entries, id)
+			return nil
+		}
+		e.done = true
+		e.status = status
+		e.contentType = c.Response().Header().Get(echo.HeaderContentType)
+		e.body = rec.body.Bytes()
+		e.tooLarge = rec.overflow
+		e.expires = s.now().Add(s.TTL)
+		return nil
+	}
+}
+
+// expire forgets the responses older than the TTL and the requests in flight for longer than the
+// in-flight timeout. The caller holds s.mu.
+func (s *Store) expire() {
+	now := s.now()
+	for id, e := range s.entries {
+		if now.After(e.expires) {
+			delete(s.entries, id)
+		}
+	}
+}
+
+// reserve makes room for a new key, forgetting the recorded response that expires first when the store is full.
+// It reports false when every entry belongs to a request still in flight. The caller holds s.mu.
+func (s *Store) reserve() bool {
+	if s.MaxEntries <= 0 || len(s.entries) < s.MaxEntries {
+		return true
+	}
+	oldestID := ""
+	var oldest *entry
+	for id, e := range s.entries {
+		if e.done && (oldest == nil || e.expires.Before(oldest.expires)) {
+			oldestID, oldest = id, e
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(s.entries, oldestID)
+	return true
+}
+
+func unsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// recorder copies the body of a response while it is written, up to limit bytes when limit is positive.
+type recorder struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	limit    int
+	overflow bool // The body exceeded limit; what was copied has been discarded
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	switch {
+	case r.overflow:
+	case r.limit > 0 && r.body.Len()+len(b) > r.limit:
+		r.overflow = true
+		r.body = bytes.Buffer{}
+	default:
+		r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,132 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const body = `{}`
+
+// fingerprint is the fingerprint of the requests sent by serve.
+var fingerprint = sha256.Sum256(bytes.Join([][]byte{[]byte(http.MethodPost), []byte("/"), []byte(body)}, []byte{0}))
+
+// newEcho returns an Echo instance that answers errors of this package with their status.
+func newEcho() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		var info *response.ErrorInfo
+		if errors.As(err, &info) {
+			c.NoContent(info.Code)
+			return
+		}
+		e.DefaultHTTPErrorHandler(err, c)
+	}
+	return e
+}
+
+func serve(e *echo.Echo, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(HeaderKey, key)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPanickingHandlerReleasesKey(t *testing.T) {
+	store := NewStore(time.Hour)
+	calls := 0
+	e := newEcho()
+	e.Use(middleware.Recover())
+	e.POST("/", func(c echo.Context) error {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return c.String(http.StatusCreated, "created")
+	}, store.Middleware)
+
+	if rec := serve(e, "k"); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("first request: status %d, want 500", rec.Code)
+	}
+	if rec := serve(e, "k"); rec.Code != http.StatusCreated {
+		t.Fatalf("retry: status %d, want 201", rec.Code)
+	}
+	if rec := serve(e, "k"); rec.Code != http.StatusCreated || rec.Header().Get(HeaderReplayed) != "true" {
+		t.Fatalf("replay: status %d, replayed %q", rec.Code, rec.Header().Get(HeaderReplayed))
+	}
+}
+
+func TestInFlightKeyExpires(t *testing.T) {
+	store := NewStore(time.Hour)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	store.entries["\x00k"] = &entry{fingerprint: fingerprint, expires: now.Add(store.InFlightTimeout)}
+	e := newEcho()
+	e.POST("/", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }, store.Middleware)
+
+	if rec := serve(e, "k"); rec.Code != http.StatusConflict {
+		t.Fatalf("while in flight: status %d, want 409", rec.Code)
+	}
+	now = now.Add(store.InFlightTimeout + time.Second)
+	if rec := serve(e, "k"); rec.Code != http.StatusNoContent {
+		t.Fatalf("after the in-flight timeout: status %d, want 204", rec.Code)
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	store := NewStore(time.Hour)
+	store.MaxEntries = 2
+	e := newEcho()
+	e.POST("/", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }, store.Middleware)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if rec := serve(e, key); rec.Code != http.StatusNoContent {
+			t.Fatalf("key %s: status %d, want 204", key, rec.Code)
+		}
+	}
+	if len(store.entries) != 2 {
+		t.Fatalf("%d entries, want 2", len(store.entries))
+	}
+
+	store.entries = map[string]*entry{
+		"\x00x": {expires: time.Now().Add(time.Minute)},
+		"\x00y": {expires: time.Now().Add(time.Minute)},
+	}
+	if rec := serve(e, "z"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("with every entry in flight: status %d, want 503", rec.Code)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	store := NewStore(time.Hour)
+	store.MaxResponseSize = 4
+	calls := 0
+	e := newEcho()
+	e.POST("/", func(c echo.Context) error {
+		calls++
+		return c.String(http.StatusCreated, "too large")
+	}, store.Middleware)
+
+	if rec := serve(e, "k"); rec.Code != http.StatusCreated || rec.Body.String() != "too large" {
+		t.Fatalf("first request: status %d body %q, want 201 with the full body", rec.Code, rec.Body.String())
+	}
+	if e := store.entries["\x00k"]; e == nil || !e.tooLarge || len(e.body) != 0 {
+		t.Fatalf("entry %+v, want the status only", e)
+	}
+	if rec := serve(e, "k"); rec.Code != http.StatusConflict {
+		t.Fatalf("replay: status %d, want 409", rec.Code)
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
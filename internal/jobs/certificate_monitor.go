@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+)
+
+// DefaultCertificateCheckInterval is used if CERT_CHECK_INTERVAL env var is not set.
+const DefaultCertificateCheckInterval = time.Hour
+
+// CertificateMonitor checks the identity and TLS CA certificates of every configured organization at startup and
+// then periodically, logging a warning for certificates about to expire and an error for expired or unreadable
+// ones. Each check also updates the certificate expiry metrics.
+type CertificateMonitor struct {
+	Service  *services.CertificateService
+	Interval time.Duration
+}
+
+// NewCertificateMonitor creates a new CertificateMonitor.
+func NewCertificateMonitor(service *services.CertificateService, interval time.Duration) *CertificateMonitor {
+	return &CertificateMonitor{Service: service, Interval: interval}
+}
+
+// Start checks the certificates once and then, unless Interval is zero, every Interval in the background until
+// ctx is cancelled.
+func (m *CertificateMonitor) Start(ctx context.Context) {
+	m.Check(ctx)
+	if m.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(m.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Check(ctx)
+			}
+		}
+	}()
+}
+
+// Check checks the certificates of every configured organization once.
+func (m *CertificateMonitor) Check(ctx context.Context) {
+	resp := m.Service.GetCertificates()
+	if !resp.Success {
+		logger.ErrorContext(ctx, "CertificateMonitor: Failed to check certificates", "error", resp.Error.Message)
+		return
+	}
+	for _, cert := range resp.List {
+		certCtx := logging.With(ctx, slog.String(logging.OrgKey, cert.OrgID))
+		args := []any{"kind", cert.Kind, "path", cert.Path}
+		switch cert.Status {
+		case entity.CertExpiring:
+			logger.WarnContext(certCtx, "CertificateMonitor: Certificate expires soon", append(args, "not_after", cert.NotAfter.Format(time.RFC3339), "days_left", cert.DaysLeft)...)
+		case entity.CertExpired:
+			logger.ErrorContext(certCtx, "CertificateMonitor: Certificate has expired, requests of the organization will fail", append(args, "not_after", cert.NotAfter.Format(time.RFC3339))...)
+		case entity.CertUnreadable:
+			logger.ErrorContext(certCtx, "CertificateMonitor: Failed to read certificate", append(args, "error", cert.Error)...)
+		default:
+			logger.DebugContext(certCtx, "CertificateMonitor: Certificate is valid", append(args, "not_after", cert.NotAfter.Format(time.RFC3339), "days_left", cert.DaysLeft)...)
+		}
+	}
+}
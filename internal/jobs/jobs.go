@@ -0,0 +1,5 @@
+package jobs
+
+import "github.com/AryaJayadi/MedTrace_api/internal/logging"
+
+var logger = logging.Logger("jobs")
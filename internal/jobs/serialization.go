@@ -0,0 +1,328 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+	"github.com/AryaJayadi/MedTrace_api/internal/store"
+)
+
+const (
+	// DefaultSerializationJobsFile is used if SERIALIZATION_JOBS_FILE env var is not set.
+	DefaultSerializationJobsFile = "data/serialization_jobs.json"
+	// DefaultChunkSize is the number of drugs per ledger transaction if the request does not set one.
+	DefaultChunkSize = 500
+	// MaxChunkSize bounds the size of a single CreateDrugs transaction.
+	MaxChunkSize = 1000
+)
+
+// serializationRecord is what gets persisted per job: the public job state plus the request needed to regenerate its drug IDs.
+type serializationRecord struct {
+	Job     entity.SerializationJob    `json:"job"`
+	Request drug.BulkCreateDrugRequest `json:"request"`
+}
+
+// SerializationRunner creates the drugs of bulk serialization requests in bounded chunks in the background.
+// Job state is persisted after every chunk, so a failed or interrupted job can be resumed without creating duplicates.
+type SerializationRunner struct {
+	Service *services.DrugService
+
+	mu      sync.Mutex
+	records map[string]*serializationRecord
+	running map[string]bool
+	file    *store.JSONFile[map[string]*serializationRecord]
+}
+
+// NewSerializationRunner creates a new SerializationRunner backed by the given file.
+// Jobs that were running when the process stopped are marked as failed so they can be resumed.
+func NewSerializationRunner(service *services.DrugService, path string) (*SerializationRunner, error) {
+	file := store.NewJSONFile[map[string]*serializationRecord](path)
+	records, err := file.Load()
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = make(map[string]*serializationRecord)
+	}
+
+	for _, rec := range records {
+		if rec.Job.Status != entity.JobRunning {
+			continue
+		}
+		rec.Job.Status = entity.JobFailed
+		for i := range rec.Job.Chunks {
+			if rec.Job.Chunks[i].Status == entity.JobRunning {
+				rec.Job.Chunks[i].Status = entity.JobFailed
+				rec.Job.Chunks[i].Error = "interrupted by server restart"
+			}
+		}
+	}
+
+	return &SerializationRunner{
+		Service: service,
+		records: records,
+		running: make(map[string]bool),
+		file:    file,
+	}, nil
+}
+
+// Submit validates a bulk serialization request, records a new job for orgID and starts it.
+func (r *SerializationRunner) Submit(orgID string, req *drug.BulkCreateDrugRequest) response.BaseValueResponse[entity.SerializationJob] {
+	ids, err := services.ExpandDrugIDs(req)
+	if err != nil {
+		return response.ErrorValueResponse[entity.SerializationJob](400, "Invalid serialization request: %v", err)
+	}
+	chunkSize := req.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < 0 || chunkSize > MaxChunkSize {
+		return response.ErrorValueResponse[entity.SerializationJob](400, "chunkSize must be between 1 and %d", MaxChunkSize)
+	}
+
+	jobID, err := newJobID()
+	if err != nil {
+		return response.ErrorValueResponse[entity.SerializationJob](500, "Failed to generate job ID: %v", err)
+	}
+
+	now := time.Now().UTC()
+	job := entity.SerializationJob{
+		ID:        jobID,
+		OrgID:     orgID,
+		OwnerID:   req.OwnerID,
+		BatchID:   req.BatchID,
+		Status:    entity.JobPending,
+		Total:     len(ids),
+		ChunkSize: chunkSize,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for start := 0; start < len(ids); start += chunkSize {
+		job.Chunks = append(job.Chunks, entity.SerializationChunk{
+			Index:  len(job.Chunks),
+			Start:  start,
+			End:    min(start+chunkSize, len(ids)),
+			Status: entity.JobPending,
+		})
+	}
+
+	r.mu.Lock()
+	r.records[jobID] = &serializationRecord{Job: job, Request: *req}
+	r.running[jobID] = true
+	err = r.saveLocked()
+	r.mu.Unlock()
+	if err != nil {
+		return response.ErrorValueResponse[entity.SerializationJob](500, "Failed to persist serialization job: %v", err)
+	}
+
+	go r.run(jobID, ids)
+	return response.SuccessValueResponse(job)
+}
+
+// Get returns a job owned by orgID.
+func (r *SerializationRunner) Get(orgID, jobID string) response.BaseValueResponse[entity.SerializationJob] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.records[jobID]
+	if !ok || rec.Job.OrgID != orgID {
+		return response.ErrorValueResponse[entity.SerializationJob](404, "Serialization job %s not found", jobID)
+	}
+	return response.SuccessValueResponse(snapshot(rec.Job))
+}
+
+// Resume restarts the failed chunks of a job owned by orgID.
+func (r *SerializationRunner) Resume(orgID, jobID string) response.BaseValueResponse[entity.SerializationJob] {
+	r.mu.Lock()
+	rec, ok := r.records[jobID]
+	if !ok || rec.Job.OrgID != orgID {
+		r.mu.Unlock()
+		return response.ErrorValueResponse[entity.SerializationJob](404, "Serialization job %s not found", jobID)
+	}
+	if r.running[jobID] {
+		r.mu.Unlock()
+		return response.ErrorValueResponse[entity.SerializationJob](409, "Serialization job %s is already running", jobID)
+	}
+	if rec.Job.Status == entity.JobCompleted {
+		r.mu.Unlock()
+		return response.ErrorValueResponse[entity.SerializationJob](409, "Serialization job %s is already completed", jobID)
+	}
+
+	ids, err := services.ExpandDrugIDs(&rec.Request)
+	if err != nil {
+		r.mu.Unlock()
+		return response.ErrorValueResponse[entity.SerializationJob](500, "Failed to regenerate drug IDs: %v", err)
+	}
+	for i := range rec.Job.Chunks {
+		if rec.Job.Chunks[i].Status == entity.JobFailed {
+			rec.Job.Chunks[i].Status = entity.JobPending
+			rec.Job.Chunks[i].Error = ""
+		}
+	}
+	rec.Job.Status = entity.JobPending
+	rec.Job.UpdatedAt = time.Now().UTC()
+	r.running[jobID] = true
+	job := snapshot(rec.Job)
+	err = r.saveLocked()
+	r.mu.Unlock()
+	if err != nil {
+		logger.Error("SerializationRunner: Failed to persist job", "job", jobID, "error", err)
+	}
+
+	go r.run(jobID, ids)
+	return response.SuccessValueResponse(job)
+}
+
+// run submits every chunk that is not completed yet. Drugs already on the ledger are skipped,
+// which makes re-running a chunk whose earlier transaction did commit harmless.
+func (r *SerializationRunner) run(jobID string, ids []string) {
+	defer func() {
+		r.mu.Lock()
+		delete(r.running, jobID)
+		r.mu.Unlock()
+	}()
+
+	r.mu.Lock()
+	rec := r.records[jobID]
+	orgID, ownerID, batchID := rec.Job.OrgID, rec.Job.OwnerID, rec.Job.BatchID
+	rec.Job.Status = entity.JobRunning
+	r.saveAndUnlock(jobID)
+
+	ctx := logging.With(context.Background(), slog.String(logging.OrgKey, orgID), slog.String("job", jobID))
+	contract, closeGateway, err := auth.NewContractForOrg(orgID)
+	if err != nil {
+		r.finish(jobID, fmt.Sprintf("failed to connect as %s: %v", orgID, err))
+		return
+	}
+	defer func() {
+		if errClose := closeGateway(); errClose != nil {
+			logger.ErrorContext(ctx, "SerializationRunner: Error closing Fabric gateway", "error", errClose)
+		}
+	}()
+
+	existing := make(map[string]bool)
+	existingResp := r.Service.GetDrugByBatch(contract, ctx, batchID)
+	if !existingResp.Success {
+		r.finish(jobID, existingResp.Error.Message)
+		return
+	}
+	for _, d := range existingResp.List {
+		existing[d.ID] = true
+	}
+
+	r.mu.Lock()
+	chunks := append([]entity.SerializationChunk(nil), rec.Job.Chunks...)
+	r.mu.Unlock()
+
+	for _, chunk := range chunks {
+		if chunk.Status == entity.JobCompleted {
+			continue
+		}
+		r.updateChunk(jobID, chunk.Index, func(ch *entity.SerializationChunk) { ch.Status = entity.JobRunning })
+
+		var pending []string
+		for _, id := range ids[chunk.Start:chunk.End] {
+			if !existing[id] {
+				pending = append(pending, id)
+			}
+		}
+		skipped := chunk.End - chunk.Start - len(pending)
+
+		created := 0
+		var chunkErr string
+		if len(pending) > 0 {
+			resp := r.Service.CreateDrugs(contract, ctx, ownerID, batchID, pending)
+			if resp.Success {
+				created = len(pending)
+				for _, id := range pending {
+					existing[id] = true
+				}
+			} else {
+				chunkErr = resp.Error.Message
+			}
+		}
+
+		r.mu.Lock()
+		rec.Job.Created += created
+		r.mu.Unlock()
+		r.updateChunk(jobID, chunk.Index, func(ch *entity.SerializationChunk) {
+			ch.Skipped = skipped
+			if chunkErr != "" {
+				ch.Status = entity.JobFailed
+				ch.Error = chunkErr
+			} else {
+				ch.Status = entity.JobCompleted
+				ch.Error = ""
+			}
+		})
+	}
+	r.finish(jobID, "")
+}
+
+// updateChunk applies fn to a chunk of a job and persists the job.
+func (r *SerializationRunner) updateChunk(jobID string, index int, fn func(*entity.SerializationChunk)) {
+	r.mu.Lock()
+	rec := r.records[jobID]
+	fn(&rec.Job.Chunks[index])
+	r.saveAndUnlock(jobID)
+}
+
+// finish sets the final job status: failed if connecting failed (reason set) or any chunk failed, completed otherwise.
+func (r *SerializationRunner) finish(jobID, reason string) {
+	r.mu.Lock()
+	rec := r.records[jobID]
+	rec.Job.Status = entity.JobCompleted
+	for i := range rec.Job.Chunks {
+		ch := &rec.Job.Chunks[i]
+		if reason != "" && ch.Status != entity.JobCompleted {
+			ch.Status = entity.JobFailed
+			ch.Error = reason
+		}
+		if ch.Status != entity.JobCompleted {
+			rec.Job.Status = entity.JobFailed
+		}
+	}
+	if rec.Job.Status == entity.JobFailed {
+		logger.Warn("SerializationRunner: Job stopped with failed chunks; it can be resumed", "job", jobID, "batch", rec.Job.BatchID)
+	}
+	r.saveAndUnlock(jobID)
+}
+
+// saveAndUnlock stamps the job, persists all records and releases r.mu, which the caller must hold.
+func (r *SerializationRunner) saveAndUnlock(jobID string) {
+	r.records[jobID].Job.UpdatedAt = time.Now().UTC()
+	err := r.saveLocked()
+	r.mu.Unlock()
+	if err != nil {
+		logger.Error("SerializationRunner: Failed to persist job", "job", jobID, "error", err)
+	}
+}
+
+func (r *SerializationRunner) saveLocked() error {
+	return r.file.Save(r.records)
+}
+
+// snapshot copies a job so callers can read it without holding the runner lock.
+func snapshot(job entity.SerializationJob) entity.SerializationJob {
+	job.Chunks = append([]entity.SerializationChunk(nil), job.Chunks...)
+	return job
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/logging"
+	"github.com/AryaJayadi/MedTrace_api/internal/services"
+)
+
+// DefaultTransferExpiryInterval is used if TRANSFER_EXPIRY_SWEEP_INTERVAL env var is not set.
+const DefaultTransferExpiryInterval = time.Minute
+
+// TransferExpirySweeper periodically expires pending transfers whose AcceptDeadline has passed.
+// Transfers can only be expired by their sender, so each sweep runs once per configured organization.
+// An organization's transfers are queried first, and the ExpireTransfers transaction is only submitted when
+// one of them is overdue, so idle sweeps add nothing to the ledger.
+type TransferExpirySweeper struct {
+	Service  *services.TransferService
+	Interval time.Duration
+}
+
+// NewTransferExpirySweeper creates a new TransferExpirySweeper.
+func NewTransferExpirySweeper(service *services.TransferService, interval time.Duration) *TransferExpirySweeper {
+	return &TransferExpirySweeper{Service: service, Interval: interval}
+}
+
+// Start runs the sweeper in the background until ctx is cancelled.
+func (s *TransferExpirySweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Sweep(ctx)
+			}
+		}
+	}()
+}
+
+// Sweep expires overdue transfers for every configured organization once.
+func (s *TransferExpirySweeper) Sweep(ctx context.Context) {
+	now := time.Now()
+	for _, orgID := range config.GetOrgNames() {
+		s.sweepOrg(ctx, orgID, now)
+	}
+}
+
+func (s *TransferExpirySweeper) sweepOrg(ctx context.Context, orgID string, now time.Time) {
+	ctx = logging.With(ctx, slog.String(logging.OrgKey, orgID))
+	contract, closeGateway, err := auth.NewContractForOrg(orgID)
+	if err != nil {
+		logger.ErrorContext(ctx, "TransferExpirySweeper: Failed to connect", "error", err)
+		return
+	}
+	defer func() {
+		if errClose := closeGateway(); errClose != nil {
+			logger.ErrorContext(ctx, "TransferExpirySweeper: Error closing Fabric gateway", "error", errClose)
+		}
+	}()
+
+	overdueResp := s.Service.OverdueTransfers(contract, ctx, now)
+	if !overdueResp.Success {
+		logger.ErrorContext(ctx, "TransferExpirySweeper: Failed to query outgoing transfers", "error", overdueResp.Error.Message)
+		return
+	}
+	if len(overdueResp.List) == 0 {
+		return
+	}
+
+	resp := s.Service.ExpireTransfers(contract, ctx, now)
+	if !resp.Success {
+		logger.ErrorContext(ctx, "TransferExpirySweeper: Failed to expire transfers", "error", resp.Error.Message)
+		return
+	}
+	for _, t := range resp.List {
+		logger.InfoContext(ctx, "TransferExpirySweeper: Expired transfer", "transfer", t.ID, "sender", t.SenderID, "receiver", t.ReceiverID, "deadline", t.AcceptDeadline.Format(time.RFC3339))
+	}
+}
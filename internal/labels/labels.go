@@ -0,0 +1,145 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/datamatrix"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Supported symbologies and output formats.
+const (
+	SymbologyDataMatrix = "datamatrix" // GS1 DataMatrix carrying the element string
+	SymbologyQR         = "qr"         // QR code carrying the GS1 Digital Link URI
+
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// DefaultModuleSize is the size in pixels of one barcode module in PNG output, MaxModuleSize the largest accepted.
+const (
+	DefaultModuleSize = 8
+	MaxModuleSize     = 20
+)
+
+// Label is a rendered label image or document.
+type Label struct {
+	ContentType string
+	Data        []byte
+}
+
+// Encode builds the barcode for ids. DataMatrix symbols carry the FNC1-prefixed GS1 element string;
+// QR symbols carry the GS1 Digital Link URI under resolverBase, which resolves to the public history endpoint.
+func Encode(ids gs1.Identifiers, symbology, resolverBase string) (barcode.Barcode, error) {
+	if ids.Lot != "" {
+		if err := gs1.ValidateLot(ids.Lot); err != nil {
+			return nil, fmt.Errorf("batch ID cannot be encoded as a GS1 lot number: %w", err)
+		}
+	}
+	switch symbology {
+	case SymbologyDataMatrix:
+		fnc1 := string([]byte{datamatrix.FNC1})
+		return datamatrix.Encode(fnc1 + ids.ElementString(fnc1))
+	case SymbologyQR:
+		return qr.Encode(ids.DigitalLink(resolverBase), qr.M, qr.Auto)
+	default:
+		return nil, fmt.Errorf("unsupported symbology '%s', expected %s or %s", symbology, SymbologyDataMatrix, SymbologyQR)
+	}
+}
+
+// Render encodes ids and draws the symbol as PNG or SVG, including the quiet zone required by the symbology.
+func Render(ids gs1.Identifiers, symbology, format, resolverBase string, moduleSize int) (Label, error) {
+	code, err := Encode(ids, symbology, resolverBase)
+	if err != nil {
+		return Label{}, err
+	}
+	if moduleSize <= 0 {
+		moduleSize = DefaultModuleSize
+	}
+	if moduleSize > MaxModuleSize {
+		return Label{}, fmt.Errorf("module size %d is larger than %d pixels", moduleSize, MaxModuleSize)
+	}
+
+	switch format {
+	case FormatPNG:
+		data, err := renderPNG(code, quietZone(symbology), moduleSize)
+		if err != nil {
+			return Label{}, err
+		}
+		return Label{ContentType: "image/png", Data: data}, nil
+	case FormatSVG:
+		return Label{ContentType: "image/svg+xml", Data: renderSVG(code, quietZone(symbology), ids.HumanReadable())}, nil
+	default:
+		return Label{}, fmt.Errorf("unsupported format '%s', expected %s or %s", format, FormatPNG, FormatSVG)
+	}
+}
+
+// quietZone returns the minimum blank margin in modules around a symbol.
+func quietZone(symbology string) int {
+	if symbology == SymbologyQR {
+		return 4
+	}
+	return 1
+}
+
+func renderPNG(code barcode.Barcode, quiet, moduleSize int) ([]byte, error) {
+	bounds := code.Bounds()
+	w, h := bounds.Dx()+2*quiet, bounds.Dy()+2*quiet
+	img := image.NewGray(image.Rect(0, 0, w*moduleSize, h*moduleSize))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			if !isDark(code, bounds.Min.X+x, bounds.Min.Y+y) {
+				continue
+			}
+			for dy := 0; dy < moduleSize; dy++ {
+				for dx := 0; dx < moduleSize; dx++ {
+					img.SetGray((x+quiet)*moduleSize+dx, (y+quiet)*moduleSize+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderSVG(code barcode.Barcode, quiet int, title string) []byte {
+	bounds := code.Bounds()
+	w, h := bounds.Dx()+2*quiet, bounds.Dy()+2*quiet
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, w, h)
+	fmt.Fprintf(&b, `<title>%s</title>`, escapeXML(title))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, w, h)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			if isDark(code, bounds.Min.X+x, bounds.Min.Y+y) {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+quiet, y+quiet)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return []byte(b.String())
+}
+
+func isDark(code barcode.Barcode, x, y int) bool {
+	r, _, _, _ := code.At(x, y).RGBA()
+	return r < 0x8000
+}
+
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
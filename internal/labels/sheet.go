@@ -0,0 +1,87 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/go-pdf/fpdf"
+)
+
+// Sheet layout on A4 portrait, in millimetres.
+const (
+	sheetMargin   = 10.0
+	sheetColumns  = 3
+	sheetRows     = 8
+	labelWidth    = 63.0
+	labelHeight   = 34.0
+	symbolSize    = 24.0
+	textLineStep  = 4.5
+	symbolPadding = 3.0
+)
+
+// Sheet renders a print-ready A4 PDF with one label per entry of items, laid out in a grid.
+// Each label has the symbol on the left and the human-readable GS1 fields on the right.
+func Sheet(items []gs1.Identifiers, symbology, resolverBase, title string) (Label, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("MedTraceAPI", true)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetFont("Helvetica", "", 8)
+
+	perPage := sheetColumns * sheetRows
+	for i, ids := range items {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		slot := i % perPage
+		x := sheetMargin + float64(slot%sheetColumns)*labelWidth
+		y := sheetMargin + float64(slot/sheetColumns)*labelHeight
+
+		code, err := Encode(ids, symbology, resolverBase)
+		if err != nil {
+			return Label{}, fmt.Errorf("label %d: %w", i+1, err)
+		}
+		img, err := renderPNG(code, quietZone(symbology), 4)
+		if err != nil {
+			return Label{}, fmt.Errorf("label %d: %w", i+1, err)
+		}
+		name := fmt.Sprintf("label-%d", i)
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(img))
+		pdf.ImageOptions(name, x+symbolPadding, y+(labelHeight-symbolSize)/2, symbolSize, symbolSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		textX := x + symbolSize + 2*symbolPadding
+		lineY := y + 8
+		for _, line := range humanReadableLines(ids) {
+			pdf.Text(textX, lineY, line)
+			lineY += textLineStep
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return Label{}, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return Label{}, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return Label{ContentType: "application/pdf", Data: buf.Bytes()}, nil
+}
+
+// humanReadableLines returns the text printed next to a symbol, one GS1 field per line.
+func humanReadableLines(ids gs1.Identifiers) []string {
+	var lines []string
+	if ids.GTIN != "" {
+		lines = append(lines, "(01) GTIN "+ids.GTIN)
+	}
+	if ids.Serial != "" {
+		lines = append(lines, "(21) SN "+ids.Serial)
+	}
+	if ids.Lot != "" {
+		lines = append(lines, "(10) LOT "+ids.Lot)
+	}
+	if !ids.ExpiryDate.IsZero() {
+		lines = append(lines, "(17) EXP "+ids.ExpiryDate.Format("2006-01-02"))
+	}
+	return lines
+}
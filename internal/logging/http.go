@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var accessLogger = Logger("http")
+
+// Middleware adds the request ID to the context of every request and logs the request once it is answered.
+// It must run after the request ID middleware and before the tracing middleware, which writes the errors
+// of the request, so that the logged status is the one sent.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		req := c.Request()
+		ctx := With(req.Context(), slog.String(RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID)))
+		c.SetRequest(req.WithContext(ctx))
+
+		err := next(c)
+
+		status := c.Response().Status
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		// The context of the request now also carries what was learned about it, e.g. its organization.
+		accessLogger.LogAttrs(c.Request().Context(), level, "Request",
+			slog.String("method", req.Method),
+			slog.String("route", c.Path()),
+			slog.String("path", req.URL.Path),
+			slog.Int("status", status),
+			slog.Int64("bytes", c.Response().Size),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("remote_ip", c.RealIP()),
+		)
+		return err
+	}
+}
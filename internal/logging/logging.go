@@ -0,0 +1,114 @@
+// Package logging sets up structured JSON logging with log/slog. Every package logs through its own Logger, whose
+// level can be set separately. Lines logged with the context of a request carry its request ID, organization,
+// Fabric user and transaction ID as well as its trace, and secrets are redacted before they are written.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes added to the lines of a request.
+const (
+	PackageKey   = "package"
+	RequestIDKey = "request_id"
+	OrgKey       = "org"
+	UserKey      = "user"
+	TxIDKey      = "tx_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+)
+
+// levels are the minimum levels of the packages, set by Setup.
+type levels struct {
+	fallback slog.Level
+	packages map[string]slog.Level
+}
+
+var current atomic.Pointer[levels]
+
+func init() {
+	current.Store(&levels{fallback: slog.LevelInfo})
+}
+
+// output writes the lines of all loggers. Its own level is the lowest, as the loggers check theirs.
+var output slog.Handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: redact})
+
+// Logger returns the logger of a package, e.g. "auth" or "fabric". Its level is the one Setup gave the package.
+// Loggers can be created before Setup is called.
+func Logger(pkg string) *slog.Logger {
+	return slog.New(&handler{Handler: output.WithAttrs([]slog.Attr{slog.String(PackageKey, pkg)}), pkg: pkg})
+}
+
+// Setup sets the level of all packages and, from a list such as "fabric=debug,handlers=warn", the levels of
+// individual packages. It makes the "main" logger the default, so that the log package writes through it too.
+func Setup(level, packageLevels string) error {
+	l := &levels{packages: map[string]slog.Level{}}
+	if err := l.fallback.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	for _, entry := range strings.Split(packageLevels, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pkg, value, ok := strings.Cut(entry, "=")
+		var pkgLevel slog.Level
+		if !ok || pkg == "" {
+			return fmt.Errorf("invalid package log level %q: expecting package=level", entry)
+		}
+		if err := pkgLevel.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("invalid log level of package %s: %w", pkg, err)
+		}
+		l.packages[pkg] = pkgLevel
+	}
+	current.Store(l)
+	slog.SetDefault(Logger("main"))
+	return nil
+}
+
+type attrsContextKey struct{}
+
+// With returns a context whose log lines carry attrs besides those of ctx.
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsContextKey{}).([]slog.Attr)
+	return context.WithValue(ctx, attrsContextKey{}, append(existing[:len(existing):len(existing)], attrs...))
+}
+
+// handler filters the lines of a package by its level and adds the attributes of their context.
+type handler struct {
+	slog.Handler
+	pkg string
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	l := current.Load()
+	if pkgLevel, ok := l.packages[h.pkg]; ok {
+		return level >= pkgLevel
+	}
+	return level >= l.fallback
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(attrsContextKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()), slog.String(SpanIDKey, sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{Handler: h.Handler.WithAttrs(attrs), pkg: h.pkg}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name), pkg: h.pkg}
+}
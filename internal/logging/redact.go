@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets in log lines.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute keys, lower-cased and without separators, whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"password":      true,
+	"refreshtoken":  true,
+	"accesstoken":   true,
+	"token":         true,
+	"secret":        true,
+	"cookie":        true,
+}
+
+// bearer matches the credentials of an Authorization header quoted in a message.
+var bearer = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+// jwt matches a JSON Web Token without its Bearer prefix: a base64url JSON header, which starts with eyJ, a
+// payload and a signature, empty for unsecured tokens.
+var jwt = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// redact is the ReplaceAttr function of the output handler. It hides the values of sensitive attributes and
+// headers, and bearer tokens and JWTs within strings, errors and fmt.Stringer values.
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if sensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s, ok := redactString(a.Value.String()); ok {
+			return slog.String(a.Key, s)
+		}
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case http.Header:
+			return slog.Any(a.Key, redactHeader(v))
+		case error:
+			if s, ok := redactString(v.Error()); ok {
+				return slog.String(a.Key, s)
+			}
+		case fmt.Stringer:
+			if s, ok := redactString(v.String()); ok {
+				return slog.String(a.Key, s)
+			}
+		}
+	}
+	return a
+}
+
+// redactString replaces bearer credentials and JWTs in s, and reports whether it found any.
+func redactString(s string) (string, bool) {
+	if !bearer.MatchString(s) && !jwt.MatchString(s) {
+		return s, false
+	}
+	s = bearer.ReplaceAllString(s, "$1 "+Redacted)
+	return jwt.ReplaceAllString(s, Redacted), true
+}
+
+func sensitive(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveKeys[key]
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		if sensitive(name) {
+			values = []string{Redacted}
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware records the duration and status of every request under its route pattern, e.g. "/batches/:id",
+// so that the number of series does not grow with IDs. Requests matching no route are recorded as "unmatched".
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestDuration.WithLabelValues(c.Request().Method, route, strconv.Itoa(status(c, err))).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// status is the status a request is answered with. An error returned by the handler is only written by the
+// error handler after the middleware returns, so its status is taken from the error.
+func status(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var errInfo *response.ErrorInfo
+	if errors.As(err, &errInfo) {
+		return errInfo.Code
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,138 @@
+// Package metrics exposes Prometheus metrics of the API: HTTP requests per route, Fabric gateway calls per
+// chaincode function and organization, open gateway connections, the expiry of organization certificates and
+// business events such as transfers and serialized drugs. Metrics are registered with the default Prometheus registry, which also carries the Go
+// runtime and process metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "medtrace"
+
+// Transfer events counted by TransferEvent.
+const (
+	TransferCreated   = "created"
+	TransferAccepted  = "accepted"
+	TransferRejected  = "rejected"
+	TransferCancelled = "cancelled"
+	TransferExpired   = "expired"
+)
+
+// Phases of Fabric gateway calls. Evaluate is a query; a submitted transaction is endorsed, submitted to the
+// orderer and then waited on until it is committed.
+const (
+	PhaseEvaluate     = "evaluate"
+	PhaseEndorse      = "endorse"
+	PhaseSubmit       = "submit"
+	PhaseCommitStatus = "commit_status"
+)
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	fabricCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of Fabric gateway calls by organization, chaincode function and phase.",
+		// Commit waits include block cutting, so the buckets reach further than for HTTP requests.
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"org", "function", "phase"})
+
+	fabricCallErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "call_errors_total",
+		Help:      "Failed Fabric gateway calls by organization, chaincode function, phase and gRPC code.",
+	}, []string{"org", "function", "phase", "code"})
+
+	gatewaysOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "gateways_open",
+		Help:      "Fabric gateway connections currently open by organization.",
+	}, []string{"org"})
+
+	gatewaysOpened = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "gateways_opened_total",
+		Help:      "Fabric gateway connections opened by organization.",
+	}, []string{"org"})
+
+	certificateExpiry = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "certificate",
+		Name:      "expiry_timestamp_seconds",
+		Help:      "Unix time at which an organization's identity or TLS CA certificate expires, by organization and kind.",
+	}, []string{"org", "kind"})
+
+	certificateExpiring = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "certificate",
+		Name:      "expiring",
+		Help:      "1 when an organization's certificate expires within the warning period or has expired, by organization and kind.",
+	}, []string{"org", "kind"})
+
+	transfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Transfers by event: created, accepted, rejected, cancelled or expired.",
+	}, []string{"event"})
+
+	drugsSerialized = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drugs_serialized_total",
+		Help:      "Drugs created on the ledger.",
+	})
+)
+
+// ObserveFabricCall records a Fabric gateway call. code is the gRPC code of a failed call and empty otherwise.
+func ObserveFabricCall(org, function, phase string, duration time.Duration, code string) {
+	fabricCallDuration.WithLabelValues(org, function, phase).Observe(duration.Seconds())
+	if code != "" {
+		fabricCallErrors.WithLabelValues(org, function, phase, code).Inc()
+	}
+}
+
+// GatewayOpened records a new gateway connection of an organization.
+func GatewayOpened(org string) {
+	gatewaysOpened.WithLabelValues(org).Inc()
+	gatewaysOpen.WithLabelValues(org).Inc()
+}
+
+// GatewayClosed records that a gateway connection of an organization was closed.
+func GatewayClosed(org string) {
+	gatewaysOpen.WithLabelValues(org).Dec()
+}
+
+// ObserveCertificate records when a certificate of an organization expires and whether that is within the
+// warning period.
+func ObserveCertificate(org, kind string, notAfter time.Time, expiring bool) {
+	certificateExpiry.WithLabelValues(org, kind).Set(float64(notAfter.Unix()))
+	value := 0.0
+	if expiring {
+		value = 1
+	}
+	certificateExpiring.WithLabelValues(org, kind).Set(value)
+}
+
+// TransferEvent counts n transfers that were created, accepted, rejected, cancelled or expired.
+func TransferEvent(event string, n int) {
+	transfers.WithLabelValues(event).Add(float64(n))
+}
+
+// DrugsSerialized counts n drugs created on the ledger.
+func DrugsSerialized(n int) {
+	drugsSerialized.Add(float64(n))
+}
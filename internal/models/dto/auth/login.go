@@ -1,8 +1,15 @@
 package auth
 
+import "log/slog"
+
 type LoginResponseData struct {
 	AccessToken  string `json:"AccessToken"`
 	RefreshToken string `json:"RefreshToken"`
 	Message      string `json:"Message"`
 	OrgID        string `json:"OrgID"`
 }
+
+// LogValue leaves the tokens out of log lines.
+func (d LoginResponseData) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("Message", d.Message), slog.String("OrgID", d.OrgID))
+}
@@ -1,6 +1,13 @@
 package auth
 
+import "log/slog"
+
 type PayloadLogin struct {
 	Organization string `json:"organization" validate:"required"`
 	Password     string `json:"password" validate:"required"`
 }
+
+// LogValue leaves the password out of log lines.
+func (p PayloadLogin) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("organization", p.Organization))
+}
@@ -1,5 +1,12 @@
 package auth
 
+import "log/slog"
+
 type PayloadRefreshToken struct {
 	RefreshToken string `json:"RefreshToken" validate:"required"`
 }
+
+// LogValue leaves the refresh token out of log lines.
+func (p PayloadRefreshToken) LogValue() slog.Value {
+	return slog.GroupValue()
+}
@@ -1,5 +1,12 @@
 package auth
 
+import "log/slog"
+
 type RefreshTokenResponseData struct {
 	AccessToken string `json:"AccessToken"`
 }
+
+// LogValue leaves the access token out of log lines.
+func (d RefreshTokenResponseData) LogValue() slog.Value {
+	return slog.GroupValue()
+}
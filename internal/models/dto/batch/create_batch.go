@@ -5,9 +5,10 @@ import (
 )
 
 type CreateBatch struct {
-	Amount         int       `json:"Amount"`         // Amount of drugs in the batch
-	DrugName       string    `json:"DrugName"`       // Drug name
-	ExpiryDate     time.Time `json:"ExpiryDate"`     // Expiry date for all drugs in the batch
-	ID             string    `json:"ID"`             // Unique batch ID
-	ProductionDate time.Time `json:"ProductionDate"` // Production date for all drugs in the batch
+	Amount         int       `json:"Amount" validate:"gt=0"`                                // Amount of drugs in the batch
+	DrugName       string    `json:"DrugName" validate:"required"`                          // Drug name
+	ExpiryDate     time.Time `json:"ExpiryDate" validate:"required,gtfield=ProductionDate"` // Expiry date for all drugs in the batch
+	ID             string    `json:"ID" validate:"required"`                                // Unique batch ID
+	ProductionDate time.Time `json:"ProductionDate" validate:"required"`                    // Production date for all drugs in the batch
+	GTIN           string    `json:"GTIN,omitempty" validate:"omitempty,gtin"`              // Optional GS1 GTIN of the product; the batch ID is then its lot number
 }
@@ -3,7 +3,7 @@ package batch
 import "time"
 
 type UpdateBatch struct {
-	DrugName       string    `json:"DrugName"`       // Drug name
-	ExpiryDate     time.Time `json:"ExpiryDate"`     // Expiry date for all drugs in the batch
-	ProductionDate time.Time `json:"ProductionDate"` // Production date
+	DrugName       string    `json:"DrugName" validate:"required"`                          // Drug name
+	ExpiryDate     time.Time `json:"ExpiryDate" validate:"required,gtfield=ProductionDate"` // Expiry date for all drugs in the batch
+	ProductionDate time.Time `json:"ProductionDate" validate:"required"`                    // Production date
 }
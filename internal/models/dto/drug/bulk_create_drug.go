@@ -0,0 +1,29 @@
+package drug
+
+// SerialRange generates drug IDs as Prefix followed by each number from Start to End (inclusive),
+// left-padded with zeros to Width digits.
+type SerialRange struct {
+	Prefix string `json:"prefix"`
+	Start  int64  `json:"start" validate:"gte=0"`
+	End    int64  `json:"end" validate:"gtefield=Start"`
+	Width  int    `json:"width" validate:"gte=0,lte=20"`
+}
+
+// SerialPattern generates Count drug IDs from Template, starting the sequence at Start.
+// The template may contain {batch} for the batch ID and {seq} or {seq:N} for the sequence number padded to N digits.
+type SerialPattern struct {
+	Template string `json:"template" validate:"required"`
+	Start    int64  `json:"start" validate:"gte=0"`
+	Count    int    `json:"count" validate:"gt=0"`
+}
+
+// BulkCreateDrugRequest defines the structure for serializing many drugs of one batch in a background job.
+// Exactly one of DrugsID, Range and Pattern must be set.
+type BulkCreateDrugRequest struct {
+	OwnerID   string         `json:"ownerID" validate:"required"`
+	BatchID   string         `json:"batchID" validate:"required"`
+	DrugsID   []string       `json:"drugsID,omitempty" validate:"omitempty,unique,dive,required"` // Explicit list of drug IDs
+	Range     *SerialRange   `json:"range,omitempty"`                                             // Numeric serial range
+	Pattern   *SerialPattern `json:"pattern,omitempty"`                                           // Generator pattern
+	ChunkSize int            `json:"chunkSize,omitempty" validate:"gte=0,lte=1000"`               // Drugs per ledger transaction; defaults to 500
+}
@@ -2,8 +2,13 @@ package drug
 
 // CreateDrugRequest defines the structure for creating a new drug via API
 type CreateDrugRequest struct {
-	OwnerID string `json:"ownerID"`
-	BatchID string `json:"batchID"`
-	DrugID  string `json:"drugID"` // Client might suggest an ID or chaincode generates it.
+	OwnerID string `json:"ownerID" validate:"required"`
+	BatchID string `json:"batchID" validate:"required"`
+	DrugID  string `json:"drugID" validate:"required_without=GTIN"` // Client might suggest an ID or chaincode generates it.
 	// Based on chaincode, it seems client provides it.
+
+	// Optional GS1 identity. When both are set the drug ID is the SGTIN "<GTIN-14>.<serial>";
+	// DrugID may then be omitted, or must match.
+	GTIN         string `json:"gtin,omitempty" validate:"omitempty,gtin"`
+	SerialNumber string `json:"serialNumber,omitempty" validate:"required_with=GTIN"`
 }
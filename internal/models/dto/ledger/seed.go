@@ -0,0 +1,27 @@
+package ledger
+
+import (
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/organization"
+)
+
+// Seed is the data written to an empty ledger in place of the chaincode's built-in sample data.
+// It is read from a JSON or YAML file; entries that already exist on the ledger are skipped.
+type Seed struct {
+	Organizations []organization.RegisterOrganization `json:"organizations"`
+	Batches       []SeedBatch                         `json:"batches"`
+	Drugs         []SeedDrugs                         `json:"drugs"`
+}
+
+// SeedBatch is a batch created by its manufacturer.
+type SeedBatch struct {
+	batch.CreateBatch
+	ManufacturerID string `json:"ManufacturerID,omitempty"` // Optional. Organization that creates the batch; defaults to the caller
+}
+
+// SeedDrugs are drugs of one batch, created by their owner.
+type SeedDrugs struct {
+	OwnerID string   `json:"ownerID"`
+	BatchID string   `json:"batchID"`
+	DrugsID []string `json:"drugsID"`
+}
@@ -0,0 +1,13 @@
+package organization
+
+// RegisterOrganization registers a new organization on the ledger and maps it to its Fabric MSP and peer
+type RegisterOrganization struct {
+	ID           string `json:"ID" validate:"required"`                           // Organization ID, also used to log in (e.g. "Org5")
+	Name         string `json:"Name" validate:"required"`                         // Organization name
+	Type         string `json:"Type" validate:"required"`                         // Organization type (e.g., Manufacturer, Distributor, Pharmacy)
+	Location     string `json:"Location"`                                         // Organization location
+	MSPID        string `json:"MSPID" validate:"required"`                        // Fabric MSP ID (e.g. "Org5MSP")
+	PeerEndpoint string `json:"PeerEndpoint" validate:"required"`                 // gRPC endpoint of the organization's gateway peer (e.g. "dns:///localhost:11051")
+	GatewayPeer  string `json:"GatewayPeer" validate:"required,hostname_rfc1123"` // TLS host name of the gateway peer (e.g. "peer0.org5.medtrace.com")
+	CryptoPath   string `json:"CryptoPath,omitempty"`                             // Optional. Directory of the organization's crypto material, derived from the ID if empty
+}
@@ -0,0 +1,7 @@
+package organization
+
+type UpdateOrganization struct {
+	Name     string `json:"Name" validate:"required_without_all=Type Location"` // Organization name
+	Type     string `json:"Type"`                                               // Organization type (e.g., Manufacturer, Distributor, Pharmacy)
+	Location string `json:"Location"`                                           // Organization location
+}
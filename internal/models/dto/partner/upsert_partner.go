@@ -0,0 +1,10 @@
+package partner
+
+import "time"
+
+// UpsertPartner adds a trading partner to the caller's list or updates its license and status
+type UpsertPartner struct {
+	LicenseNumber string     `json:"LicenseNumber" validate:"required"`                                    // License of the partner, e.g. its state wholesale distributor license
+	LicenseExpiry *time.Time `json:"LicenseExpiry" validate:"required"`                                    // Transfers to the partner are refused from this time on
+	Status        string     `json:"Status,omitempty" validate:"omitempty,oneof=ACTIVE SUSPENDED REVOKED"` // ACTIVE (default), SUSPENDED or REVOKED
+}
@@ -0,0 +1,28 @@
+package transfer
+
+import "time"
+
+// Allocation strategies for picking units when a transfer is requested by batch or drug name.
+const (
+	AllocationFEFO = "FEFO" // First-expiry-first-out: earliest ExpiryDate first (default)
+	AllocationFIFO = "FIFO" // First-in-first-out: earliest ProductionDate first
+)
+
+// BatchAllocation requests units either from one batch or, by drug name, from any of the caller's batches.
+// Exactly one of BatchID and DrugName must be set.
+type BatchAllocation struct {
+	BatchID  string `json:"BatchID,omitempty" validate:"required_without=DrugName,excluded_with=DrugName"` // Take units from this batch only
+	DrugName string `json:"DrugName,omitempty" validate:"required_without=BatchID"`                        // Take units of this drug from any batch, in strategy order
+	Quantity int    `json:"Quantity" validate:"gte=0"`                                                     // Number of units; 0 means every available unit
+}
+
+// CreateBatchTransferRequest defines the structure for creating a transfer by batch and quantity.
+// The API resolves the concrete drug IDs from the caller's available drugs before calling CreateTransfer.
+type CreateBatchTransferRequest struct {
+	ReceiverID     string            `json:"ReceiverID" validate:"required"`                          // Receiver ID
+	TransferDate   *time.Time        `json:"TransferDate"`                                            // Transfer date
+	AcceptDeadline *time.Time        `json:"AcceptDeadline,omitempty"`                                // Optional acceptance deadline
+	Items          []BatchAllocation `json:"Items" validate:"required,min=1,dive"`                    // What to ship
+	Strategy       string            `json:"Strategy,omitempty" validate:"omitempty,oneof=FEFO FIFO"` // FEFO (default) or FIFO
+	SSCC           string            `json:"SSCC,omitempty" validate:"omitempty,sscc"`                // Optional GS1 SSCC of the shipping unit
+}
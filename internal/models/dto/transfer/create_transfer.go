@@ -5,8 +5,10 @@ import "time"
 // CreateTransferRequest defines the structure for creating a new transfer via API
 // Its JSON tags must match the fields expected by the chaincode's CreateTransfer DTO
 type CreateTransferRequest struct {
-	DrugsID      []string   `json:"DrugsID"`      // List of drug IDs. Chaincode expects "DrugsID".
-	ReceiverID   string     `json:"ReceiverID"`   // Receiver ID. Chaincode expects "ReceiverID".
-	TransferDate *time.Time `json:"TransferDate"` // Transfer date. Chaincode expects "TransferDate".
+	DrugsID        []string   `json:"DrugsID" validate:"required,min=1,unique,dive,required"` // List of drug IDs. Chaincode expects "DrugsID".
+	ReceiverID     string     `json:"ReceiverID" validate:"required"`                         // Receiver ID. Chaincode expects "ReceiverID".
+	TransferDate   *time.Time `json:"TransferDate"`                                           // Transfer date. Chaincode expects "TransferDate".
+	AcceptDeadline *time.Time `json:"AcceptDeadline,omitempty"`                               // Optional. Pending transfers past this time are expired and the drugs released.
+	SSCC           string     `json:"SSCC,omitempty" validate:"omitempty,sscc"`               // Optional GS1 SSCC of the shipping unit carrying the drugs.
 	// SenderID is omitted as it's determined by the chaincode from the caller's identity.
 }
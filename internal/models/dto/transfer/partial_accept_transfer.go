@@ -0,0 +1,26 @@
+package transfer
+
+import "time"
+
+// Discrepancy types a receiver can report for a drug in a partially accepted transfer.
+const (
+	DiscrepancyMissing = "MISSING" // Listed in the transfer but not delivered
+	DiscrepancyDamaged = "DAMAGED" // Delivered but not fit to accept
+	DiscrepancySurplus = "SURPLUS" // Delivered but not listed in the transfer
+)
+
+// DrugDiscrepancy describes a single drug the receiver does not accept as delivered.
+type DrugDiscrepancy struct {
+	DrugID string `json:"DrugID" validate:"required"`                             // Drug the discrepancy refers to
+	Type   string `json:"Type" validate:"required,oneof=MISSING DAMAGED SURPLUS"` // One of MISSING, DAMAGED or SURPLUS
+	Note   string `json:"Note"`                                                   // Free-form remark from the receiver
+}
+
+// PartialAcceptTransferRequest defines the structure for accepting a subset of a transfer
+// Its JSON tags must match the fields expected by the chaincode's PartialAcceptTransfer DTO
+type PartialAcceptTransferRequest struct {
+	TransferID      string            `json:"transferID" validate:"required"`                  // Chaincode expects "transferID".
+	ReceiveDate     *time.Time        `json:"ReceiveDate,omitempty"`                           // Chaincode expects "ReceiveDate".
+	AcceptedDrugsID []string          `json:"AcceptedDrugsID" validate:"unique,dive,required"` // Drugs taken into the receiver's custody.
+	Discrepancies   []DrugDiscrepancy `json:"Discrepancies" validate:"dive"`                   // Everything else, with a reason per drug.
+}
@@ -11,4 +11,5 @@ type Batch struct {
 	ManufacturerName    string    `json:"ManufacturerName"`    // Manufacturer name
 	ManufactureLocation string    `json:"ManufactureLocation"` // Manufacture timestamp
 	ProductionDate      time.Time `json:"ProductionDate"`      // Production date
+	GTIN                string    `json:"GTIN,omitempty"`      // GS1 GTIN-14 of the product, if known
 }
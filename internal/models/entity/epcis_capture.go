@@ -0,0 +1,19 @@
+package entity
+
+// Outcomes of a captured EPCIS event
+const (
+	CaptureSubmitted = "SUBMITTED"
+	CaptureSkipped   = "SKIPPED"
+	CaptureFailed    = "FAILED"
+)
+
+// EPCISCaptureResult reports what was written to the ledger for one event of a captured EPCIS document
+type EPCISCaptureResult struct {
+	Index       int      `json:"Index"` // Position of the event in the document
+	EventID     string   `json:"EventID,omitempty"`
+	Type        string   `json:"Type"`
+	BizStep     string   `json:"BizStep,omitempty"`
+	Status      string   `json:"Status"`
+	Submissions []string `json:"Submissions,omitempty"` // Ledger writes made, e.g. "CreateBatch LOT1"
+	Message     string   `json:"Message,omitempty"`
+}
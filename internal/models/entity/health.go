@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Health states
+const (
+	HealthUp   = "UP"
+	HealthDown = "DOWN"
+)
+
+// Certificate states
+const (
+	CertValid      = "VALID"
+	CertExpiring   = "EXPIRING" // Expires within the warning period
+	CertExpired    = "EXPIRED"
+	CertUnreadable = "UNREADABLE"
+)
+
+// HealthReport is the result of a health check of the API and the organizations it acts as.
+type HealthReport struct {
+	Status        string      `json:"Status"` // UP when every organization is up
+	CheckedAt     time.Time   `json:"CheckedAt"`
+	Organizations []OrgHealth `json:"Organizations,omitempty"`
+}
+
+// OrgHealth is the health of one organization: whether its crypto material loads and its gateway peer answers.
+type OrgHealth struct {
+	OrgID        string              `json:"OrgID"`
+	Status       string              `json:"Status"`
+	Error        string              `json:"Error,omitempty"` // Why the organization is down
+	PeerEndpoint string              `json:"PeerEndpoint,omitempty"`
+	LatencyMs    int64               `json:"LatencyMs,omitempty"`   // Duration of the query to the gateway peer
+	BlockHeight  uint64              `json:"BlockHeight,omitempty"` // Height of the channel's chain at the peer
+	Certificates []CertificateStatus `json:"Certificates,omitempty"`
+	LastCommit   *CommitRecord       `json:"LastCommit,omitempty"` // Unset when nothing was committed since the server started
+}
+
+// CertificateStatus describes a certificate an organization connects with and how close it is to expiring.
+type CertificateStatus struct {
+	OrgID    string    `json:"OrgID"`
+	Kind     string    `json:"Kind"` // "identity" or "tls_ca"
+	Path     string    `json:"Path"`
+	Subject  string    `json:"Subject,omitempty"`
+	NotAfter time.Time `json:"NotAfter"`
+	Status   string    `json:"Status"`
+	DaysLeft int       `json:"DaysLeft"`        // Whole days until NotAfter, negative once expired
+	Error    string    `json:"Error,omitempty"` // Why the certificate could not be read
+}
+
+// CommitRecord is a transaction committed as valid through an organization's gateway.
+type CommitRecord struct {
+	TxID        string    `json:"TxID"`
+	BlockNumber uint64    `json:"BlockNumber"`
+	CommittedAt time.Time `json:"CommittedAt"`
+}
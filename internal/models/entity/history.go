@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type HistoryBatch struct {
+	Batch     *Batch    `json:"Batch"`
+	TxID      string    `json:"TxID"`
+	Timestamp time.Time `json:"Timestamp"`
+	IsDelete  bool      `json:"IsDelete"`
+}
+
+type HistoryTransfer struct {
+	Transfer  *Transfer `json:"Transfer"`
+	TxID      string    `json:"TxID"`
+	Timestamp time.Time `json:"Timestamp"`
+	IsDelete  bool      `json:"IsDelete"`
+}
+
+type HistoryOrganization struct {
+	Organization *Organization `json:"Organization"`
+	TxID         string        `json:"TxID"`
+	Timestamp    time.Time     `json:"Timestamp"`
+	IsDelete     bool          `json:"IsDelete"`
+}
+
+// historyInput is a key history record as returned by the chaincode history functions
+type historyInput[T any] struct {
+	Record    *T        `json:"record"`
+	TxId      string    `json:"txId"`
+	Timestamp time.Time `json:"timestamp"`
+	IsDelete  bool      `json:"isDelete"`
+}
+
+func (h *HistoryBatch) UnmarshalJSON(data []byte) error {
+	var input historyInput[Batch]
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	h.Batch = input.Record
+	h.TxID = input.TxId
+	h.Timestamp = input.Timestamp
+	h.IsDelete = input.IsDelete
+	return nil
+}
+
+func (h *HistoryTransfer) UnmarshalJSON(data []byte) error {
+	var input historyInput[Transfer]
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	h.Transfer = input.Record
+	h.TxID = input.TxId
+	h.Timestamp = input.Timestamp
+	h.IsDelete = input.IsDelete
+	return nil
+}
+
+func (h *HistoryOrganization) UnmarshalJSON(data []byte) error {
+	var input historyInput[Organization]
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	h.Organization = input.Record
+	h.TxID = input.TxId
+	h.Timestamp = input.Timestamp
+	h.IsDelete = input.IsDelete
+	return nil
+}
+
+// FieldChange is one field that differs between two consecutive versions of a ledger record.
+// Old is null for a field the version added and New is null for a field it removed.
+type FieldChange struct {
+	Field string `json:"Field"`
+	Old   any    `json:"Old"`
+	New   any    `json:"New"`
+}
+
+// HistoryChange lists the fields a transaction changed compared to the previous version of the record.
+// The first version lists every field as added; a deletion lists every field as removed.
+type HistoryChange struct {
+	TxID      string        `json:"TxID"`
+	Timestamp time.Time     `json:"Timestamp"`
+	IsDelete  bool          `json:"IsDelete"`
+	Changes   []FieldChange `json:"Changes"`
+}
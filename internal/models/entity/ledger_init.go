@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// LedgerInitRecord records one request to initialize the ledger.
+type LedgerInitRecord struct {
+	OrgID         string    `json:"OrgID"` // Organization that made the request
+	RemoteIP      string    `json:"RemoteIP"`
+	Source        string    `json:"Source"` // "builtin", "request" or the path of the seed file
+	StartedAt     time.Time `json:"StartedAt"`
+	FinishedAt    time.Time `json:"FinishedAt"`
+	Success       bool      `json:"Success"`
+	Error         string    `json:"Error,omitempty"`
+	Organizations int       `json:"Organizations"` // Organizations created from the seed
+	Batches       int       `json:"Batches"`       // Batches created from the seed
+	Drugs         int       `json:"Drugs"`         // Drugs created from the seed
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/AryaJayadi/MedTrace_api/pkg/verifier"
+
+// VerifiableHistory is the key history of a ledger record with, for each entry, the block number, validation code
+// and signed envelope of the transaction that wrote it, so pkg/verifier can check it offline
+type VerifiableHistory = verifier.History
+
+// VerifiableHistoryEntry is one version of a record and the proof of the transaction that wrote it
+type VerifiableHistoryEntry = verifier.Entry
+
+// LedgerProof locates a transaction on the ledger and carries its signed envelope
+type LedgerProof = verifier.Proof
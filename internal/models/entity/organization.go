@@ -1,8 +1,10 @@
 package entity
 
 type Organization struct {
-	ID       string `json:"ID"`       // Unique organization ID
-	Location string `json:"Location"` // Organization location
-	Name     string `json:"Name"`     // Organization name
-	Type     string `json:"Type"`     // Organization type (e.g., Manufacturer, Distributor, Pharmacy)
+	ID            string `json:"ID"`            // Unique organization ID
+	Location      string `json:"Location"`      // Organization location
+	Name          string `json:"Name"`          // Organization name
+	Type          string `json:"Type"`          // Organization type (e.g., Manufacturer, Distributor, Pharmacy)
+	MSPID         string `json:"MSPID"`         // Fabric MSP the organization's identities belong to
+	IsDeactivated bool   `json:"IsDeactivated"` // Deactivated organizations cannot receive transfers
 }
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/utils"
+)
+
+// Transfer statuses shown in provenance graphs
+const (
+	TransferPending   = "PENDING"
+	TransferAccepted  = "ACCEPTED"
+	TransferRejected  = "REJECTED"
+	TransferCancelled = "CANCELLED"
+	TransferExpired   = "EXPIRED"
+)
+
+// Unit statuses shown in provenance graphs
+const (
+	UnitInStock        = "IN_STOCK"
+	UnitInTransit      = "IN_TRANSIT"
+	UnitDecommissioned = "DECOMMISSIONED"
+)
+
+// ProvenanceNode is an organization that held or was offered units
+type ProvenanceNode struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	Type     string `json:"Type"`
+	Location string `json:"Location"`
+	Origin   bool   `json:"Origin"`  // First owner of at least one unit, usually the manufacturer
+	Holding  int    `json:"Holding"` // Units currently owned and not in transit
+}
+
+// ProvenanceEdge is a custody hop: a transfer of units between two organizations
+type ProvenanceEdge struct {
+	TransferID   string             `json:"TransferID"`
+	From         string             `json:"From"`
+	To           string             `json:"To"`
+	Status       string             `json:"Status"`
+	TransferDate time.Time          `json:"TransferDate"`
+	ReceiveDate  utils.OptionalTime `json:"ReceiveDate"` // Acceptance or rejection time
+	Quantity     int                `json:"Quantity"`
+	DrugsID      []string           `json:"DrugsID"`
+}
+
+// ProvenanceUnit is the custody path and current state of one drug
+type ProvenanceUnit struct {
+	DrugID         string   `json:"DrugID"`
+	BatchID        string   `json:"BatchID"`
+	CurrentOwnerID string   `json:"CurrentOwnerID"`
+	Status         string   `json:"Status"`
+	Location       string   `json:"Location"`
+	TransferID     string   `json:"TransferID,omitempty"` // Pending transfer of a unit in transit
+	Path           []string `json:"Path"`                 // Owners in order, from the first owner to the current one
+}
+
+// Provenance is the composed custody view of a drug, batch or transfer: organizations as nodes and transfers as edges
+type Provenance struct {
+	Subject   string           `json:"Subject"` // "drug", "batch" or "transfer"
+	SubjectID string           `json:"SubjectID"`
+	Batches   []Batch          `json:"Batches"`
+	Nodes     []ProvenanceNode `json:"Nodes"`
+	Edges     []ProvenanceEdge `json:"Edges"`
+	Units     []ProvenanceUnit `json:"Units"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+)
+
+// Serialization job and chunk states
+const (
+	JobPending   = "PENDING"
+	JobRunning   = "RUNNING"
+	JobCompleted = "COMPLETED"
+	JobFailed    = "FAILED"
+)
+
+// SerializationChunk is one bounded ledger transaction of a bulk serialization job.
+// Start and End are offsets into the job's generated drug IDs (End exclusive).
+type SerializationChunk struct {
+	Index   int    `json:"Index"`
+	Start   int    `json:"Start"`
+	End     int    `json:"End"`
+	Status  string `json:"Status"`
+	Skipped int    `json:"Skipped"` // Drugs already on the ledger when the chunk was submitted
+	Error   string `json:"Error,omitempty"`
+}
+
+// SerializationJob tracks the progress of creating all drugs of a batch in chunks
+type SerializationJob struct {
+	ID        string               `json:"ID"`
+	OrgID     string               `json:"OrgID"`
+	OwnerID   string               `json:"OwnerID"`
+	BatchID   string               `json:"BatchID"`
+	Status    string               `json:"Status"`
+	Total     int                  `json:"Total"`
+	Created   int                  `json:"Created"`
+	ChunkSize int                  `json:"ChunkSize"`
+	Chunks    []SerializationChunk `json:"Chunks"`
+	CreatedAt time.Time            `json:"CreatedAt"`
+	UpdatedAt time.Time            `json:"UpdatedAt"`
+}
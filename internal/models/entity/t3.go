@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+)
+
+// T3Product is the transaction information for one product lot in a transfer
+type T3Product struct {
+	DrugName         string    `json:"DrugName"`
+	GTIN             string    `json:"GTIN,omitempty"`
+	LotNumber        string    `json:"LotNumber"` // Batch ID
+	ExpiryDate       time.Time `json:"ExpiryDate"`
+	ManufacturerName string    `json:"ManufacturerName"`
+	Quantity         int       `json:"Quantity"`
+	DrugsID          []string  `json:"DrugsID"`
+}
+
+// T3Party identifies a trading partner by organization ID, name and address
+type T3Party struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	Location string `json:"Location"`
+}
+
+// T3TransactionInfo is the transaction information (TI) of a change of ownership
+type T3TransactionInfo struct {
+	TransferID      string      `json:"TransferID"`
+	TransactionDate time.Time   `json:"TransactionDate"`
+	SSCC            string      `json:"SSCC,omitempty"`
+	Seller          T3Party     `json:"Seller"`
+	Buyer           T3Party     `json:"Buyer"`
+	Products        []T3Product `json:"Products"`
+}
+
+// T3HistoryEntry is one prior change of ownership in the transaction history (TH), oldest first
+type T3HistoryEntry struct {
+	TransferID      string    `json:"TransferID"`
+	TransactionDate time.Time `json:"TransactionDate"`
+	ReceiveDate     time.Time `json:"ReceiveDate"`
+	Seller          T3Party   `json:"Seller"`
+	Buyer           T3Party   `json:"Buyer"`
+	Quantity        int       `json:"Quantity"` // Drugs of this transfer that went through it
+}
+
+// T3Statement is the seller's transaction statement (TS)
+type T3Statement struct {
+	SellerID   string   `json:"SellerID"`
+	Statements []string `json:"Statements"`
+}
+
+// DocumentSignature is a detached ECDSA signature over the JSON of a document without its signature, as encoded
+// by Go's json.Marshal in struct field order (see signing.SignedBytes); other JSON encoders produce different bytes
+type DocumentSignature struct {
+	Algorithm   string    `json:"Algorithm"`
+	SignerOrgID string    `json:"SignerOrgID"`
+	SignerMSPID string    `json:"SignerMSPID"`
+	Certificate string    `json:"Certificate"` // PEM-encoded X.509 certificate of the signer
+	Digest      string    `json:"Digest"`      // Base64 SHA-256 digest of the signed bytes
+	Value       string    `json:"Value"`       // Base64 ASN.1 ECDSA signature
+	SignedAt    time.Time `json:"SignedAt"`
+}
+
+// T3Document bundles the transaction information, history and statement for a transfer.
+// It is frozen when first generated and kept for the retention period.
+type T3Document struct {
+	TransferID  string             `json:"TransferID"`
+	GeneratedAt time.Time          `json:"GeneratedAt"`
+	RetainUntil time.Time          `json:"RetainUntil"`
+	Information T3TransactionInfo  `json:"TransactionInformation"`
+	History     []T3HistoryEntry   `json:"TransactionHistory"`
+	Statement   T3Statement        `json:"TransactionStatement"`
+	Signature   *DocumentSignature `json:"Signature,omitempty"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Trading partner statuses
+const (
+	PartnerActive    = "ACTIVE"
+	PartnerSuspended = "SUSPENDED"
+	PartnerRevoked   = "REVOKED"
+)
+
+// TradingPartner is an organization another organization is authorized to ship to, with the license that authorizes it
+type TradingPartner struct {
+	OrgID         string    `json:"OrgID"`     // Organization keeping the partner list
+	PartnerID     string    `json:"PartnerID"` // Authorized receiver
+	LicenseNumber string    `json:"LicenseNumber"`
+	LicenseExpiry time.Time `json:"LicenseExpiry"`
+	Status        string    `json:"Status"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
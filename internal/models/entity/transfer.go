@@ -8,10 +8,14 @@ import (
 
 // Transfer entity based on chaincode model (updated to value types)
 type Transfer struct {
-	ID           string             `json:"ID"`
-	IsAccepted   bool               `json:"isAccepted"`
-	ReceiveDate  utils.OptionalTime `json:"ReceiveDate"`
-	ReceiverID   string             `json:"ReceiverID"`
-	SenderID     string             `json:"SenderID"`
-	TransferDate time.Time          `json:"TransferDate"`
+	ID             string             `json:"ID"`
+	IsAccepted     bool               `json:"isAccepted"`
+	IsCancelled    bool               `json:"isCancelled"`
+	IsExpired      bool               `json:"isExpired"`
+	AcceptDeadline utils.OptionalTime `json:"AcceptDeadline"`
+	ReceiveDate    utils.OptionalTime `json:"ReceiveDate"`
+	ReceiverID     string             `json:"ReceiverID"`
+	SenderID       string             `json:"SenderID"`
+	TransferDate   time.Time          `json:"TransferDate"`
+	SSCC           string             `json:"SSCC,omitempty"`
 }
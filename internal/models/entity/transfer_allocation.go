@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+)
+
+// AllocatedBatch lists the units picked from a single batch for a transfer
+type AllocatedBatch struct {
+	BatchID    string    `json:"BatchID"`
+	DrugName   string    `json:"DrugName"`
+	ExpiryDate time.Time `json:"ExpiryDate"`
+	DrugsID    []string  `json:"DrugsID"`
+}
+
+// TransferAllocation is the transfer created from a batch/quantity request together with the units allocated to it
+type TransferAllocation struct {
+	Transfer    Transfer         `json:"Transfer"`
+	Allocations []AllocatedBatch `json:"Allocations"`
+}
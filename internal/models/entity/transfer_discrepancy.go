@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// DiscrepancyItem is a single drug reported as missing, damaged or surplus on receipt
+type DiscrepancyItem struct {
+	DrugID string `json:"DrugID"`
+	Type   string `json:"Type"`
+	Note   string `json:"Note"`
+}
+
+// TransferDiscrepancy is the receiver's report stored on the ledger for a partially accepted transfer
+type TransferDiscrepancy struct {
+	TransferID      string            `json:"TransferID"`
+	SenderID        string            `json:"SenderID"`
+	ReceiverID      string            `json:"ReceiverID"`
+	AcceptedDrugsID []string          `json:"AcceptedDrugsID"`
+	Items           []DiscrepancyItem `json:"Items"`
+	ReportDate      time.Time         `json:"ReportDate"`
+}
@@ -1,8 +1,25 @@
 package response
 
+// ErrorInfo describes a failed request. It is also the error handlers return to the central error handler.
 type ErrorInfo struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code      int          `json:"code"`                // HTTP status
+	ErrorCode string       `json:"errorCode,omitempty"` // Stable machine-readable code, e.g. "NOT_FOUND"
+	Message   string       `json:"message"`
+	Fields    []FieldError `json:"fields,omitempty"`    // Set when the request failed validation
+	RequestID string       `json:"requestId,omitempty"` // ID of the request, as in the X-Request-ID response header
+}
+
+// FieldError is a validation problem with one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`   // JSON path of the field, e.g. "Items[0].Quantity"
+	Rule    string `json:"rule"`    // Validation rule that failed, e.g. "required"
+	Message string `json:"message"` // Human-readable description of the problem
+}
+
+// BaseResponse is the envelope of a response without a value, such as an error.
+type BaseResponse struct {
+	Success bool       `json:"success"`
+	Error   *ErrorInfo `json:"error,omitempty"`
 }
 
 type BaseValueResponse[T any] struct {
@@ -14,5 +31,13 @@ type BaseValueResponse[T any] struct {
 type BaseListResponse[T any] struct {
 	Success bool       `json:"success"`
 	List    []*T       `json:"list"`
+	Page    *Page      `json:"page,omitempty"` // Set when a page of the list was requested
 	Error   *ErrorInfo `json:"error,omitempty"`
 }
+
+// Page locates the items of a list response within the whole list.
+type Page struct {
+	Offset int `json:"offset"` // Index of the first item of the page
+	Limit  int `json:"limit"`  // Maximum number of items in the page
+	Total  int `json:"total"`  // Number of items in the whole list
+}
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Stable error codes. Clients should branch on these rather than on messages, which may change.
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+	CodeConflict             = "CONFLICT"
+	CodeRequestTooLarge      = "REQUEST_TOO_LARGE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeTooManyRequests      = "TOO_MANY_REQUESTS"
+	CodeInternal             = "INTERNAL_ERROR"
+	CodeUnavailable          = "SERVICE_UNAVAILABLE"
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodeRequestTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// DefaultErrorCode returns the stable error code of an HTTP status.
+func DefaultErrorCode(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// NewError creates an error with an HTTP status and a formatted message.
+func NewError(code int, format string, args ...any) *ErrorInfo {
+	return &ErrorInfo{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ErrorInfo) Error() string {
+	return e.Message
+}
+
+// Problem is an RFC 7807 problem details object, with the stable error code, validation problems
+// and request ID as extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+}
+
+// ProblemTypeBase prefixes the error code, in lower case, to form the problem type URI, e.g. "urn:medtrace:problem:not-found".
+const ProblemTypeBase = "urn:medtrace:problem:"
+
+// ToProblem converts an error to problem details for the request path instance.
+func (e *ErrorInfo) ToProblem(instance string) Problem {
+	code := e.ErrorCode
+	if code == "" {
+		code = DefaultErrorCode(e.Code)
+	}
+	return Problem{
+		Type:      ProblemTypeBase + strings.ToLower(strings.ReplaceAll(code, "_", "-")),
+		Title:     http.StatusText(e.Code),
+		Status:    e.Code,
+		Detail:    e.Message,
+		Instance:  instance,
+		Code:      code,
+		Errors:    e.Fields,
+		RequestID: e.RequestID,
+	}
+}
@@ -42,3 +42,13 @@ func ErrorListResponse[T any](code int, format string, args ...any) BaseListResp
 		},
 	}
 }
+
+// PageListResponse cuts a successful list response to the page of at most limit items starting at offset.
+func PageListResponse[T any](resp BaseListResponse[T], offset, limit int) BaseListResponse[T] {
+	total := len(resp.List)
+	start := min(offset, total)
+	end := min(start+limit, total)
+	resp.List = resp.List[start:end]
+	resp.Page = &Page{Offset: offset, Limit: limit, Total: total}
+	return resp
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
@@ -29,7 +30,7 @@ func (s *BatchService) CreateBatch(contract *client.Contract, ctx context.Contex
 		return response.ErrorValueResponse[entity.Batch](500, "Failed to marshal request: %v", err)
 	}
 
-	resp, err := contract.SubmitTransaction("CreateBatch", string(reqJSON))
+	resp, err := fabric.Submit(ctx, contract, "CreateBatch", string(reqJSON))
 	if err != nil {
 		return response.ErrorValueResponse[entity.Batch](500, "Failed to submit transaction to Fabric: %v", err)
 	}
@@ -44,7 +45,7 @@ func (s *BatchService) CreateBatch(contract *client.Contract, ctx context.Contex
 
 // GetAllBatches retrieves all batches from the ledger using the provided contract.
 func (s *BatchService) GetAllBatches(contract *client.Contract, ctx context.Context) response.BaseListResponse[entity.Batch] {
-	resp, err := contract.EvaluateTransaction("GetAllBatches")
+	resp, err := fabric.Evaluate(ctx, contract, "GetAllBatches")
 	if err != nil {
 		return response.ErrorListResponse[entity.Batch](500, "Failed to evaluate transaction: %v", err)
 	}
@@ -69,7 +70,7 @@ func (s *BatchService) UpdateBatch(contract *client.Contract, ctx context.Contex
 		return response.ErrorValueResponse[entity.Batch](500, "Failed to marshal request: %v", err)
 	}
 
-	resp, err := contract.SubmitTransaction("UpdateBatch", batchID, string(reqJSON))
+	resp, err := fabric.Submit(ctx, contract, "UpdateBatch", batchID, string(reqJSON))
 	if err != nil {
 		return response.ErrorValueResponse[entity.Batch](500, "Failed to submit transaction to Fabric: %v", err)
 	}
@@ -84,7 +85,7 @@ func (s *BatchService) UpdateBatch(contract *client.Contract, ctx context.Contex
 
 // GetBatchByID retrieves a specific batch by ID from the ledger using the provided contract.
 func (s *BatchService) GetBatchByID(contract *client.Contract, ctx context.Context, batchID string) response.BaseValueResponse[entity.Batch] {
-	resultBytes, err := contract.EvaluateTransaction("GetBatch", batchID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetBatch", batchID)
 	if err != nil {
 		return response.ErrorValueResponse[entity.Batch](500, "Failed to evaluate GetBatch transaction: %v", err)
 	}
@@ -102,7 +103,7 @@ func (s *BatchService) GetBatchByID(contract *client.Contract, ctx context.Conte
 
 // BatchExists checks if a batch exists on the ledger using the provided contract.
 func (s *BatchService) BatchExists(contract *client.Contract, ctx context.Context, batchID string) response.BaseValueResponse[bool] {
-	resultBytes, err := contract.EvaluateTransaction("BatchExists", batchID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "BatchExists", batchID)
 	if err != nil {
 		return response.ErrorValueResponse[bool](500, "Failed to evaluate BatchExists transaction: %v", err)
 	}
@@ -113,3 +114,37 @@ func (s *BatchService) BatchExists(contract *client.Contract, ctx context.Contex
 	}
 	return response.SuccessValueResponse(exists)
 }
+
+// GetHistoryBatch retrieves the ledger key history of a batch as returned by the chaincode.
+func (s *BatchService) GetHistoryBatch(contract *client.Contract, ctx context.Context, batchID string) response.BaseListResponse[entity.HistoryBatch] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetHistoryBatch", batchID)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryBatch](500, "Failed to evaluate GetHistoryBatch transaction: %v", err)
+	}
+
+	var records []entity.HistoryBatch
+	err = json.Unmarshal(resultBytes, &records)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryBatch](500, "Failed to unmarshal history batch data for GetHistoryBatch: %v", err)
+	}
+
+	recordsPtrs := make([]*entity.HistoryBatch, len(records))
+	for i := range records {
+		recordsPtrs[i] = &records[i]
+	}
+
+	return response.SuccessListResponse(recordsPtrs)
+}
+
+// GetBatchChanges returns the fields each transaction in the key history of a batch changed.
+func (s *BatchService) GetBatchChanges(contract *client.Contract, ctx context.Context, batchID string) response.BaseListResponse[entity.HistoryChange] {
+	historyResp := s.GetHistoryBatch(contract, ctx, batchID)
+	if !historyResp.Success {
+		return response.BaseListResponse[entity.HistoryChange]{Success: false, Error: historyResp.Error}
+	}
+	versions := make([]historyVersion, len(historyResp.List))
+	for i, rec := range historyResp.List {
+		versions[i] = historyVersion{TxID: rec.TxID, Timestamp: rec.Timestamp, IsDelete: rec.IsDelete, Record: rec.Batch}
+	}
+	return diffHistory(versions)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+// DefaultCertExpiryWarning is used if CERT_EXPIRY_WARNING env var is not set.
+const DefaultCertExpiryWarning = 30 * 24 * time.Hour
+
+// CertificateService reports how close the identity and TLS CA certificates of the configured organizations
+// are to expiring. An expired enrollment certificate makes every request of its organization fail.
+type CertificateService struct {
+	// Warning is how long before NotAfter a certificate is reported as expiring.
+	Warning time.Duration
+}
+
+// NewCertificateService creates a new CertificateService.
+func NewCertificateService(warning time.Duration) *CertificateService {
+	return &CertificateService{Warning: warning}
+}
+
+// GetCertificates reads the certificates of every configured organization, ordered by organization, and records
+// their expiry as metrics.
+func (s *CertificateService) GetCertificates() response.BaseListResponse[entity.CertificateStatus] {
+	var statuses []*entity.CertificateStatus
+	for _, orgID := range config.GetOrgNames() {
+		orgCfg, err := config.GetOrgConfig(orgID)
+		if err != nil {
+			return response.ErrorListResponse[entity.CertificateStatus](500, "Failed to get configuration of organization %s: %v", orgID, err)
+		}
+		for _, status := range s.orgCertificates(orgID, orgCfg.Certificates(), time.Now()) {
+			statuses = append(statuses, &status)
+		}
+	}
+	return response.SuccessListResponse(statuses)
+}
+
+// orgCertificates describes the certificates of an organization as of now and records their expiry as metrics.
+func (s *CertificateService) orgCertificates(orgID string, certificates []fabric.Certificate, now time.Time) []entity.CertificateStatus {
+	statuses := make([]entity.CertificateStatus, 0, len(certificates))
+	for _, cert := range certificates {
+		status := entity.CertificateStatus{OrgID: orgID, Kind: cert.Kind, Path: cert.Path}
+		if cert.Err != nil {
+			status.Status = entity.CertUnreadable
+			status.Error = cert.Err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		status.Subject = cert.Subject
+		status.NotAfter = cert.NotAfter
+		status.DaysLeft = int(math.Floor(cert.NotAfter.Sub(now).Hours() / 24))
+		switch {
+		case now.After(cert.NotAfter):
+			status.Status = entity.CertExpired
+		case now.Add(s.Warning).After(cert.NotAfter):
+			status.Status = entity.CertExpiring
+		default:
+			status.Status = entity.CertValid
+		}
+		metrics.ObserveCertificate(orgID, cert.Kind, cert.NotAfter, status.Status != entity.CertValid)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
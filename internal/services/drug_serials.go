@@ -0,0 +1,108 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+)
+
+// MaxBulkDrugs caps the number of drugs a single bulk serialization request may create.
+const MaxBulkDrugs = 100000
+
+// MaxSerialWidth caps the zero-padded width of generated serial numbers, the 20 characters of a GS1 serial.
+const MaxSerialWidth = 20
+
+var seqPlaceholder = regexp.MustCompile(`\{seq(?::(\d+))?\}`)
+
+// ExpandDrugIDs returns the drug IDs described by a bulk serialization request, in order.
+// It rejects requests that set more or fewer than one ID source, exceed MaxBulkDrugs or contain duplicates.
+func ExpandDrugIDs(req *drug.BulkCreateDrugRequest) ([]string, error) {
+	sources := 0
+	if len(req.DrugsID) > 0 {
+		sources++
+	}
+	if req.Range != nil {
+		sources++
+	}
+	if req.Pattern != nil {
+		sources++
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("exactly one of drugsID, range or pattern is required")
+	}
+
+	var ids []string
+	switch {
+	case req.Range != nil:
+		r := req.Range
+		if r.Width < 0 || r.Width > MaxSerialWidth {
+			return nil, fmt.Errorf("range width must be between 0 and %d", MaxSerialWidth)
+		}
+		if r.End < r.Start {
+			return nil, fmt.Errorf("range end %d is before start %d", r.End, r.Start)
+		}
+		// The span is taken as unsigned so that ranges wider than MaxInt64 cannot wrap around the limit.
+		span := uint64(r.End) - uint64(r.Start)
+		if span >= MaxBulkDrugs {
+			return nil, fmt.Errorf("range covers more than %d drugs", MaxBulkDrugs)
+		}
+		count := int64(span) + 1
+		ids = make([]string, 0, count)
+		for i := int64(0); i < count; i++ {
+			ids = append(ids, r.Prefix+fmt.Sprintf("%0*d", r.Width, r.Start+i))
+		}
+	case req.Pattern != nil:
+		p := req.Pattern
+		if !seqPlaceholder.MatchString(p.Template) {
+			return nil, fmt.Errorf("pattern template must contain {seq} or {seq:N}")
+		}
+		if p.Count <= 0 || p.Count > MaxBulkDrugs {
+			return nil, fmt.Errorf("pattern count must be between 1 and %d", MaxBulkDrugs)
+		}
+		if p.Start > math.MaxInt64-int64(p.Count) {
+			return nil, fmt.Errorf("pattern sequence starting at %d overflows", p.Start)
+		}
+		base := strings.ReplaceAll(p.Template, "{batch}", req.BatchID)
+		// Widths are checked before any ID is generated: each ID holds every placeholder at its full width.
+		widths := map[string]int{}
+		for _, m := range seqPlaceholder.FindAllStringSubmatch(base, -1) {
+			width := 0
+			if m[1] != "" {
+				var err error
+				width, err = strconv.Atoi(m[1])
+				if err != nil || width > MaxSerialWidth {
+					return nil, fmt.Errorf("pattern placeholder %s is wider than %d digits", m[0], MaxSerialWidth)
+				}
+			}
+			widths[m[0]] = width
+		}
+		ids = make([]string, 0, p.Count)
+		for i := 0; i < p.Count; i++ {
+			seq := p.Start + int64(i)
+			ids = append(ids, seqPlaceholder.ReplaceAllStringFunc(base, func(m string) string {
+				return fmt.Sprintf("%0*d", widths[m], seq)
+			}))
+		}
+	default:
+		if len(req.DrugsID) > MaxBulkDrugs {
+			return nil, fmt.Errorf("%d drugs requested, the maximum is %d", len(req.DrugsID), MaxBulkDrugs)
+		}
+		ids = req.DrugsID
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("drug IDs cannot be empty")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("drug ID %s is generated more than once", id)
+		}
+		seen[id] = true
+	}
+	return ids, nil
+}
@@ -1,9 +1,16 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
 
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
@@ -23,20 +30,68 @@ func NewDrugService() *DrugService {
 }
 
 // CreateDrug calls the CreateDrug chaincode function using the provided contract.
+// A request with a GTIN and serial number creates the drug under its SGTIN ID; a DrugID given as well must match it.
 func (s *DrugService) CreateDrug(contract *client.Contract, ctx context.Context, req *drug.CreateDrugRequest) response.BaseValueResponse[string] {
+	if req.GTIN != "" || req.SerialNumber != "" {
+		sgtin, err := gs1.NewSGTIN(req.GTIN, req.SerialNumber)
+		if err != nil {
+			return response.ErrorValueResponse[string](400, "Invalid GS1 identity: %v", err)
+		}
+		if req.DrugID == "" {
+			req.DrugID = sgtin.String()
+		} else if req.DrugID != sgtin.String() {
+			return response.ErrorValueResponse[string](400, "DrugID does not match the SGTIN %s", sgtin)
+		}
+	}
+	if req.OwnerID == "" || req.BatchID == "" || req.DrugID == "" {
+		return response.ErrorValueResponse[string](400, "OwnerID, BatchID, and DrugID are required")
+	}
+
 	// Chaincode CreateDrug returns drugID string, not the full drug object directly from that call.
-	resultBytes, err := contract.SubmitTransaction("CreateDrug", req.OwnerID, req.BatchID, req.DrugID)
+	resultBytes, err := fabric.Submit(ctx, contract, "CreateDrug", req.OwnerID, req.BatchID, req.DrugID)
 	if err != nil {
 		return response.ErrorValueResponse[string](500, "Failed to submit CreateDrug transaction: %v", err)
 	}
 	// The result from chaincode is the drugID string
 	drugID := string(resultBytes)
+	metrics.DrugsSerialized(1)
 	return response.SuccessValueResponse(drugID)
 }
 
+// CreateDrugs calls the CreateDrugs chaincode function using the provided contract.
+// All drugs are created in a single ledger transaction, so callers should keep the list bounded.
+func (s *DrugService) CreateDrugs(contract *client.Contract, ctx context.Context, ownerID, batchID string, drugIDs []string) response.BaseListResponse[string] {
+	ccReqJSON, err := json.Marshal(map[string]interface{}{
+		"OwnerID": ownerID,
+		"BatchID": batchID,
+		"DrugsID": drugIDs,
+	})
+	if err != nil {
+		return response.ErrorListResponse[string](500, "Failed to marshal CreateDrugs request: %v", err)
+	}
+
+	resultBytes, err := fabric.Submit(ctx, contract, "CreateDrugs", string(ccReqJSON))
+	if err != nil {
+		return response.ErrorListResponse[string](500, "Failed to submit CreateDrugs transaction: %v", err)
+	}
+
+	var created []string
+	err = json.Unmarshal(resultBytes, &created)
+	if err != nil {
+		return response.ErrorListResponse[string](500, "Failed to unmarshal CreateDrugs result: %v", err)
+	}
+
+	createdPtrs := make([]*string, len(created))
+	for i := range created {
+		createdPtrs[i] = &created[i]
+	}
+	metrics.DrugsSerialized(len(created))
+	return response.SuccessListResponse(createdPtrs)
+}
+
 // GetDrug calls the GetDrug chaincode function using the provided contract.
 func (s *DrugService) GetDrug(contract *client.Contract, ctx context.Context, drugID string) response.BaseValueResponse[entity.Drug] {
-	resultBytes, err := contract.EvaluateTransaction("GetDrug", drugID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetDrug", drugID)
 	if err != nil {
 		return response.ErrorValueResponse[entity.Drug](500, "Failed to evaluate GetDrug transaction: %v", err)
 	}
@@ -54,7 +109,7 @@ func (s *DrugService) GetDrug(contract *client.Contract, ctx context.Context, dr
 
 // GetMyDrugs calls the GetMyDrug chaincode function using the provided contract.
 func (s *DrugService) GetMyDrugs(contract *client.Contract, ctx context.Context) response.BaseListResponse[entity.Drug] {
-	resultBytes, err := contract.EvaluateTransaction("GetMyDrug")
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetMyDrug")
 	if err != nil {
 		return response.ErrorListResponse[entity.Drug](500, "Failed to evaluate GetMyDrug transaction: %v", err)
 	}
@@ -76,7 +131,7 @@ func (s *DrugService) GetMyDrugs(contract *client.Contract, ctx context.Context)
 
 // GetDrugByBatch calls the GetDrugByBatch chaincode function using the provided contract.
 func (s *DrugService) GetDrugByBatch(contract *client.Contract, ctx context.Context, batchID string) response.BaseListResponse[entity.Drug] {
-	resultBytes, err := contract.EvaluateTransaction("GetDrugByBatch", batchID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetDrugByBatch", batchID)
 	if err != nil {
 		return response.ErrorListResponse[entity.Drug](500, "Failed to evaluate GetDrugByBatch transaction: %v", err)
 	}
@@ -100,7 +155,7 @@ func (s *DrugService) GetDrugByBatch(contract *client.Contract, ctx context.Cont
 }
 
 func (s *DrugService) GetDrugByTransfer(contract *client.Contract, ctx context.Context, transferID string) response.BaseListResponse[entity.Drug] {
-	resultBytes, err := contract.EvaluateTransaction("GetDrugByTransfer", transferID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetDrugByTransfer", transferID)
 	if err != nil {
 		return response.ErrorListResponse[entity.Drug](500, "Failed to evaluate GetDrugByTransfer: %v", err)
 	}
@@ -124,7 +179,7 @@ func (s *DrugService) GetDrugByTransfer(contract *client.Contract, ctx context.C
 
 // GetMyAvailDrugs calls the GetMyAvailDrugs chaincode function using the provided contract.
 func (s *DrugService) GetMyAvailDrugs(contract *client.Contract, ctx context.Context) response.BaseListResponse[entity.Drug] {
-	resultBytes, err := contract.EvaluateTransaction("GetMyAvailDrugs")
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetMyAvailDrugs")
 	if err != nil {
 		return response.ErrorListResponse[entity.Drug](500, "Failed to evaluate GetMyAvailDrugs transaction: %v", err)
 	}
@@ -143,8 +198,68 @@ func (s *DrugService) GetMyAvailDrugs(contract *client.Contract, ctx context.Con
 	return response.SuccessListResponse(drugsPtrs)
 }
 
+// StreamMyDrugs is GetMyDrugs for callers that handle the drugs one at a time; see streamDrugs.
+func (s *DrugService) StreamMyDrugs(contract *client.Contract, ctx context.Context) iter.Seq2[*entity.Drug, *response.ErrorInfo] {
+	return streamDrugs(contract, ctx, "GetMyDrug")
+}
+
+// StreamMyAvailDrugs is GetMyAvailDrugs for callers that handle the drugs one at a time; see streamDrugs.
+func (s *DrugService) StreamMyAvailDrugs(contract *client.Contract, ctx context.Context) iter.Seq2[*entity.Drug, *response.ErrorInfo] {
+	return streamDrugs(contract, ctx, "GetMyAvailDrugs")
+}
+
+// StreamDrugByBatch is GetDrugByBatch for callers that handle the drugs one at a time; see streamDrugs.
+func (s *DrugService) StreamDrugByBatch(contract *client.Contract, ctx context.Context, batchID string) iter.Seq2[*entity.Drug, *response.ErrorInfo] {
+	return streamDrugs(contract, ctx, "GetDrugByBatch", batchID)
+}
+
+// StreamDrugByTransfer is GetDrugByTransfer for callers that handle the drugs one at a time; see streamDrugs.
+func (s *DrugService) StreamDrugByTransfer(contract *client.Contract, ctx context.Context, transferID string) iter.Seq2[*entity.Drug, *response.ErrorInfo] {
+	return streamDrugs(contract, ctx, "GetDrugByTransfer", transferID)
+}
+
+// streamDrugs evaluates a chaincode function returning a list of drugs and yields each drug as soon as it is
+// decoded from the result, so the decoded list is never held as a whole. The chaincode still returns the list in
+// one response. It yields a nil drug with the error when the evaluation or decoding fails, and then stops.
+func streamDrugs(contract *client.Contract, ctx context.Context, function string, args ...string) iter.Seq2[*entity.Drug, *response.ErrorInfo] {
+	return func(yield func(*entity.Drug, *response.ErrorInfo) bool) {
+		resultBytes, err := fabric.Evaluate(ctx, contract, function, args...)
+		if err != nil {
+			yield(nil, response.NewError(500, "Failed to evaluate %s transaction: %v", function, err))
+			return
+		}
+		fail := func(err error) {
+			yield(nil, response.NewError(500, "Failed to unmarshal drugs data for %s: %v", function, err))
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(resultBytes))
+		start, err := dec.Token()
+		if err == io.EOF || (err == nil && start == nil) {
+			return // Empty result or JSON null: no drugs
+		}
+		if err != nil {
+			fail(err)
+			return
+		}
+		if start != json.Delim('[') {
+			fail(fmt.Errorf("expected a JSON array, got %v", start))
+			return
+		}
+		for dec.More() {
+			var d entity.Drug
+			if err := dec.Decode(&d); err != nil {
+				fail(err)
+				return
+			}
+			if !yield(&d, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (s *DrugService) GetHistoryDrug(contract *client.Contract, ctx context.Context, drugID string) response.BaseListResponse[entity.HistoryDrug] {
-	resultBytes, err := contract.EvaluateTransaction("GetHistoryDrug", drugID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetHistoryDrug", drugID)
 	if err != nil {
 		return response.ErrorListResponse[entity.HistoryDrug](500, "Failed to evaluate GetHistoryDrug transaction: %v", err)
 	}
@@ -162,3 +277,16 @@ func (s *DrugService) GetHistoryDrug(contract *client.Contract, ctx context.Cont
 
 	return response.SuccessListResponse(recordsPtrs)
 }
+
+// GetDrugChanges returns the fields each transaction in the key history of a drug changed.
+func (s *DrugService) GetDrugChanges(contract *client.Contract, ctx context.Context, drugID string) response.BaseListResponse[entity.HistoryChange] {
+	historyResp := s.GetHistoryDrug(contract, ctx, drugID)
+	if !historyResp.Success {
+		return response.BaseListResponse[entity.HistoryChange]{Success: false, Error: historyResp.Error}
+	}
+	versions := make([]historyVersion, len(historyResp.List))
+	for i, rec := range historyResp.List {
+		versions[i] = historyVersion{TxID: rec.TxID, Timestamp: rec.Timestamp, IsDelete: rec.IsDelete, Record: rec.Drug}
+	}
+	return diffHistory(versions)
+}
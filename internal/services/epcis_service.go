@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/epcis"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/validation"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// captureChunkSize bounds the drugs created per ledger transaction when capturing commissioning events.
+const captureChunkSize = 500
+
+// captureValidator checks planned submissions with the same rules the REST API applies when binding requests.
+var captureValidator = validation.New()
+
+// EPCISService exports ledger history as EPCIS 2.0 events and captures EPCIS documents from partners.
+type EPCISService struct {
+	Drugs     *DrugService
+	Batches   *BatchService
+	Transfers *TransferService
+}
+
+// NewEPCISService creates a new EPCISService.
+func NewEPCISService(drugs *DrugService, batches *BatchService, transfers *TransferService) *EPCISService {
+	return &EPCISService{Drugs: drugs, Batches: batches, Transfers: transfers}
+}
+
+// ExportDrug returns the events of a single drug, derived from its key history.
+func (s *EPCISService) ExportDrug(contract *client.Contract, ctx context.Context, drugID string) response.BaseValueResponse[epcis.Document] {
+	records, errInfo := loadLedgerRecords(s.Drugs, s.Batches, s.Transfers, contract, ctx, []string{drugID}, nil)
+	if errInfo != nil {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: errInfo}
+	}
+	if len(records.Histories[drugID]) == 0 {
+		return response.ErrorValueResponse[epcis.Document](404, "Drug %s not found", drugID)
+	}
+	return response.SuccessValueResponse(epcis.NewDocument(epcis.Events(epcis.Records(records))))
+}
+
+// ExportBatch returns the events of a batch and of every drug in it.
+// It reads the key history of each drug, so exports of large batches take one ledger query per drug.
+func (s *EPCISService) ExportBatch(contract *client.Contract, ctx context.Context, batchID string) response.BaseValueResponse[epcis.Document] {
+	batchResp := s.Batches.GetBatchByID(contract, ctx, batchID)
+	if !batchResp.Success {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: batchResp.Error}
+	}
+	drugsResp := s.Drugs.GetDrugByBatch(contract, ctx, batchID)
+	if !drugsResp.Success {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: drugsResp.Error}
+	}
+
+	drugIDs := make([]string, 0, len(drugsResp.List))
+	for _, d := range drugsResp.List {
+		drugIDs = append(drugIDs, d.ID)
+	}
+	records, errInfo := loadLedgerRecords(s.Drugs, s.Batches, s.Transfers, contract, ctx, drugIDs, map[string]entity.Batch{batchID: *batchResp.Value})
+	if errInfo != nil {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: errInfo}
+	}
+	return response.SuccessValueResponse(epcis.NewDocument(epcis.Events(epcis.Records(records))))
+}
+
+// ExportTransfer returns the shipping, receiving and voiding events of a transfer for the drugs still linked to it.
+func (s *EPCISService) ExportTransfer(contract *client.Contract, ctx context.Context, transferID string) response.BaseValueResponse[epcis.Document] {
+	transferResp := s.Transfers.GetTransfer(contract, ctx, transferID)
+	if !transferResp.Success {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: transferResp.Error}
+	}
+	drugsResp := s.Drugs.GetDrugByTransfer(contract, ctx, transferID)
+	if !drugsResp.Success {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: drugsResp.Error}
+	}
+
+	drugIDs := make([]string, 0, len(drugsResp.List))
+	for _, d := range drugsResp.List {
+		drugIDs = append(drugIDs, d.ID)
+	}
+	records, errInfo := loadLedgerRecords(s.Drugs, s.Batches, s.Transfers, contract, ctx, drugIDs, nil)
+	if errInfo != nil {
+		return response.BaseValueResponse[epcis.Document]{Success: false, Error: errInfo}
+	}
+	records.Transfers[transferID] = *transferResp.Value
+	return response.SuccessValueResponse(epcis.NewDocument(epcis.ForTransfer(epcis.Events(epcis.Records(records)), transferID)))
+}
+
+// Capture applies the events of a partner's EPCIS document as ledger submissions by orgID and reports the outcome
+// of every event. Events are applied in order; a failed event does not stop later ones.
+// Batches that already exist and drugs already on the ledger are skipped, so a document can be captured again.
+func (s *EPCISService) Capture(contract *client.Contract, ctx context.Context, orgID string, doc epcis.Document) response.BaseListResponse[entity.EPCISCaptureResult] {
+	plans := epcis.PlanCapture(doc, orgID)
+	for i := range plans {
+		if plans[i].Err != nil {
+			continue
+		}
+		for _, sub := range plans[i].Submissions {
+			if err := validateSubmission(sub); err != nil {
+				plans[i].Err = err
+				break
+			}
+		}
+	}
+	results := make([]*entity.EPCISCaptureResult, 0, len(plans))
+	for _, p := range plans {
+		result := &entity.EPCISCaptureResult{
+			Index:   p.Index,
+			EventID: p.Event.EventID,
+			Type:    p.Event.Type,
+			BizStep: p.Event.BizStep,
+			Status:  entity.CaptureSubmitted,
+		}
+		switch {
+		case p.Err != nil:
+			result.Status = entity.CaptureFailed
+			result.Message = p.Err.Error()
+		case p.Skip != "":
+			result.Status = entity.CaptureSkipped
+			result.Message = p.Skip
+		default:
+			for _, sub := range p.Submissions {
+				done, err := s.submit(contract, ctx, orgID, sub)
+				if done != "" {
+					result.Submissions = append(result.Submissions, done)
+				}
+				if err != nil {
+					result.Status = entity.CaptureFailed
+					result.Message = err.Error()
+					break
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return response.SuccessListResponse(results)
+}
+
+// validateSubmission applies the rules of the REST requests to a planned ledger write, so captured events cannot
+// submit what the API itself would reject.
+func validateSubmission(sub epcis.Submission) error {
+	switch {
+	case sub.Batch != nil:
+		return captureValidator.Validate(sub.Batch)
+	case sub.Drugs != nil:
+		if err := captureValidator.Validate(sub.Drugs); err != nil {
+			return err
+		}
+		_, err := ExpandDrugIDs(sub.Drugs)
+		return err
+	case sub.Transfer != nil:
+		if len(sub.Transfer.DrugsID) > MaxBulkDrugs {
+			return fmt.Errorf("%d drugs shipped, the maximum is %d", len(sub.Transfer.DrugsID), MaxBulkDrugs)
+		}
+		return captureValidator.Validate(sub.Transfer)
+	}
+	return nil
+}
+
+// submit performs one planned ledger write and describes what was written.
+func (s *EPCISService) submit(contract *client.Contract, ctx context.Context, orgID string, sub epcis.Submission) (string, error) {
+	switch {
+	case sub.Batch != nil:
+		existsResp := s.Batches.BatchExists(contract, ctx, sub.Batch.ID)
+		if !existsResp.Success {
+			return "", fmt.Errorf("%s", existsResp.Error.Message)
+		}
+		if *existsResp.Value {
+			return "", nil
+		}
+		batchResp := s.Batches.CreateBatch(contract, ctx, sub.Batch)
+		if !batchResp.Success {
+			return "", fmt.Errorf("%s", batchResp.Error.Message)
+		}
+		return "CreateBatch " + batchResp.Value.ID, nil
+
+	case sub.Drugs != nil:
+		existingResp := s.Drugs.GetDrugByBatch(contract, ctx, sub.Drugs.BatchID)
+		if !existingResp.Success {
+			return "", fmt.Errorf("%s", existingResp.Error.Message)
+		}
+		existing := make(map[string]bool, len(existingResp.List))
+		for _, d := range existingResp.List {
+			existing[d.ID] = true
+		}
+		var pending []string
+		for _, id := range sub.Drugs.DrugsID {
+			if !existing[id] {
+				pending = append(pending, id)
+			}
+		}
+
+		created := 0
+		for start := 0; start < len(pending); start += captureChunkSize {
+			end := min(start+captureChunkSize, len(pending))
+			createResp := s.Drugs.CreateDrugs(contract, ctx, sub.Drugs.OwnerID, sub.Drugs.BatchID, pending[start:end])
+			if !createResp.Success {
+				return fmt.Sprintf("CreateDrugs %d in batch %s", created, sub.Drugs.BatchID), fmt.Errorf("%s", createResp.Error.Message)
+			}
+			created += len(createResp.List)
+		}
+		if created == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("CreateDrugs %d in batch %s", created, sub.Drugs.BatchID), nil
+
+	case sub.Transfer != nil:
+		transferResp := s.Transfers.CreateTransfer(contract, ctx, orgID, sub.Transfer)
+		if !transferResp.Success {
+			return "", fmt.Errorf("%s", transferResp.Error.Message)
+		}
+		return "CreateTransfer " + transferResp.Value.ID, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// Defaults of the readiness check. Probes hit /readyz every few seconds without authentication, and each check
+// opens a gateway per organization, so a report is reused for HealthCacheTTL.
+const (
+	DefaultHealthCacheTTL     = 5 * time.Second
+	DefaultHealthCheckTimeout = 10 * time.Second
+)
+
+// NetworkFunc connects to the channel as an organization. The returned function closes its gateway.
+type NetworkFunc func(orgID string) (*client.Network, func() error, error)
+
+// HealthService checks that the API can act as each configured organization on the Fabric network.
+type HealthService struct {
+	// Connect opens the channel as each organization to query its gateway peer.
+	Connect NetworkFunc
+	// Certificates reports the expiry of each organization's certificates.
+	Certificates *CertificateService
+	// CacheTTL is how long a report is returned by Check before the organizations are checked again.
+	CacheTTL time.Duration
+	// Timeout bounds a whole check; organizations that have not answered by then are reported down.
+	Timeout time.Duration
+
+	mu     sync.Mutex // Held during a check, so concurrent probes share it
+	report entity.HealthReport
+}
+
+// NewHealthService creates a new HealthService.
+func NewHealthService(connect NetworkFunc, certificates *CertificateService) *HealthService {
+	return &HealthService{
+		Connect:      connect,
+		Certificates: certificates,
+		CacheTTL:     DefaultHealthCacheTTL,
+		Timeout:      DefaultHealthCheckTimeout,
+	}
+}
+
+// Check checks every configured organization concurrently, or returns the report of a check made less than
+// CacheTTL ago. An organization is up when its identity certificate, private key and TLS CA certificate load and
+// its gateway peer answers a query for the channel's chain info within Timeout.
+func (s *HealthService) Check(ctx context.Context) entity.HealthReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.report.CheckedAt.IsZero() && time.Since(s.report.CheckedAt) < s.CacheTTL {
+		return s.report
+	}
+	s.report = s.check(ctx)
+	return s.report
+}
+
+func (s *HealthService) check(ctx context.Context) entity.HealthReport {
+	orgIDs := config.GetOrgNames()
+	report := entity.HealthReport{
+		Status:        entity.HealthUp,
+		CheckedAt:     time.Now(),
+		Organizations: make([]entity.OrgHealth, len(orgIDs)),
+	}
+
+	// The report is shared with later callers, so it is not cut short when this caller goes away. Gateway
+	// connections and Fabric queries don't stop with ctx either, so the deadline is enforced here: late
+	// organizations finish in the background and their results are dropped.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.Timeout)
+	defer cancel()
+	type result struct {
+		index  int
+		health entity.OrgHealth
+	}
+	results := make(chan result, len(orgIDs))
+	for i, orgID := range orgIDs {
+		go func() {
+			results <- result{i, s.checkOrganization(ctx, orgID)}
+		}()
+	}
+	done := make([]bool, len(orgIDs))
+collect:
+	for range orgIDs {
+		select {
+		case r := <-results:
+			report.Organizations[r.index] = r.health
+			done[r.index] = true
+		case <-ctx.Done():
+			break collect
+		}
+	}
+	for i, orgID := range orgIDs {
+		if !done[i] {
+			report.Organizations[i] = entity.OrgHealth{
+				OrgID:  orgID,
+				Status: entity.HealthDown,
+				Error:  fmt.Sprintf("Health check did not finish within %s", s.Timeout),
+			}
+		}
+	}
+
+	for _, org := range report.Organizations {
+		if org.Status != entity.HealthUp {
+			report.Status = entity.HealthDown
+		}
+	}
+	return report
+}
+
+func (s *HealthService) checkOrganization(ctx context.Context, orgID string) entity.OrgHealth {
+	health := entity.OrgHealth{OrgID: orgID, Status: entity.HealthDown}
+	if commit, ok := fabric.LastCommit(orgID); ok {
+		health.LastCommit = &entity.CommitRecord{TxID: commit.TxID, BlockNumber: commit.BlockNumber, CommittedAt: commit.Time}
+	}
+
+	orgCfg, err := config.GetOrgConfig(orgID)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.PeerEndpoint = orgCfg.PeerEndpoint
+
+	certificates, err := orgCfg.CheckCredentials()
+	health.Certificates = s.Certificates.orgCertificates(orgID, certificates, time.Now())
+	if err != nil {
+		health.Error = fmt.Sprintf("Failed to load crypto material: %v", err)
+		return health
+	}
+
+	start := time.Now()
+	height, err := s.chainHeight(ctx, orgID)
+	health.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Error = fmt.Sprintf("Gateway peer did not answer: %v", err)
+		return health
+	}
+	health.BlockHeight = height
+	health.Status = entity.HealthUp
+	return health
+}
+
+// chainHeight queries the height of the channel's chain at the gateway peer of an organization through qscc,
+// which is cheap and does not depend on the MedTrace chaincode.
+func (s *HealthService) chainHeight(ctx context.Context, orgID string) (uint64, error) {
+	network, closeGateway, err := s.Connect(orgID)
+	if err != nil {
+		return 0, err
+	}
+	defer closeGateway()
+
+	infoBytes, err := fabric.Evaluate(ctx, network.GetContract(qsccName), "GetChainInfo", network.Name())
+	if err != nil {
+		return 0, err
+	}
+	var info common.BlockchainInfo
+	if err := proto.Unmarshal(infoBytes, &info); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal chain info: %w", err)
+	}
+	return info.GetHeight(), nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+// historyVersion is one record of a key history, whatever the type of the record.
+type historyVersion struct {
+	TxID      string
+	Timestamp time.Time
+	IsDelete  bool
+	Record    any
+}
+
+// diffHistory compares each version of a record with the one before it, oldest first, field by field
+// on their JSON form so the field names match what the API returns.
+func diffHistory(versions []historyVersion) response.BaseListResponse[entity.HistoryChange] {
+	sorted := make([]historyVersion, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	changes := make([]*entity.HistoryChange, 0, len(sorted))
+	var prev map[string]any
+	for _, v := range sorted {
+		var fields map[string]any
+		if !v.IsDelete {
+			data, err := json.Marshal(v.Record)
+			if err != nil {
+				return response.ErrorListResponse[entity.HistoryChange](500, "Failed to marshal version %s: %v", v.TxID, err)
+			}
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return response.ErrorListResponse[entity.HistoryChange](500, "Failed to read fields of version %s: %v", v.TxID, err)
+			}
+		}
+		changes = append(changes, &entity.HistoryChange{
+			TxID:      v.TxID,
+			Timestamp: v.Timestamp,
+			IsDelete:  v.IsDelete,
+			Changes:   diffFields(prev, fields),
+		})
+		prev = fields
+	}
+	return response.SuccessListResponse(changes)
+}
+
+// diffFields returns the fields that differ between before and after, sorted by name.
+func diffFields(before, after map[string]any) []entity.FieldChange {
+	names := make(map[string]bool, len(before)+len(after))
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	changes := []entity.FieldChange{}
+	for _, name := range sortedNames {
+		o, n := before[name], after[name]
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, entity.FieldChange{Field: name, Old: o, New: n})
+		}
+	}
+	return changes
+}
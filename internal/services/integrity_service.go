@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/pkg/verifier"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// qsccName is the system chaincode that answers ledger queries by transaction ID.
+const qsccName = "qscc"
+
+// rawHistoryRecord is a key history record as returned by the chaincode, with the record kept as written.
+type rawHistoryRecord struct {
+	Record    json.RawMessage `json:"record"`
+	TxId      string          `json:"txId"`
+	Timestamp time.Time       `json:"timestamp"`
+	IsDelete  bool            `json:"isDelete"`
+}
+
+// blockCacheSize is the number of transactions whose block proof is kept. Committed blocks never change,
+// so a cached proof stays valid; the cache only spares repeated qscc queries for popular histories.
+const blockCacheSize = 128
+
+// IntegrityService attaches ledger evidence to key histories so third parties can verify them.
+type IntegrityService struct {
+	mu     sync.Mutex
+	blocks map[string]*verifier.BlockProof // By transaction ID
+	order  []string                        // Transaction IDs of blocks, oldest first
+}
+
+// NewIntegrityService creates a new IntegrityService.
+func NewIntegrityService() *IntegrityService {
+	return &IntegrityService{blocks: map[string]*verifier.BlockProof{}}
+}
+
+// GetVerifiableHistoryDrug returns the key history of a drug with the signed envelope of every transaction in it
+// and the block holding it, read from the peer through qscc.
+func (s *IntegrityService) GetVerifiableHistoryDrug(network *client.Network, contract *client.Contract, ctx context.Context, drugID string) response.BaseValueResponse[entity.VerifiableHistory] {
+	return s.verifiableHistory(network, contract, ctx, "GetHistoryDrug", drugID)
+}
+
+func (s *IntegrityService) verifiableHistory(network *client.Network, contract *client.Contract, ctx context.Context, historyFunc, key string) response.BaseValueResponse[entity.VerifiableHistory] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, historyFunc, key)
+	if err != nil {
+		return response.ErrorValueResponse[entity.VerifiableHistory](500, "Failed to evaluate %s transaction: %v", historyFunc, err)
+	}
+	var records []rawHistoryRecord
+	if err := json.Unmarshal(resultBytes, &records); err != nil {
+		return response.ErrorValueResponse[entity.VerifiableHistory](500, "Failed to unmarshal history data for %s: %v", historyFunc, err)
+	}
+	if len(records) == 0 {
+		return response.ErrorValueResponse[entity.VerifiableHistory](404, "No history found for %s", key)
+	}
+
+	qscc := network.GetContract(qsccName)
+	history := entity.VerifiableHistory{
+		Channel:   network.Name(),
+		Chaincode: contract.ChaincodeName(),
+		Key:       key,
+		Entries:   make([]entity.VerifiableHistoryEntry, 0, len(records)),
+	}
+	for _, rec := range records {
+		proof, errInfo := s.proof(qscc, ctx, network.Name(), rec.TxId)
+		if errInfo != nil {
+			return response.BaseValueResponse[entity.VerifiableHistory]{Success: false, Error: errInfo}
+		}
+		record := rec.Record
+		if rec.IsDelete || len(record) == 0 {
+			record = json.RawMessage("null")
+		}
+		history.Entries = append(history.Entries, entity.VerifiableHistoryEntry{
+			TxID:      rec.TxId,
+			Timestamp: rec.Timestamp,
+			IsDelete:  rec.IsDelete,
+			Record:    record,
+			Proof:     proof,
+		})
+	}
+	return response.SuccessValueResponse(history)
+}
+
+// proof reads the block holding a transaction, with the transaction's envelope, the orderer signatures and the
+// validation codes of the committing peer.
+func (s *IntegrityService) proof(qscc *client.Contract, ctx context.Context, channel, txID string) (entity.LedgerProof, *response.ErrorInfo) {
+	blockProof, errInfo := s.blockProof(qscc, ctx, channel, txID)
+	if errInfo != nil {
+		return entity.LedgerProof{}, errInfo
+	}
+
+	proof := entity.LedgerProof{
+		BlockNumber:    blockProof.Number,
+		ValidationCode: blockProof.ValidationCode().String(),
+		Envelope:       blockProof.Envelope(),
+		Endorsements:   []verifier.Endorsement{},
+		Block:          blockProof,
+	}
+	if tx, err := verifier.ParseEnvelope(proof.Envelope); err == nil {
+		proof.Endorsements = verifier.EndorsementsOf(tx)
+	}
+	return proof, nil
+}
+
+// blockProof returns the proof of the block holding a transaction, from the cache or queried through qscc.
+func (s *IntegrityService) blockProof(qscc *client.Contract, ctx context.Context, channel, txID string) (*verifier.BlockProof, *response.ErrorInfo) {
+	s.mu.Lock()
+	cached, ok := s.blocks[txID]
+	s.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	blockBytes, err := fabric.Evaluate(ctx, qscc, "GetBlockByTxID", channel, txID)
+	if err != nil {
+		return nil, &response.ErrorInfo{Code: 500, Message: "Failed to query block of transaction " + txID + ": " + err.Error()}
+	}
+	var block common.Block
+	if err := proto.Unmarshal(blockBytes, &block); err != nil {
+		return nil, &response.ErrorInfo{Code: 500, Message: "Failed to unmarshal block of transaction " + txID + ": " + err.Error()}
+	}
+	blockProof, err := verifier.NewBlockProof(&block, txID)
+	if err != nil {
+		return nil, &response.ErrorInfo{Code: 500, Message: "Failed to read transaction " + txID + " from its block: " + err.Error()}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.blocks[txID]; !ok {
+		if len(s.order) >= blockCacheSize {
+			delete(s.blocks, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.blocks[txID] = blockProof
+		s.order = append(s.order, txID)
+	}
+	return blockProof, nil
+}
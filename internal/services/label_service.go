@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/AryaJayadi/MedTrace_api/internal/labels"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// LabelService renders GS1 barcode labels for drugs and batches already on the ledger.
+type LabelService struct {
+	Drugs        *DrugService
+	Batches      *BatchService
+	ResolverBase string // Base URL of GS1 Digital Link URIs encoded in QR codes
+}
+
+// NewLabelService creates a new LabelService.
+func NewLabelService(drugs *DrugService, batches *BatchService, resolverBase string) *LabelService {
+	return &LabelService{Drugs: drugs, Batches: batches, ResolverBase: resolverBase}
+}
+
+// DrugLabel renders the label of a single pack: GTIN and serial from its SGTIN drug ID, lot and expiry from its batch.
+func (s *LabelService) DrugLabel(contract *client.Contract, ctx context.Context, drugID, symbology, format string, moduleSize int) response.BaseValueResponse[labels.Label] {
+	drugResp := s.Drugs.GetDrug(contract, ctx, drugID)
+	if !drugResp.Success {
+		return response.BaseValueResponse[labels.Label]{Success: false, Error: drugResp.Error}
+	}
+	batchResp := s.Batches.GetBatchByID(contract, ctx, drugResp.Value.BatchID)
+	if !batchResp.Success {
+		return response.BaseValueResponse[labels.Label]{Success: false, Error: batchResp.Error}
+	}
+
+	ids, err := packIdentifiers(drugResp.Value, batchResp.Value)
+	if err != nil {
+		return response.ErrorValueResponse[labels.Label](400, "%v", err)
+	}
+	label, err := labels.Render(ids, symbology, format, s.ResolverBase, moduleSize)
+	if err != nil {
+		return response.ErrorValueResponse[labels.Label](400, "Failed to render label: %v", err)
+	}
+	return response.SuccessValueResponse(label)
+}
+
+// BatchLabel renders a batch-level label carrying the batch GTIN, lot and expiry but no serial.
+func (s *LabelService) BatchLabel(contract *client.Contract, ctx context.Context, batchID, symbology, format string, moduleSize int) response.BaseValueResponse[labels.Label] {
+	batchResp := s.Batches.GetBatchByID(contract, ctx, batchID)
+	if !batchResp.Success {
+		return response.BaseValueResponse[labels.Label]{Success: false, Error: batchResp.Error}
+	}
+	b := batchResp.Value
+	if b.GTIN == "" {
+		return response.ErrorValueResponse[labels.Label](400, "Batch %s has no GTIN; set one at creation to print GS1 labels", batchID)
+	}
+
+	ids := gs1.Identifiers{GTIN: b.GTIN, Lot: b.ID, ExpiryDate: b.ExpiryDate}
+	label, err := labels.Render(ids, symbology, format, s.ResolverBase, moduleSize)
+	if err != nil {
+		return response.ErrorValueResponse[labels.Label](400, "Failed to render label: %v", err)
+	}
+	return response.SuccessValueResponse(label)
+}
+
+// MaxSheetLabels caps the labels rendered into one sheet; larger batches are printed in pages.
+const MaxSheetLabels = 500
+
+// BatchLabelSheet renders a print-ready PDF with one pack label per drug of the batch, ordered by drug ID.
+// It labels limit drugs from offset on; a limit of 0 labels every drug, which fails for batches of more than
+// MaxSheetLabels drugs.
+func (s *LabelService) BatchLabelSheet(contract *client.Contract, ctx context.Context, batchID, symbology string, offset, limit int) response.BaseValueResponse[labels.Label] {
+	batchResp := s.Batches.GetBatchByID(contract, ctx, batchID)
+	if !batchResp.Success {
+		return response.BaseValueResponse[labels.Label]{Success: false, Error: batchResp.Error}
+	}
+	drugsResp := s.Drugs.GetDrugByBatch(contract, ctx, batchID)
+	if !drugsResp.Success {
+		return response.BaseValueResponse[labels.Label]{Success: false, Error: drugsResp.Error}
+	}
+	if len(drugsResp.List) == 0 {
+		return response.ErrorValueResponse[labels.Label](404, "Batch %s has no drugs to label", batchID)
+	}
+
+	drugs := drugsResp.List
+	if limit == 0 && len(drugs) > MaxSheetLabels {
+		return response.ErrorValueResponse[labels.Label](400, "Batch %s has %d drugs, more than the %d labels of one sheet; request the sheets in pages with offset and limit", batchID, len(drugs), MaxSheetLabels)
+	}
+	if limit < 0 || limit > MaxSheetLabels {
+		return response.ErrorValueResponse[labels.Label](400, "limit must be a number from 1 to %d", MaxSheetLabels)
+	}
+	if offset < 0 || offset >= len(drugs) {
+		return response.ErrorValueResponse[labels.Label](400, "offset %d is past the %d drugs of batch %s", offset, len(drugs), batchID)
+	}
+	sort.Slice(drugs, func(i, j int) bool { return drugs[i].ID < drugs[j].ID })
+	if limit > 0 {
+		drugs = drugs[offset:min(offset+limit, len(drugs))]
+	}
+	items := make([]gs1.Identifiers, 0, len(drugs))
+	for _, d := range drugs {
+		ids, err := packIdentifiers(d, batchResp.Value)
+		if err != nil {
+			return response.ErrorValueResponse[labels.Label](400, "%v", err)
+		}
+		items = append(items, ids)
+	}
+
+	title := "Labels for batch " + batchID
+	if limit > 0 {
+		title = fmt.Sprintf("%s, drugs %d to %d of %d", title, offset+1, offset+len(drugs), len(drugsResp.List))
+	}
+	sheet, err := labels.Sheet(items, symbology, s.ResolverBase, title)
+	if err != nil {
+		return response.ErrorValueResponse[labels.Label](400, "Failed to render label sheet: %v", err)
+	}
+	return response.SuccessValueResponse(sheet)
+}
+
+// packIdentifiers combines a drug's SGTIN with its batch's lot and expiry.
+func packIdentifiers(d *entity.Drug, b *entity.Batch) (gs1.Identifiers, error) {
+	sgtin, err := gs1.ParseSGTIN(d.ID)
+	if err != nil {
+		return gs1.Identifiers{}, fmt.Errorf("Drug %s cannot be labelled, it was not created with a GTIN and serial: %w", d.ID, err)
+	}
+	return gs1.Identifiers{GTIN: sgtin.GTIN, Serial: sgtin.Serial, Lot: b.ID, ExpiryDate: b.ExpiryDate}, nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// ledgerRecords is the ledger data about a set of drugs: their key histories and the batches and transfers they refer to.
+type ledgerRecords struct {
+	Batches   map[string]entity.Batch
+	Histories map[string][]entity.HistoryDrug
+	Transfers map[string]entity.Transfer
+}
+
+// loadLedgerRecords reads the history of each drug and every batch and transfer any of its records refers to.
+// Batches already known to the caller can be passed in to save queries.
+func loadLedgerRecords(drugs *DrugService, batches *BatchService, transfers *TransferService, contract *client.Contract, ctx context.Context, drugIDs []string, knownBatches map[string]entity.Batch) (ledgerRecords, *response.ErrorInfo) {
+	records := ledgerRecords{
+		Batches:   make(map[string]entity.Batch),
+		Histories: make(map[string][]entity.HistoryDrug),
+		Transfers: make(map[string]entity.Transfer),
+	}
+	for id, b := range knownBatches {
+		records.Batches[id] = b
+	}
+
+	for _, drugID := range drugIDs {
+		historyResp := drugs.GetHistoryDrug(contract, ctx, drugID)
+		if !historyResp.Success {
+			return ledgerRecords{}, historyResp.Error
+		}
+		for _, rec := range historyResp.List {
+			records.Histories[drugID] = append(records.Histories[drugID], *rec)
+			if rec.Drug == nil {
+				continue
+			}
+			if _, ok := records.Batches[rec.Drug.BatchID]; !ok && rec.Drug.BatchID != "" {
+				batchResp := batches.GetBatchByID(contract, ctx, rec.Drug.BatchID)
+				if !batchResp.Success {
+					return ledgerRecords{}, batchResp.Error
+				}
+				records.Batches[rec.Drug.BatchID] = *batchResp.Value
+			}
+			if _, ok := records.Transfers[rec.Drug.TransferID]; !ok && rec.Drug.TransferID != "" {
+				transferResp := transfers.GetTransfer(contract, ctx, rec.Drug.TransferID)
+				if !transferResp.Success {
+					return ledgerRecords{}, transferResp.Error
+				}
+				records.Transfers[rec.Drug.TransferID] = *transferResp.Value
+			}
+		}
+	}
+	return records, nil
+}
+
+// shippedTransfers returns the transfers a drug was shipped in, oldest first, from its key history.
+func shippedTransfers(history []entity.HistoryDrug) []string {
+	records := make([]entity.HistoryDrug, len(history))
+	copy(records, history)
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+
+	var transferIDs []string
+	transferred := false
+	for _, rec := range records {
+		if rec.Drug == nil {
+			transferred = false
+			continue
+		}
+		if rec.Drug.IsTransferred && !transferred && rec.Drug.TransferID != "" {
+			transferIDs = append(transferIDs, rec.Drug.TransferID)
+		}
+		transferred = rec.Drug.IsTransferred
+	}
+	return transferIDs
+}
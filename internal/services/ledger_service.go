@@ -1,32 +1,274 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/ledger"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/store"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"gopkg.in/yaml.v3"
 )
 
+// DefaultLedgerAuditFile records every request to initialize the ledger.
+const DefaultLedgerAuditFile = "data/ledger_init_audit.json"
+
+// Sources of the data written by InitLedger, besides the path of a seed file.
+const (
+	SeedBuiltin = "builtin" // The chaincode's built-in sample data
+	SeedRequest = "request" // A seed sent with the request
+)
+
+// ConnectFunc opens a contract acting as another organization. The returned function closes its gateway.
+type ConnectFunc func(orgID string) (*client.Contract, func() error, error)
+
 // LedgerService handles ledger-related operations.
-// It no longer stores the contract directly.
+// Initializing the ledger is only allowed when Enabled is set, and every attempt is recorded in an audit log.
 type LedgerService struct {
-	// No contract field here
+	Organizations *OrganizationService
+	Batches       *BatchService
+	Drugs         *DrugService
+
+	// Enabled allows InitLedger. The server only sets it in development mode.
+	Enabled bool
+	// SeedFile is written when a request carries no seed; without it the chaincode's built-in data is loaded.
+	SeedFile string
+	// Connect opens contracts for the manufacturers of seed batches and drugs other than the caller.
+	Connect ConnectFunc
+
+	audit *store.JSONFile[[]entity.LedgerInitRecord]
+	mu    sync.Mutex // Serializes initialization runs
 }
 
-// NewLedgerService creates a new LedgerService.
-// It no longer takes a contract as a parameter.
-func NewLedgerService() *LedgerService {
-	return &LedgerService{}
+// NewLedgerService creates a new LedgerService that records initialization requests in auditPath.
+func NewLedgerService(organizations *OrganizationService, batches *BatchService, drugs *DrugService, enabled bool, seedFile, auditPath string, connect ConnectFunc) *LedgerService {
+	return &LedgerService{
+		Organizations: organizations,
+		Batches:       batches,
+		Drugs:         drugs,
+		Enabled:       enabled,
+		SeedFile:      seedFile,
+		Connect:       connect,
+		audit:         store.NewJSONFile[[]entity.LedgerInitRecord](auditPath),
+	}
 }
 
-// InitLedger calls the InitLedger chaincode function using the provided contract.
-// The chaincode InitLedger function doesn't return a specific value on success, just an error if it fails.
-// So, we'll return a simple success message.
-func (s *LedgerService) InitLedger(contract *client.Contract, ctx context.Context) response.BaseValueResponse[string] {
-	// ctx is available if needed for future use (e.g. timeouts, cancellation), but not directly used by SubmitTransaction here.
-	_, err := contract.SubmitTransaction("InitLedger") // Result not typically used for InitLedger
+// InitLedger writes initial data to the ledger on behalf of orgID: the given seed, else the configured seed file,
+// else the chaincode's built-in data. The attempt is recorded whether or not it succeeds.
+func (s *LedgerService) InitLedger(contract *client.Contract, ctx context.Context, orgID, remoteIP string, seed *ledger.Seed) response.BaseValueResponse[entity.LedgerInitRecord] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := entity.LedgerInitRecord{OrgID: orgID, RemoteIP: remoteIP, StartedAt: time.Now().UTC()}
+	errInfo := s.initLedger(contract, ctx, orgID, seed, &record)
+	record.FinishedAt = time.Now().UTC()
+	record.Success = errInfo == nil
+	if errInfo != nil {
+		record.Error = errInfo.Message
+	}
+
+	if err := s.appendAudit(record); err != nil {
+		if errInfo == nil {
+			errInfo = &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Ledger was initialized but the audit record could not be saved: %v", err)}
+		} else {
+			errInfo.Message = fmt.Sprintf("%s; the audit record could not be saved: %v", errInfo.Message, err)
+		}
+	}
+	if errInfo != nil {
+		return response.BaseValueResponse[entity.LedgerInitRecord]{Success: false, Error: errInfo}
+	}
+	return response.SuccessValueResponse(record)
+}
+
+func (s *LedgerService) initLedger(contract *client.Contract, ctx context.Context, orgID string, seed *ledger.Seed, record *entity.LedgerInitRecord) *response.ErrorInfo {
+	if !s.Enabled {
+		return &response.ErrorInfo{Code: 403, Message: "Ledger initialization is disabled on this server"}
+	}
+
+	switch {
+	case seed != nil:
+		record.Source = SeedRequest
+	case s.SeedFile != "":
+		record.Source = s.SeedFile
+		loaded, err := LoadSeed(s.SeedFile)
+		if err != nil {
+			return &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to load seed file %s: %v", s.SeedFile, err)}
+		}
+		seed = loaded
+	default:
+		record.Source = SeedBuiltin
+		// The chaincode InitLedger function doesn't return a value on success, just an error if it fails.
+		if _, err := fabric.Submit(ctx, contract, "InitLedger"); err != nil {
+			return &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to submit InitLedger transaction: %v", err)}
+		}
+		return nil
+	}
+	return s.applySeed(contract, ctx, orgID, seed, record)
+}
+
+// applySeed creates the organizations, batches and drugs of a seed in that order, skipping those that already exist.
+// Batches and drugs are created by their manufacturer, connecting as that organization when it is not the caller.
+func (s *LedgerService) applySeed(contract *client.Contract, ctx context.Context, orgID string, seed *ledger.Seed, record *entity.LedgerInitRecord) *response.ErrorInfo {
+	contracts := map[string]*client.Contract{orgID: contract}
+	var closers []func() error
+	defer func() {
+		for _, closeGateway := range closers {
+			closeGateway()
+		}
+	}()
+	contractFor := func(id string) (*client.Contract, *response.ErrorInfo) {
+		if id == "" {
+			return contract, nil
+		}
+		if c, ok := contracts[id]; ok {
+			return c, nil
+		}
+		if s.Connect == nil {
+			return nil, &response.ErrorInfo{Code: 400, Message: fmt.Sprintf("Cannot act as %s to seed its data", id)}
+		}
+		c, closeGateway, err := s.Connect(id)
+		if err != nil {
+			return nil, &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to connect as %s: %v", id, err)}
+		}
+		contracts[id] = c
+		closers = append(closers, closeGateway)
+		return c, nil
+	}
+
+	for i := range seed.Organizations {
+		req := &seed.Organizations[i]
+		existing := s.Organizations.GetOrganizationByID(contract, ctx, req.ID)
+		if existing.Success {
+			continue
+		}
+		if existing.Error.Code != 404 {
+			return existing.Error
+		}
+		var created response.BaseValueResponse[entity.Organization]
+		if _, err := config.GetOrgConfig(req.ID); err == nil {
+			// Organizations of the static configuration only need their profile on the ledger.
+			created = s.Organizations.createOrganization(contract, ctx, entity.Organization{ID: req.ID, Name: req.Name, Type: req.Type, Location: req.Location, MSPID: req.MSPID})
+		} else {
+			created = s.Organizations.RegisterOrganization(contract, ctx, req)
+		}
+		if !created.Success {
+			return &response.ErrorInfo{Code: created.Error.Code, Message: fmt.Sprintf("Organization %s: %s", req.ID, created.Error.Message)}
+		}
+		record.Organizations++
+	}
+
+	for i := range seed.Batches {
+		b := &seed.Batches[i]
+		c, errInfo := contractFor(b.ManufacturerID)
+		if errInfo != nil {
+			return errInfo
+		}
+		existing := s.Batches.GetBatchByID(c, ctx, b.ID)
+		if existing.Success {
+			continue
+		}
+		if existing.Error.Code != 404 {
+			return existing.Error
+		}
+		created := s.Batches.CreateBatch(c, ctx, &b.CreateBatch)
+		if !created.Success {
+			return &response.ErrorInfo{Code: created.Error.Code, Message: fmt.Sprintf("Batch %s: %s", b.ID, created.Error.Message)}
+		}
+		record.Batches++
+	}
+
+	for _, d := range seed.Drugs {
+		c, errInfo := contractFor(d.OwnerID)
+		if errInfo != nil {
+			return errInfo
+		}
+		existing := s.Drugs.GetDrugByBatch(c, ctx, d.BatchID)
+		if !existing.Success {
+			return existing.Error
+		}
+		known := make(map[string]bool, len(existing.List))
+		for _, drug := range existing.List {
+			known[drug.ID] = true
+		}
+		missing := make([]string, 0, len(d.DrugsID))
+		for _, id := range d.DrugsID {
+			if !known[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		created := s.Drugs.CreateDrugs(c, ctx, d.OwnerID, d.BatchID, missing)
+		if !created.Success {
+			return &response.ErrorInfo{Code: created.Error.Code, Message: fmt.Sprintf("Drugs of batch %s: %s", d.BatchID, created.Error.Message)}
+		}
+		record.Drugs += len(created.List)
+	}
+	return nil
+}
+
+// GetInitAudit lists the recorded requests to initialize the ledger, most recent first.
+func (s *LedgerService) GetInitAudit() response.BaseListResponse[entity.LedgerInitRecord] {
+	records, err := s.audit.Load()
+	if err != nil {
+		return response.ErrorListResponse[entity.LedgerInitRecord](500, "Failed to load ledger initialization audit log: %v", err)
+	}
+	list := make([]*entity.LedgerInitRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		list = append(list, &records[i])
+	}
+	return response.SuccessListResponse(list)
+}
+
+func (s *LedgerService) appendAudit(record entity.LedgerInitRecord) error {
+	records, err := s.audit.Load()
+	if err != nil {
+		return err
+	}
+	return s.audit.Save(append(records, record))
+}
+
+// LoadSeed reads a seed file. Files ending in .yaml or .yml are read as YAML, others as JSON.
+func LoadSeed(path string) (*ledger.Seed, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return response.ErrorValueResponse[string](500, "Failed to submit InitLedger transaction: %v", err)
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	return ParseSeed(data, ext == ".yaml" || ext == ".yml")
+}
+
+// ParseSeed decodes a seed from JSON, or from YAML with the same field names.
+// Unknown fields are rejected so that a misspelt field does not silently seed incomplete data.
+func ParseSeed(data []byte, isYAML bool) (*ledger.Seed, error) {
+	if isYAML {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		converted, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("seed cannot be represented as JSON: %w", err)
+		}
+		data = converted
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var seed ledger.Seed
+	if err := dec.Decode(&seed); err != nil {
+		return nil, err
 	}
-	return response.SuccessValueResponse("Ledger initialized successfully.")
+	return &seed, nil
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/organization"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
@@ -23,7 +26,7 @@ func NewOrganizationService() *OrganizationService {
 
 // GetOrganizationByID retrieves a specific organization from the ledger using the provided contract.
 func (s *OrganizationService) GetOrganizationByID(contract *client.Contract, ctx context.Context, orgID string) response.BaseValueResponse[entity.Organization] {
-	resultBytes, err := contract.EvaluateTransaction("GetOrganization", orgID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetOrganization", orgID)
 	if err != nil {
 		return response.ErrorValueResponse[entity.Organization](500, "Failed to evaluate GetOrganization transaction: %v", err)
 	}
@@ -41,7 +44,7 @@ func (s *OrganizationService) GetOrganizationByID(contract *client.Contract, ctx
 
 // GetOrganizations retrieves all organizations from the ledger using the provided contract.
 func (s *OrganizationService) GetOrganizations(contract *client.Contract, ctx context.Context) response.BaseListResponse[entity.Organization] {
-	resp, err := contract.EvaluateTransaction("GetAllOrganizations")
+	resp, err := fabric.Evaluate(ctx, contract, "GetAllOrganizations")
 	if err != nil {
 		return response.ErrorListResponse[entity.Organization](500, "Failed to evaluate transaction to Fabric: %v", err)
 	}
@@ -58,3 +61,131 @@ func (s *OrganizationService) GetOrganizations(contract *client.Contract, ctx co
 
 	return response.SuccessListResponse(ptrList)
 }
+
+// GetHistoryOrganization retrieves the ledger key history of an organization as returned by the chaincode.
+func (s *OrganizationService) GetHistoryOrganization(contract *client.Contract, ctx context.Context, orgID string) response.BaseListResponse[entity.HistoryOrganization] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetHistoryOrganization", orgID)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryOrganization](500, "Failed to evaluate GetHistoryOrganization transaction: %v", err)
+	}
+
+	var records []entity.HistoryOrganization
+	err = json.Unmarshal(resultBytes, &records)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryOrganization](500, "Failed to unmarshal history organization data for GetHistoryOrganization: %v", err)
+	}
+
+	recordsPtrs := make([]*entity.HistoryOrganization, len(records))
+	for i := range records {
+		recordsPtrs[i] = &records[i]
+	}
+
+	return response.SuccessListResponse(recordsPtrs)
+}
+
+// GetOrganizationChanges returns the fields each transaction in the key history of an organization changed.
+func (s *OrganizationService) GetOrganizationChanges(contract *client.Contract, ctx context.Context, orgID string) response.BaseListResponse[entity.HistoryChange] {
+	historyResp := s.GetHistoryOrganization(contract, ctx, orgID)
+	if !historyResp.Success {
+		return response.BaseListResponse[entity.HistoryChange]{Success: false, Error: historyResp.Error}
+	}
+	versions := make([]historyVersion, len(historyResp.List))
+	for i, rec := range historyResp.List {
+		versions[i] = historyVersion{TxID: rec.TxID, Timestamp: rec.Timestamp, IsDelete: rec.IsDelete, Record: rec.Organization}
+	}
+	return diffHistory(versions)
+}
+
+// RegisterOrganization adds the MSP mapping of a new organization to the configuration, so that it can log in
+// without a code change, and then creates it on the ledger. The mapping is removed again if the organization
+// could not be created.
+func (s *OrganizationService) RegisterOrganization(contract *client.Contract, ctx context.Context, req *organization.RegisterOrganization) response.BaseValueResponse[entity.Organization] {
+	if req.ID == "" || req.Name == "" || req.Type == "" || req.MSPID == "" || req.PeerEndpoint == "" || req.GatewayPeer == "" {
+		return response.ErrorValueResponse[entity.Organization](400, "ID, Name, Type, MSPID, PeerEndpoint and GatewayPeer are required")
+	}
+	if _, err := config.GetOrgConfig(req.ID); err == nil {
+		return response.ErrorValueResponse[entity.Organization](409, "Organization %s is already configured", req.ID)
+	}
+
+	// The configuration is saved first, so that an organization on the ledger can always log in.
+	err := config.RegisterOrg(config.OrgInfo{
+		Name:         req.ID,
+		MSPID:        req.MSPID,
+		CryptoPath:   req.CryptoPath,
+		PeerEndpoint: req.PeerEndpoint,
+		GatewayPeer:  req.GatewayPeer,
+	})
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to save the configuration of organization %s: %v", req.ID, err)
+	}
+
+	created := s.createOrganization(contract, ctx, entity.Organization{ID: req.ID, Name: req.Name, Type: req.Type, Location: req.Location, MSPID: req.MSPID})
+	if !created.Success {
+		// A transaction whose commit status could not be read may still have created the organization.
+		if existing := s.GetOrganizationByID(contract, ctx, req.ID); existing.Success {
+			return existing
+		}
+		if err := config.UnregisterOrg(req.ID); err != nil {
+			return response.ErrorValueResponse[entity.Organization](500, "Organization %s was not created on the ledger (%s) and its configuration could not be removed: %v", req.ID, created.Error.Message, err)
+		}
+	}
+	return created
+}
+
+// createOrganization submits the profile of an organization to the ledger.
+func (s *OrganizationService) createOrganization(contract *client.Contract, ctx context.Context, org entity.Organization) response.BaseValueResponse[entity.Organization] {
+	orgJSON, err := json.Marshal(org)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to marshal request: %v", err)
+	}
+	resp, err := fabric.Submit(ctx, contract, "CreateOrganization", string(orgJSON))
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to submit CreateOrganization transaction: %v", err)
+	}
+	if err := json.Unmarshal(resp, &org); err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to unmarshal Fabric response: %v", err)
+	}
+	return response.SuccessValueResponse(org)
+}
+
+// UpdateOrganization updates the profile of an organization on the ledger.
+func (s *OrganizationService) UpdateOrganization(contract *client.Contract, ctx context.Context, orgID string, req *organization.UpdateOrganization) response.BaseValueResponse[entity.Organization] {
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to marshal request: %v", err)
+	}
+
+	resp, err := fabric.Submit(ctx, contract, "UpdateOrganization", orgID, string(reqJSON))
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to submit UpdateOrganization transaction: %v", err)
+	}
+
+	var org entity.Organization
+	if err := json.Unmarshal(resp, &org); err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to unmarshal Fabric response: %v", err)
+	}
+	return response.SuccessValueResponse(org)
+}
+
+// DeactivateOrganization marks an organization as deactivated on the ledger. It keeps its history and drugs
+// but can no longer receive transfers.
+func (s *OrganizationService) DeactivateOrganization(contract *client.Contract, ctx context.Context, orgID string) response.BaseValueResponse[entity.Organization] {
+	current := s.GetOrganizationByID(contract, ctx, orgID)
+	if !current.Success {
+		return current
+	}
+	if current.Value.IsDeactivated {
+		return response.ErrorValueResponse[entity.Organization](409, "Organization %s is already deactivated", orgID)
+	}
+
+	resp, err := fabric.Submit(ctx, contract, "DeactivateOrganization", orgID)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to submit DeactivateOrganization transaction: %v", err)
+	}
+
+	var org entity.Organization
+	if err := json.Unmarshal(resp, &org); err != nil {
+		return response.ErrorValueResponse[entity.Organization](500, "Failed to unmarshal Fabric response: %v", err)
+	}
+	return response.SuccessValueResponse(org)
+}
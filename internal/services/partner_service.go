@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/partner"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/store"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// DefaultPartnersFile holds the trading partner lists of all organizations.
+const DefaultPartnersFile = "data/partners.json"
+
+// partnerLists maps an organization ID to its trading partners by partner ID.
+type partnerLists map[string]map[string]entity.TradingPartner
+
+// PartnerService keeps the trading partners each organization is authorized to ship to.
+// Partner lists are kept by the API, not on the ledger.
+type PartnerService struct {
+	Organizations *OrganizationService
+
+	file *store.JSONFile[partnerLists]
+	mu   sync.Mutex
+}
+
+// NewPartnerService creates a new PartnerService that stores partner lists in path.
+func NewPartnerService(organizations *OrganizationService, path string) *PartnerService {
+	return &PartnerService{Organizations: organizations, file: store.NewJSONFile[partnerLists](path)}
+}
+
+// GetPartners lists the trading partners of an organization, ordered by partner ID.
+func (s *PartnerService) GetPartners(orgID string) response.BaseListResponse[entity.TradingPartner] {
+	lists, err := s.file.Load()
+	if err != nil {
+		return response.ErrorListResponse[entity.TradingPartner](500, "Failed to load trading partners: %v", err)
+	}
+	partners := make([]*entity.TradingPartner, 0, len(lists[orgID]))
+	for _, p := range lists[orgID] {
+		p := p
+		partners = append(partners, &p)
+	}
+	sort.Slice(partners, func(i, j int) bool { return partners[i].PartnerID < partners[j].PartnerID })
+	return response.SuccessListResponse(partners)
+}
+
+// GetPartner returns one trading partner of an organization.
+func (s *PartnerService) GetPartner(orgID, partnerID string) response.BaseValueResponse[entity.TradingPartner] {
+	lists, err := s.file.Load()
+	if err != nil {
+		return response.ErrorValueResponse[entity.TradingPartner](500, "Failed to load trading partners: %v", err)
+	}
+	p, ok := lists[orgID][partnerID]
+	if !ok {
+		return response.ErrorValueResponse[entity.TradingPartner](404, "%s is not a trading partner of %s", partnerID, orgID)
+	}
+	return response.SuccessValueResponse(p)
+}
+
+// UpsertPartner adds partnerID to the trading partners of orgID, or updates its license and status.
+// The partner must be an organization on the ledger.
+func (s *PartnerService) UpsertPartner(contract *client.Contract, ctx context.Context, orgID, partnerID string, req *partner.UpsertPartner) response.BaseValueResponse[entity.TradingPartner] {
+	if partnerID == orgID {
+		return response.ErrorValueResponse[entity.TradingPartner](400, "An organization cannot be its own trading partner")
+	}
+	if req.LicenseNumber == "" || req.LicenseExpiry == nil {
+		return response.ErrorValueResponse[entity.TradingPartner](400, "LicenseNumber and LicenseExpiry are required")
+	}
+	status := req.Status
+	if status == "" {
+		status = entity.PartnerActive
+	}
+	if status != entity.PartnerActive && status != entity.PartnerSuspended && status != entity.PartnerRevoked {
+		return response.ErrorValueResponse[entity.TradingPartner](400, "Status must be %s, %s or %s", entity.PartnerActive, entity.PartnerSuspended, entity.PartnerRevoked)
+	}
+	orgResp := s.Organizations.GetOrganizationByID(contract, ctx, partnerID)
+	if !orgResp.Success {
+		return response.BaseValueResponse[entity.TradingPartner]{Success: false, Error: orgResp.Error}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lists, err := s.file.Load()
+	if err != nil {
+		return response.ErrorValueResponse[entity.TradingPartner](500, "Failed to load trading partners: %v", err)
+	}
+	if lists == nil {
+		lists = partnerLists{}
+	}
+	if lists[orgID] == nil {
+		lists[orgID] = map[string]entity.TradingPartner{}
+	}
+
+	now := time.Now().UTC()
+	p, exists := lists[orgID][partnerID]
+	if !exists {
+		p = entity.TradingPartner{OrgID: orgID, PartnerID: partnerID, CreatedAt: now}
+	}
+	p.LicenseNumber = req.LicenseNumber
+	p.LicenseExpiry = req.LicenseExpiry.UTC()
+	p.Status = status
+	p.UpdatedAt = now
+	lists[orgID][partnerID] = p
+
+	if err := s.file.Save(lists); err != nil {
+		return response.ErrorValueResponse[entity.TradingPartner](500, "Failed to save trading partners: %v", err)
+	}
+	return response.SuccessValueResponse(p)
+}
+
+// RemovePartner deletes partnerID from the trading partners of orgID.
+func (s *PartnerService) RemovePartner(orgID, partnerID string) response.BaseValueResponse[entity.TradingPartner] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lists, err := s.file.Load()
+	if err != nil {
+		return response.ErrorValueResponse[entity.TradingPartner](500, "Failed to load trading partners: %v", err)
+	}
+	p, ok := lists[orgID][partnerID]
+	if !ok {
+		return response.ErrorValueResponse[entity.TradingPartner](404, "%s is not a trading partner of %s", partnerID, orgID)
+	}
+	delete(lists[orgID], partnerID)
+	if err := s.file.Save(lists); err != nil {
+		return response.ErrorValueResponse[entity.TradingPartner](500, "Failed to save trading partners: %v", err)
+	}
+	return response.SuccessValueResponse(p)
+}
+
+// CheckPartner explains why senderID may not ship to receiverID at now, or returns nil if it may.
+func (s *PartnerService) CheckPartner(senderID, receiverID string, now time.Time) *response.ErrorInfo {
+	lists, err := s.file.Load()
+	if err != nil {
+		return &response.ErrorInfo{Code: 500, Message: "Failed to load trading partners: " + err.Error()}
+	}
+	p, ok := lists[senderID][receiverID]
+	switch {
+	case !ok:
+		return &response.ErrorInfo{Code: 403, Message: "Receiver " + receiverID + " is not an authorized trading partner of " + senderID}
+	case p.Status != entity.PartnerActive:
+		return &response.ErrorInfo{Code: 403, Message: "Trading partner " + receiverID + " is " + p.Status}
+	case !now.Before(p.LicenseExpiry):
+		return &response.ErrorInfo{Code: 403, Message: "License " + p.LicenseNumber + " of trading partner " + receiverID + " expired on " + p.LicenseExpiry.Format("2006-01-02")}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// ProvenanceService composes the custody history of drugs into a graph of organizations and transfers.
+type ProvenanceService struct {
+	Drugs         *DrugService
+	Batches       *BatchService
+	Transfers     *TransferService
+	Organizations *OrganizationService
+}
+
+// NewProvenanceService creates a new ProvenanceService.
+func NewProvenanceService(drugs *DrugService, batches *BatchService, transfers *TransferService, organizations *OrganizationService) *ProvenanceService {
+	return &ProvenanceService{Drugs: drugs, Batches: batches, Transfers: transfers, Organizations: organizations}
+}
+
+// DrugProvenance returns the batch, custody hops and current state of a single drug.
+func (s *ProvenanceService) DrugProvenance(contract *client.Contract, ctx context.Context, drugID string) response.BaseValueResponse[entity.Provenance] {
+	resp := s.build(contract, ctx, "drug", drugID, []string{drugID}, nil)
+	if resp.Success && len(resp.Value.Units) == 0 {
+		return response.ErrorValueResponse[entity.Provenance](404, "Drug %s not found", drugID)
+	}
+	return resp
+}
+
+// BatchProvenance returns the provenance of every drug of a batch, showing where each unit ended up.
+// It reads the key history of each drug, so large batches take one ledger query per drug.
+func (s *ProvenanceService) BatchProvenance(contract *client.Contract, ctx context.Context, batchID string) response.BaseValueResponse[entity.Provenance] {
+	batchResp := s.Batches.GetBatchByID(contract, ctx, batchID)
+	if !batchResp.Success {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: batchResp.Error}
+	}
+	drugsResp := s.Drugs.GetDrugByBatch(contract, ctx, batchID)
+	if !drugsResp.Success {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: drugsResp.Error}
+	}
+	drugIDs := make([]string, 0, len(drugsResp.List))
+	for _, d := range drugsResp.List {
+		drugIDs = append(drugIDs, d.ID)
+	}
+	return s.build(contract, ctx, "batch", batchID, drugIDs, map[string]entity.Batch{batchID: *batchResp.Value})
+}
+
+// TransferProvenance returns the full provenance of the drugs linked to a transfer.
+func (s *ProvenanceService) TransferProvenance(contract *client.Contract, ctx context.Context, transferID string) response.BaseValueResponse[entity.Provenance] {
+	transferResp := s.Transfers.GetTransfer(contract, ctx, transferID)
+	if !transferResp.Success {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: transferResp.Error}
+	}
+	drugsResp := s.Drugs.GetDrugByTransfer(contract, ctx, transferID)
+	if !drugsResp.Success {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: drugsResp.Error}
+	}
+	drugIDs := make([]string, 0, len(drugsResp.List))
+	for _, d := range drugsResp.List {
+		drugIDs = append(drugIDs, d.ID)
+	}
+	return s.build(contract, ctx, "transfer", transferID, drugIDs, nil)
+}
+
+func (s *ProvenanceService) build(contract *client.Contract, ctx context.Context, subject, subjectID string, drugIDs []string, knownBatches map[string]entity.Batch) response.BaseValueResponse[entity.Provenance] {
+	records, errInfo := loadLedgerRecords(s.Drugs, s.Batches, s.Transfers, contract, ctx, drugIDs, knownBatches)
+	if errInfo != nil {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: errInfo}
+	}
+	orgsResp := s.Organizations.GetOrganizations(contract, ctx)
+	if !orgsResp.Success {
+		return response.BaseValueResponse[entity.Provenance]{Success: false, Error: orgsResp.Error}
+	}
+	orgs := make(map[string]entity.Organization, len(orgsResp.List))
+	for _, o := range orgsResp.List {
+		orgs[o.ID] = *o
+	}
+	return response.SuccessValueResponse(buildProvenance(subject, subjectID, records, orgs))
+}
+
+// buildProvenance walks the history of every drug in records to find its owners in order and its current state,
+// and aggregates the transfers the drugs were shipped in into edges between organizations.
+func buildProvenance(subject, subjectID string, records ledgerRecords, orgs map[string]entity.Organization) entity.Provenance {
+	drugIDs := make([]string, 0, len(records.Histories))
+	for id := range records.Histories {
+		drugIDs = append(drugIDs, id)
+	}
+	sort.Strings(drugIDs)
+
+	p := entity.Provenance{Subject: subject, SubjectID: subjectID, Batches: []entity.Batch{}, Nodes: []entity.ProvenanceNode{}, Edges: []entity.ProvenanceEdge{}, Units: []entity.ProvenanceUnit{}}
+	nodes := make(map[string]*entity.ProvenanceNode)
+	node := func(id string) *entity.ProvenanceNode {
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		o := orgs[id]
+		n := &entity.ProvenanceNode{ID: id, Name: o.Name, Type: o.Type, Location: o.Location}
+		nodes[id] = n
+		return n
+	}
+	edgeDrugs := make(map[string][]string)
+	batchIDs := make(map[string]bool)
+
+	for _, drugID := range drugIDs {
+		history := make([]entity.HistoryDrug, len(records.Histories[drugID]))
+		copy(history, records.Histories[drugID])
+		sort.SliceStable(history, func(i, j int) bool { return history[i].Timestamp.Before(history[j].Timestamp) })
+		if len(history) == 0 {
+			continue
+		}
+
+		unit := entity.ProvenanceUnit{DrugID: drugID, Path: []string{}}
+		var last *entity.Drug
+		for _, rec := range history {
+			if rec.IsDelete || rec.Drug == nil {
+				unit.Status = entity.UnitDecommissioned
+				continue
+			}
+			d := rec.Drug
+			if len(unit.Path) == 0 || unit.Path[len(unit.Path)-1] != d.OwnerID {
+				unit.Path = append(unit.Path, d.OwnerID)
+			}
+			last = d
+			unit.Status = ""
+		}
+		if last != nil {
+			unit.BatchID = last.BatchID
+			unit.CurrentOwnerID = last.OwnerID
+			unit.Location = last.Location
+			switch {
+			case unit.Status == entity.UnitDecommissioned:
+			case last.IsTransferred:
+				unit.Status = entity.UnitInTransit
+				unit.TransferID = last.TransferID
+			default:
+				unit.Status = entity.UnitInStock
+				node(last.OwnerID).Holding++
+			}
+			batchIDs[last.BatchID] = true
+		}
+		if len(unit.Path) > 0 {
+			node(unit.Path[0]).Origin = true
+		}
+		for _, owner := range unit.Path {
+			node(owner)
+		}
+		for _, transferID := range shippedTransfers(history) {
+			edgeDrugs[transferID] = append(edgeDrugs[transferID], drugID)
+		}
+		p.Units = append(p.Units, unit)
+	}
+
+	for transferID, ids := range edgeDrugs {
+		t := records.Transfers[transferID]
+		node(t.SenderID)
+		node(t.ReceiverID)
+		p.Edges = append(p.Edges, entity.ProvenanceEdge{
+			TransferID:   transferID,
+			From:         t.SenderID,
+			To:           t.ReceiverID,
+			Status:       TransferStatus(t),
+			TransferDate: t.TransferDate,
+			ReceiveDate:  t.ReceiveDate,
+			Quantity:     len(ids),
+			DrugsID:      ids,
+		})
+	}
+	sort.Slice(p.Edges, func(i, j int) bool {
+		if !p.Edges[i].TransferDate.Equal(p.Edges[j].TransferDate) {
+			return p.Edges[i].TransferDate.Before(p.Edges[j].TransferDate)
+		}
+		return p.Edges[i].TransferID < p.Edges[j].TransferID
+	})
+
+	for _, n := range nodes {
+		p.Nodes = append(p.Nodes, *n)
+	}
+	sort.Slice(p.Nodes, func(i, j int) bool { return p.Nodes[i].ID < p.Nodes[j].ID })
+
+	for id := range batchIDs {
+		if b, ok := records.Batches[id]; ok {
+			p.Batches = append(p.Batches, b)
+		}
+	}
+	sort.Slice(p.Batches, func(i, j int) bool { return p.Batches[i].ID < p.Batches[j].ID })
+	return p
+}
+
+// TransferStatus summarizes the state flags of a transfer. A transfer with a receive date that was not accepted was rejected.
+func TransferStatus(t entity.Transfer) string {
+	switch {
+	case t.IsCancelled:
+		return entity.TransferCancelled
+	case t.IsExpired:
+		return entity.TransferExpired
+	case t.IsAccepted:
+		return entity.TransferAccepted
+	case !t.ReceiveDate.IsZero():
+		return entity.TransferRejected
+	default:
+		return entity.TransferPending
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/signing"
+	"github.com/AryaJayadi/MedTrace_api/internal/store"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// Defaults for stored T3 documents. DSCSA requires trading partners to keep product tracing documents for six years.
+const (
+	DefaultT3Dir            = "data/t3"
+	DefaultT3RetentionYears = 6
+)
+
+// t3Statements is the transaction statement made by the seller for every transfer.
+var t3Statements = []string{
+	"The seller is authorized as required under the Drug Supply Chain Security Act.",
+	"The seller received the product from a person that is authorized as required under the Drug Supply Chain Security Act.",
+	"The seller received transaction information and a transaction statement from the prior owner of the product.",
+	"The seller did not knowingly ship a suspect or illegitimate product.",
+	"The seller had systems and processes in place to comply with verification requirements.",
+	"The seller did not knowingly provide false transaction information.",
+	"The seller did not knowingly alter the transaction history.",
+}
+
+// T3Service assembles, signs and keeps the transaction information, history and statement of transfers.
+type T3Service struct {
+	Drugs          *DrugService
+	Batches        *BatchService
+	Transfers      *TransferService
+	Organizations  *OrganizationService
+	Dir            string // Directory holding one signed JSON document per transfer
+	RetentionYears int
+
+	mu    sync.Mutex
+	locks map[string]*transferLock // Locks of the transfers whose documents are being read or stored
+}
+
+// transferLock serializes access to the stored documents of one transfer. It is removed from
+// T3Service.locks once no request holds or waits for it.
+type transferLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewT3Service creates a new T3Service that stores documents under dir for the given number of years.
+func NewT3Service(drugs *DrugService, batches *BatchService, transfers *TransferService, organizations *OrganizationService, dir string, retentionYears int) *T3Service {
+	return &T3Service{
+		Drugs:          drugs,
+		Batches:        batches,
+		Transfers:      transfers,
+		Organizations:  organizations,
+		Dir:            dir,
+		RetentionYears: retentionYears,
+		locks:          map[string]*transferLock{},
+	}
+}
+
+// GetT3 returns the T3 documents of a transfer to its sender or receiver. Only the sender can issue them: its
+// first request assembles them from the ledger, signs them with the sender's identity and stores them. Later requests
+// of either party return the stored copy unchanged, so the receiver can retrieve them even after the drugs have moved
+// on; before the sender has issued them the receiver gets a 409.
+func (s *T3Service) GetT3(contract *client.Contract, ctx context.Context, orgID, transferID string) response.BaseValueResponse[entity.T3Document] {
+	file := store.NewJSONFile[entity.T3Document](filepath.Join(s.Dir, url.PathEscape(transferID)+".json"))
+	stored, errInfo := s.loadStored(file, orgID, transferID)
+	if errInfo != nil {
+		return response.BaseValueResponse[entity.T3Document]{Success: false, Error: errInfo}
+	}
+	if stored.TransferID != "" {
+		return response.SuccessValueResponse(stored)
+	}
+
+	transferResp := s.Transfers.GetTransfer(contract, ctx, transferID)
+	if !transferResp.Success {
+		return response.BaseValueResponse[entity.T3Document]{Success: false, Error: transferResp.Error}
+	}
+	t := transferResp.Value
+	if orgID != t.SenderID && orgID != t.ReceiverID {
+		return response.ErrorValueResponse[entity.T3Document](403, "Only the sender and receiver of transfer %s can retrieve its T3 documents", transferID)
+	}
+	if t.IsCancelled || t.IsExpired {
+		return response.ErrorValueResponse[entity.T3Document](409, "Transfer %s did not change ownership, it was cancelled or expired", transferID)
+	}
+	if orgID != t.SenderID {
+		return response.ErrorValueResponse[entity.T3Document](409, "The T3 documents of transfer %s have not been issued by its sender yet", transferID)
+	}
+
+	doc, errInfo := s.assemble(contract, ctx, *t)
+	if errInfo != nil {
+		return response.BaseValueResponse[entity.T3Document]{Success: false, Error: errInfo}
+	}
+
+	signer, err := signing.ForOrg(t.SenderID)
+	if err != nil {
+		return response.ErrorValueResponse[entity.T3Document](500, "Failed to load signing identity of %s: %v", t.SenderID, err)
+	}
+	payload, err := signing.SignedBytes(doc)
+	if err != nil {
+		return response.ErrorValueResponse[entity.T3Document](500, "Failed to marshal T3 documents: %v", err)
+	}
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return response.ErrorValueResponse[entity.T3Document](500, "%v", err)
+	}
+	doc.Signature = &signature
+
+	// Another request of the sender may have issued the documents while these were assembled; the first one stored wins.
+	unlock := s.lock(transferID)
+	defer unlock()
+	stored, err = file.Load()
+	if err != nil {
+		return response.ErrorValueResponse[entity.T3Document](500, "Failed to load T3 documents of transfer %s: %v", transferID, err)
+	}
+	if stored.TransferID != "" {
+		return response.SuccessValueResponse(stored)
+	}
+	if err := file.Save(doc); err != nil {
+		return response.ErrorValueResponse[entity.T3Document](500, "Failed to store T3 documents of transfer %s: %v", transferID, err)
+	}
+	return response.SuccessValueResponse(doc)
+}
+
+// loadStored reads the stored documents of a transfer, if any, and checks that orgID is one of its parties.
+func (s *T3Service) loadStored(file *store.JSONFile[entity.T3Document], orgID, transferID string) (entity.T3Document, *response.ErrorInfo) {
+	unlock := s.lock(transferID)
+	defer unlock()
+	stored, err := file.Load()
+	if err != nil {
+		return entity.T3Document{}, &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to load T3 documents of transfer %s: %v", transferID, err)}
+	}
+	if stored.TransferID != "" && orgID != stored.Information.Seller.ID && orgID != stored.Information.Buyer.ID {
+		return entity.T3Document{}, &response.ErrorInfo{Code: 403, Message: fmt.Sprintf("Only the sender and receiver of transfer %s can retrieve its T3 documents", transferID)}
+	}
+	return stored, nil
+}
+
+// lock acquires the lock of a transfer's stored documents and returns the function releasing it.
+// No network call may be made while it is held.
+func (s *T3Service) lock(transferID string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[transferID]
+	if !ok {
+		l = &transferLock{}
+		s.locks[transferID] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, transferID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// assemble builds the unsigned T3 documents of a transfer from its drugs, their batches and the transfers
+// each drug went through before it.
+func (s *T3Service) assemble(contract *client.Contract, ctx context.Context, t entity.Transfer) (entity.T3Document, *response.ErrorInfo) {
+	drugsResp := s.Drugs.GetDrugByTransfer(contract, ctx, t.ID)
+	if !drugsResp.Success {
+		return entity.T3Document{}, drugsResp.Error
+	}
+	if len(drugsResp.List) == 0 {
+		return entity.T3Document{}, &response.ErrorInfo{Code: 409, Message: "Transfer " + t.ID + " has no drugs"}
+	}
+	drugIDs := make([]string, 0, len(drugsResp.List))
+	for _, d := range drugsResp.List {
+		drugIDs = append(drugIDs, d.ID)
+	}
+	sort.Strings(drugIDs)
+
+	records, errInfo := loadLedgerRecords(s.Drugs, s.Batches, s.Transfers, contract, ctx, drugIDs, nil)
+	if errInfo != nil {
+		return entity.T3Document{}, errInfo
+	}
+	parties := make(map[string]entity.T3Party)
+	party := func(id string) (entity.T3Party, *response.ErrorInfo) {
+		if p, ok := parties[id]; ok {
+			return p, nil
+		}
+		orgResp := s.Organizations.GetOrganizationByID(contract, ctx, id)
+		if !orgResp.Success {
+			return entity.T3Party{}, orgResp.Error
+		}
+		p := entity.T3Party{ID: id, Name: orgResp.Value.Name, Location: orgResp.Value.Location}
+		parties[id] = p
+		return p, nil
+	}
+
+	// Transaction information: one product line per batch.
+	byBatch := make(map[string][]string)
+	for _, d := range drugsResp.List {
+		byBatch[d.BatchID] = append(byBatch[d.BatchID], d.ID)
+	}
+	batchIDs := make([]string, 0, len(byBatch))
+	for id := range byBatch {
+		batchIDs = append(batchIDs, id)
+	}
+	sort.Strings(batchIDs)
+	products := make([]entity.T3Product, 0, len(batchIDs))
+	for _, batchID := range batchIDs {
+		b := records.Batches[batchID]
+		ids := byBatch[batchID]
+		sort.Strings(ids)
+		products = append(products, entity.T3Product{
+			DrugName:         b.DrugName,
+			GTIN:             b.GTIN,
+			LotNumber:        batchID,
+			ExpiryDate:       b.ExpiryDate,
+			ManufacturerName: b.ManufacturerName,
+			Quantity:         len(ids),
+			DrugsID:          ids,
+		})
+	}
+
+	// Transaction history: accepted transfers each drug went through before this one.
+	counts := make(map[string]int)
+	for _, drugID := range drugIDs {
+		for _, id := range shippedTransfers(records.Histories[drugID]) {
+			if id == t.ID {
+				break
+			}
+			if records.Transfers[id].IsAccepted {
+				counts[id]++
+			}
+		}
+	}
+	history := make([]entity.T3HistoryEntry, 0, len(counts))
+	for id, n := range counts {
+		prior := records.Transfers[id]
+		seller, errInfo := party(prior.SenderID)
+		if errInfo != nil {
+			return entity.T3Document{}, errInfo
+		}
+		buyer, errInfo := party(prior.ReceiverID)
+		if errInfo != nil {
+			return entity.T3Document{}, errInfo
+		}
+		history = append(history, entity.T3HistoryEntry{
+			TransferID:      id,
+			TransactionDate: prior.TransferDate,
+			ReceiveDate:     prior.ReceiveDate.Time,
+			Seller:          seller,
+			Buyer:           buyer,
+			Quantity:        n,
+		})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].TransactionDate.Equal(history[j].TransactionDate) {
+			return history[i].TransactionDate.Before(history[j].TransactionDate)
+		}
+		return history[i].TransferID < history[j].TransferID
+	})
+
+	seller, errInfo := party(t.SenderID)
+	if errInfo != nil {
+		return entity.T3Document{}, errInfo
+	}
+	buyer, errInfo := party(t.ReceiverID)
+	if errInfo != nil {
+		return entity.T3Document{}, errInfo
+	}
+
+	now := time.Now().UTC()
+	return entity.T3Document{
+		TransferID:  t.ID,
+		GeneratedAt: now,
+		RetainUntil: now.AddDate(s.RetentionYears, 0, 0),
+		Information: entity.T3TransactionInfo{
+			TransferID:      t.ID,
+			TransactionDate: t.TransferDate,
+			SSCC:            t.SSCC,
+			Seller:          seller,
+			Buyer:           buyer,
+			Products:        products,
+		},
+		History:   history,
+		Statement: entity.T3Statement{SellerID: t.SenderID, Statements: t3Statements},
+	}, nil
+}
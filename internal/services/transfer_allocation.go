@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+)
+
+// allocateDrugs picks concrete drug IDs for each requested item from the caller's available drugs.
+// Units are never allocated twice, batches are ordered by strategy, and drug IDs within a batch are taken in ID order.
+// Batches already expired at now are skipped when allocating by drug name and rejected when requested by ID.
+func allocateDrugs(available []entity.Drug, batches map[string]entity.Batch, items []transfer.BatchAllocation, strategy string, now time.Time) ([]entity.AllocatedBatch, error) {
+	byBatch := make(map[string][]string)
+	for _, d := range available {
+		byBatch[d.BatchID] = append(byBatch[d.BatchID], d.ID)
+	}
+	for id := range byBatch {
+		sort.Strings(byBatch[id])
+	}
+
+	var result []entity.AllocatedBatch
+	index := make(map[string]int) // batch ID -> position in result
+
+	take := func(batchID string, n int) {
+		b := batches[batchID]
+		ids := byBatch[batchID][:n]
+		byBatch[batchID] = byBatch[batchID][n:]
+		i, ok := index[batchID]
+		if !ok {
+			i = len(result)
+			index[batchID] = i
+			result = append(result, entity.AllocatedBatch{BatchID: batchID, DrugName: b.DrugName, ExpiryDate: b.ExpiryDate})
+		}
+		result[i].DrugsID = append(result[i].DrugsID, ids...)
+	}
+
+	for _, item := range items {
+		if item.BatchID != "" {
+			if b, ok := batches[item.BatchID]; ok && b.ExpiryDate.Before(now) {
+				return nil, fmt.Errorf("batch %s expired on %s", item.BatchID, b.ExpiryDate.Format(time.DateOnly))
+			}
+			left := len(byBatch[item.BatchID])
+			if left == 0 {
+				return nil, fmt.Errorf("no available units in batch %s", item.BatchID)
+			}
+			n := item.Quantity
+			if n == 0 {
+				n = left
+			}
+			if n > left {
+				return nil, fmt.Errorf("only %d available units in batch %s, requested %d", left, item.BatchID, n)
+			}
+			take(item.BatchID, n)
+			continue
+		}
+
+		candidates := candidateBatches(byBatch, batches, item.DrugName, strategy, now)
+		total := 0
+		for _, id := range candidates {
+			total += len(byBatch[id])
+		}
+		if total == 0 {
+			return nil, fmt.Errorf("no available unexpired units of %s", item.DrugName)
+		}
+		n := item.Quantity
+		if n == 0 {
+			n = total
+		}
+		if n > total {
+			return nil, fmt.Errorf("only %d available unexpired units of %s, requested %d", total, item.DrugName, n)
+		}
+		for _, id := range candidates {
+			if n == 0 {
+				break
+			}
+			k := min(n, len(byBatch[id]))
+			if k > 0 {
+				take(id, k)
+				n -= k
+			}
+		}
+	}
+	return result, nil
+}
+
+// candidateBatches returns the IDs of unexpired batches of drugName that still have available units, in strategy order.
+func candidateBatches(byBatch map[string][]string, batches map[string]entity.Batch, drugName, strategy string, now time.Time) []string {
+	var ids []string
+	for id, units := range byBatch {
+		b, ok := batches[id]
+		if !ok || len(units) == 0 || b.DrugName != drugName || b.ExpiryDate.Before(now) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := batches[ids[i]], batches[ids[j]]
+		if strategy == transfer.AllocationFIFO {
+			if !a.ProductionDate.Equal(b.ProductionDate) {
+				return a.ProductionDate.Before(b.ProductionDate)
+			}
+		} else if !a.ExpiryDate.Equal(b.ExpiryDate) {
+			return a.ExpiryDate.Before(b.ExpiryDate)
+		}
+		return a.ID < b.ID
+	})
+	return ids
+}
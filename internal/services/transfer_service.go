@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/AryaJayadi/MedTrace_api/cmd/fabric"
+	"github.com/AryaJayadi/MedTrace_api/internal/metrics"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
 	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
@@ -13,23 +17,34 @@ import (
 // TransferService handles transfer-related operations.
 // It no longer stores the contract directly.
 type TransferService struct {
-	// No contract field here
+	// Partners restricts receivers of new transfers to the sender's active, licensed trading partners.
+	Partners *PartnerService
 }
 
 // NewTransferService creates a new TransferService.
 // It no longer takes a contract as a parameter.
-func NewTransferService() *TransferService {
-	return &TransferService{}
+func NewTransferService(partners *PartnerService) *TransferService {
+	return &TransferService{Partners: partners}
 }
 
 // CreateTransfer calls the CreateTransfer chaincode function using the provided contract.
-func (s *TransferService) CreateTransfer(contract *client.Contract, ctx context.Context, req *transfer.CreateTransferRequest) response.BaseValueResponse[entity.Transfer] {
+// The receiver must be an active organization and an authorized trading partner of senderID.
+func (s *TransferService) CreateTransfer(contract *client.Contract, ctx context.Context, senderID string, req *transfer.CreateTransferRequest) response.BaseValueResponse[entity.Transfer] {
+	if s.Partners != nil {
+		if errInfo := s.Partners.CheckPartner(senderID, req.ReceiverID, time.Now()); errInfo != nil {
+			return response.BaseValueResponse[entity.Transfer]{Success: false, Error: errInfo}
+		}
+	}
+	if errInfo := checkReceiver(contract, ctx, req.ReceiverID); errInfo != nil {
+		return response.BaseValueResponse[entity.Transfer]{Success: false, Error: errInfo}
+	}
+
 	ccReqJSON, err := json.Marshal(req)
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to marshal CreateTransfer request: %v", err)
 	}
 
-	resultBytes, err := contract.SubmitTransaction("CreateTransfer", string(ccReqJSON))
+	resultBytes, err := fabric.Submit(ctx, contract, "CreateTransfer", string(ccReqJSON))
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to submit CreateTransfer transaction: %v", err)
 	}
@@ -39,12 +54,32 @@ func (s *TransferService) CreateTransfer(contract *client.Contract, ctx context.
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal CreateTransfer result: %v", err)
 	}
+	metrics.TransferEvent(metrics.TransferCreated, 1)
 	return response.SuccessValueResponse(transferEntity)
 }
 
+// checkReceiver makes sure the receiver of a new transfer exists and is not deactivated.
+func checkReceiver(contract *client.Contract, ctx context.Context, receiverID string) *response.ErrorInfo {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetOrganization", receiverID)
+	if err != nil {
+		return &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to evaluate GetOrganization transaction: %v", err)}
+	}
+	if len(resultBytes) == 0 {
+		return &response.ErrorInfo{Code: 404, Message: fmt.Sprintf("Receiver %s not found", receiverID)}
+	}
+	var receiver entity.Organization
+	if err := json.Unmarshal(resultBytes, &receiver); err != nil {
+		return &response.ErrorInfo{Code: 500, Message: fmt.Sprintf("Failed to unmarshal organization data: %v", err)}
+	}
+	if receiver.IsDeactivated {
+		return &response.ErrorInfo{Code: 409, Message: fmt.Sprintf("Receiver %s is deactivated and cannot receive transfers", receiverID)}
+	}
+	return nil
+}
+
 // GetTransfer calls the GetTransfer chaincode function using the provided contract.
 func (s *TransferService) GetTransfer(contract *client.Contract, ctx context.Context, transferID string) response.BaseValueResponse[entity.Transfer] {
-	resultBytes, err := contract.EvaluateTransaction("GetTransfer", transferID)
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetTransfer", transferID)
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to evaluate GetTransfer transaction: %v", err)
 	}
@@ -62,7 +97,7 @@ func (s *TransferService) GetTransfer(contract *client.Contract, ctx context.Con
 
 // getMyTransfersByType is a generic helper for GetMy...Transfer functions using the provided contract.
 func (s *TransferService) getMyTransfersByType(contract *client.Contract, ctx context.Context, chaincodeFunc string) response.BaseListResponse[entity.Transfer] {
-	resultBytes, err := contract.EvaluateTransaction(chaincodeFunc)
+	resultBytes, err := fabric.Evaluate(ctx, contract, chaincodeFunc)
 	if err != nil {
 		return response.ErrorListResponse[entity.Transfer](500, "Failed to evaluate %s transaction: %v", chaincodeFunc, err)
 	}
@@ -102,7 +137,7 @@ func (s *TransferService) AcceptTransfer(contract *client.Contract, ctx context.
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to marshal AcceptTransfer request: %v", err)
 	}
 
-	resultBytes, err := contract.SubmitTransaction("AcceptTransfer", string(ccReqJSON))
+	resultBytes, err := fabric.Submit(ctx, contract, "AcceptTransfer", string(ccReqJSON))
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to submit AcceptTransfer transaction: %v", err)
 	}
@@ -112,6 +147,7 @@ func (s *TransferService) AcceptTransfer(contract *client.Contract, ctx context.
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal AcceptTransfer result: %v", err)
 	}
+	metrics.TransferEvent(metrics.TransferAccepted, 1)
 	return response.SuccessValueResponse(transferEntity)
 }
 
@@ -122,7 +158,7 @@ func (s *TransferService) RejectTransfer(contract *client.Contract, ctx context.
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to marshal RejectTransfer request: %v", err)
 	}
 
-	resultBytes, err := contract.SubmitTransaction("RejectTransfer", string(ccReqJSON))
+	resultBytes, err := fabric.Submit(ctx, contract, "RejectTransfer", string(ccReqJSON))
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to submit RejectTransfer transaction: %v", err)
 	}
@@ -132,5 +168,253 @@ func (s *TransferService) RejectTransfer(contract *client.Contract, ctx context.
 	if err != nil {
 		return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal RejectTransfer result: %v", err)
 	}
+	metrics.TransferEvent(metrics.TransferRejected, 1)
+	return response.SuccessValueResponse(transferEntity)
+}
+
+// AcceptTransferPartial calls the AcceptTransferPartial chaincode function using the provided contract.
+// Every drug of the transfer must either be accepted or reported as MISSING/DAMAGED; SURPLUS reports must
+// name drugs that are not part of the transfer. Unaccepted drugs are returned to the sender by the chaincode.
+func (s *TransferService) AcceptTransferPartial(contract *client.Contract, ctx context.Context, req *transfer.PartialAcceptTransferRequest) response.BaseValueResponse[entity.Transfer] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetDrugByTransfer", req.TransferID)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to evaluate GetDrugByTransfer transaction: %v", err)
+	}
+	var drugs []entity.Drug
+	if len(resultBytes) > 0 {
+		if err := json.Unmarshal(resultBytes, &drugs); err != nil {
+			return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal drugs data for GetDrugByTransfer: %v", err)
+		}
+	}
+	if len(drugs) == 0 {
+		return response.ErrorValueResponse[entity.Transfer](404, "Transfer %s has no drugs to accept", req.TransferID)
+	}
+	if msg := checkPartialAcceptance(drugs, req); msg != "" {
+		return response.ErrorValueResponse[entity.Transfer](400, "%s", msg)
+	}
+
+	ccReqJSON, err := json.Marshal(req)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to marshal AcceptTransferPartial request: %v", err)
+	}
+
+	resultBytes, err = fabric.Submit(ctx, contract, "AcceptTransferPartial", string(ccReqJSON))
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to submit AcceptTransferPartial transaction: %v", err)
+	}
+
+	var transferEntity entity.Transfer
+	err = json.Unmarshal(resultBytes, &transferEntity)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal AcceptTransferPartial result: %v", err)
+	}
+	metrics.TransferEvent(metrics.TransferAccepted, 1)
+	return response.SuccessValueResponse(transferEntity)
+}
+
+// GetTransferDiscrepancy calls the GetTransferDiscrepancy chaincode function using the provided contract.
+// The chaincode only returns the report to the sender or the receiver of the transfer.
+func (s *TransferService) GetTransferDiscrepancy(contract *client.Contract, ctx context.Context, transferID string) response.BaseValueResponse[entity.TransferDiscrepancy] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetTransferDiscrepancy", transferID)
+	if err != nil {
+		return response.ErrorValueResponse[entity.TransferDiscrepancy](500, "Failed to evaluate GetTransferDiscrepancy transaction: %v", err)
+	}
+	if len(resultBytes) == 0 {
+		return response.ErrorValueResponse[entity.TransferDiscrepancy](404, "No discrepancy report for transfer %s", transferID)
+	}
+
+	var discrepancy entity.TransferDiscrepancy
+	err = json.Unmarshal(resultBytes, &discrepancy)
+	if err != nil {
+		return response.ErrorValueResponse[entity.TransferDiscrepancy](500, "Failed to unmarshal GetTransferDiscrepancy result: %v", err)
+	}
+	return response.SuccessValueResponse(discrepancy)
+}
+
+// checkPartialAcceptance verifies that the request accounts for every drug of the transfer exactly once.
+// It returns an empty string when the request is consistent, or a message describing the first problem found.
+func checkPartialAcceptance(drugs []entity.Drug, req *transfer.PartialAcceptTransferRequest) string {
+	inTransfer := make(map[string]bool, len(drugs))
+	for _, d := range drugs {
+		inTransfer[d.ID] = true
+	}
+
+	seen := make(map[string]bool, len(drugs))
+	for _, id := range req.AcceptedDrugsID {
+		if !inTransfer[id] {
+			return fmt.Sprintf("Drug %s is not part of transfer %s", id, req.TransferID)
+		}
+		if seen[id] {
+			return fmt.Sprintf("Drug %s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+
+	for _, d := range req.Discrepancies {
+		switch d.Type {
+		case transfer.DiscrepancyMissing, transfer.DiscrepancyDamaged:
+			if !inTransfer[d.DrugID] {
+				return fmt.Sprintf("Drug %s is not part of transfer %s", d.DrugID, req.TransferID)
+			}
+		case transfer.DiscrepancySurplus:
+			if inTransfer[d.DrugID] {
+				return fmt.Sprintf("Drug %s is part of transfer %s and cannot be reported as surplus", d.DrugID, req.TransferID)
+			}
+		default:
+			return fmt.Sprintf("Unknown discrepancy type %q for drug %s", d.Type, d.DrugID)
+		}
+		if seen[d.DrugID] {
+			return fmt.Sprintf("Drug %s is listed more than once", d.DrugID)
+		}
+		seen[d.DrugID] = true
+	}
+
+	for _, d := range drugs {
+		if !seen[d.ID] {
+			return fmt.Sprintf("Drug %s must be either accepted or reported as missing or damaged", d.ID)
+		}
+	}
+	return ""
+}
+
+// CancelTransfer calls the CancelTransfer chaincode function using the provided contract.
+// Only the sender may cancel, and only while the transfer is still pending; the drugs are released back to the sender.
+func (s *TransferService) CancelTransfer(contract *client.Contract, ctx context.Context, transferID string) response.BaseValueResponse[entity.Transfer] {
+	resultBytes, err := fabric.Submit(ctx, contract, "CancelTransfer", transferID)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to submit CancelTransfer transaction: %v", err)
+	}
+
+	var transferEntity entity.Transfer
+	err = json.Unmarshal(resultBytes, &transferEntity)
+	if err != nil {
+		return response.ErrorValueResponse[entity.Transfer](500, "Failed to unmarshal CancelTransfer result: %v", err)
+	}
+	metrics.TransferEvent(metrics.TransferCancelled, 1)
 	return response.SuccessValueResponse(transferEntity)
 }
+
+// OverdueTransfers lists the caller's outgoing transfers that are still pending after their AcceptDeadline.
+// It is a query, so checking for overdue transfers does not write to the ledger.
+func (s *TransferService) OverdueTransfers(contract *client.Contract, ctx context.Context, now time.Time) response.BaseListResponse[entity.Transfer] {
+	resp := s.GetMyOutTransfer(contract, ctx)
+	if !resp.Success {
+		return resp
+	}
+	overdue := []*entity.Transfer{}
+	for _, t := range resp.List {
+		if TransferStatus(*t) == entity.TransferPending && !t.AcceptDeadline.IsZero() && t.AcceptDeadline.Before(now) {
+			overdue = append(overdue, t)
+		}
+	}
+	return response.SuccessListResponse(overdue)
+}
+
+// ExpireTransfers calls the ExpireTransfers chaincode function using the provided contract.
+// The chaincode expires the caller's outgoing pending transfers whose AcceptDeadline is before now and returns them.
+func (s *TransferService) ExpireTransfers(contract *client.Contract, ctx context.Context, now time.Time) response.BaseListResponse[entity.Transfer] {
+	resultBytes, err := fabric.Submit(ctx, contract, "ExpireTransfers", now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return response.ErrorListResponse[entity.Transfer](500, "Failed to submit ExpireTransfers transaction: %v", err)
+	}
+	if len(resultBytes) == 0 {
+		return response.SuccessListResponse([]*entity.Transfer{})
+	}
+
+	var transfers []entity.Transfer
+	err = json.Unmarshal(resultBytes, &transfers)
+	if err != nil {
+		return response.ErrorListResponse[entity.Transfer](500, "Failed to unmarshal ExpireTransfers result: %v", err)
+	}
+
+	transfersPtrs := make([]*entity.Transfer, len(transfers))
+	for i := range transfers {
+		transfersPtrs[i] = &transfers[i]
+	}
+	metrics.TransferEvent(metrics.TransferExpired, len(transfers))
+	return response.SuccessListResponse(transfersPtrs)
+}
+
+// CreateTransferByBatch resolves the requested batch quantities into concrete drug IDs from the caller's
+// available drugs and then creates a regular transfer for them using the provided contract.
+func (s *TransferService) CreateTransferByBatch(contract *client.Contract, ctx context.Context, senderID string, req *transfer.CreateBatchTransferRequest) response.BaseValueResponse[entity.TransferAllocation] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetMyAvailDrugs")
+	if err != nil {
+		return response.ErrorValueResponse[entity.TransferAllocation](500, "Failed to evaluate GetMyAvailDrugs transaction: %v", err)
+	}
+	var available []entity.Drug
+	if err := json.Unmarshal(resultBytes, &available); err != nil {
+		return response.ErrorValueResponse[entity.TransferAllocation](500, "Failed to unmarshal drugs data for GetMyAvailDrugs: %v", err)
+	}
+
+	resultBytes, err = fabric.Evaluate(ctx, contract, "GetAllBatches")
+	if err != nil {
+		return response.ErrorValueResponse[entity.TransferAllocation](500, "Failed to evaluate GetAllBatches transaction: %v", err)
+	}
+	var batchList []entity.Batch
+	if err := json.Unmarshal(resultBytes, &batchList); err != nil {
+		return response.ErrorValueResponse[entity.TransferAllocation](500, "Failed to unmarshal Fabric response: %v", err)
+	}
+	batches := make(map[string]entity.Batch, len(batchList))
+	for _, b := range batchList {
+		batches[b.ID] = b
+	}
+
+	allocations, err := allocateDrugs(available, batches, req.Items, req.Strategy, time.Now())
+	if err != nil {
+		return response.ErrorValueResponse[entity.TransferAllocation](400, "Failed to allocate drugs: %v", err)
+	}
+
+	createReq := transfer.CreateTransferRequest{
+		ReceiverID:     req.ReceiverID,
+		TransferDate:   req.TransferDate,
+		AcceptDeadline: req.AcceptDeadline,
+		SSCC:           req.SSCC,
+	}
+	for _, a := range allocations {
+		createReq.DrugsID = append(createReq.DrugsID, a.DrugsID...)
+	}
+
+	created := s.CreateTransfer(contract, ctx, senderID, &createReq)
+	if !created.Success {
+		return response.BaseValueResponse[entity.TransferAllocation]{Success: false, Error: created.Error}
+	}
+	return response.SuccessValueResponse(entity.TransferAllocation{
+		Transfer:    *created.Value,
+		Allocations: allocations,
+	})
+}
+
+// GetHistoryTransfer retrieves the ledger key history of a transfer as returned by the chaincode.
+func (s *TransferService) GetHistoryTransfer(contract *client.Contract, ctx context.Context, transferID string) response.BaseListResponse[entity.HistoryTransfer] {
+	resultBytes, err := fabric.Evaluate(ctx, contract, "GetHistoryTransfer", transferID)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryTransfer](500, "Failed to evaluate GetHistoryTransfer transaction: %v", err)
+	}
+
+	var records []entity.HistoryTransfer
+	err = json.Unmarshal(resultBytes, &records)
+	if err != nil {
+		return response.ErrorListResponse[entity.HistoryTransfer](500, "Failed to unmarshal history transfer data for GetHistoryTransfer: %v", err)
+	}
+
+	recordsPtrs := make([]*entity.HistoryTransfer, len(records))
+	for i := range records {
+		recordsPtrs[i] = &records[i]
+	}
+
+	return response.SuccessListResponse(recordsPtrs)
+}
+
+// GetTransferChanges returns the fields each transaction in the key history of a transfer changed.
+func (s *TransferService) GetTransferChanges(contract *client.Contract, ctx context.Context, transferID string) response.BaseListResponse[entity.HistoryChange] {
+	historyResp := s.GetHistoryTransfer(contract, ctx, transferID)
+	if !historyResp.Success {
+		return response.BaseListResponse[entity.HistoryChange]{Success: false, Error: historyResp.Error}
+	}
+	versions := make([]historyVersion, len(historyResp.List))
+	for i, rec := range historyResp.List {
+		versions[i] = historyVersion{TxID: rec.TxID, Timestamp: rec.Timestamp, IsDelete: rec.IsDelete, Record: rec.Transfer}
+	}
+	return diffHistory(versions)
+}
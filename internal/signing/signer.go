@@ -0,0 +1,117 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/config"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/pkg/verifier"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// AlgorithmECDSASHA256 names the signature scheme used by Fabric MSP identities.
+const AlgorithmECDSASHA256 = "ECDSA-SHA256"
+
+// Signer signs documents with the Fabric MSP identity the API uses for an organization.
+type Signer struct {
+	OrgID          string
+	MSPID          string
+	CertificatePEM string
+	key            *ecdsa.PrivateKey
+}
+
+// ForOrg loads the certificate and private key of the organization's Fabric user from its crypto material.
+func ForOrg(orgID string) (*Signer, error) {
+	orgCfg, err := config.GetOrgConfig(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, err := os.ReadFile(orgCfg.CertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate of %s: %w", orgID, err)
+	}
+	files, err := os.ReadDir(orgCfg.KeyPath)
+	if err != nil || len(files) == 0 {
+		return nil, fmt.Errorf("failed to find private key of %s in %s: %v", orgID, orgCfg.KeyPath, err)
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(orgCfg.KeyPath, files[0].Name()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key of %s: %w", orgID, err)
+	}
+	privateKey, err := identity.PrivateKeyFromPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key of %s: %w", orgID, err)
+	}
+	key, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key of %s is not an ECDSA key", orgID)
+	}
+
+	return &Signer{OrgID: orgID, MSPID: orgCfg.MSPID, CertificatePEM: string(certPEM), key: key}, nil
+}
+
+// Sign returns a detached signature over data.
+func (s *Signer) Sign(data []byte) (entity.DocumentSignature, error) {
+	digest := sha256.Sum256(data)
+	value, err := ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return entity.DocumentSignature{}, fmt.Errorf("failed to sign document: %w", err)
+	}
+	return entity.DocumentSignature{
+		Algorithm:   AlgorithmECDSASHA256,
+		SignerOrgID: s.OrgID,
+		SignerMSPID: s.MSPID,
+		Certificate: s.CertificatePEM,
+		Digest:      base64.StdEncoding.EncodeToString(digest[:]),
+		Value:       base64.StdEncoding.EncodeToString(value),
+		SignedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// SignedBytes returns the bytes a T3 document signature covers: the compact output of Go's json.Marshal for the
+// document with its Signature field removed. Fields appear in the declaration order of entity.T3Document and its
+// nested types, without whitespace, with <, > and & escaped as \u003c, \u003e and \u0026, and with times in
+// RFC 3339 with nanoseconds as written by time.Time.MarshalJSON. A verifier decodes the document into the same
+// types and re-encodes it with encoding/json; reformatting the JSON with another encoder changes the bytes.
+func SignedBytes(doc entity.T3Document) ([]byte, error) {
+	doc.Signature = nil
+	return json.Marshal(doc)
+}
+
+// Verify checks a detached signature over data against the certificate it carries, and that the certificate
+// chains to the CAs of the signer's MSP in the trust store and was valid when the document was signed.
+func Verify(sig entity.DocumentSignature, data []byte, trust verifier.TrustStore) error {
+	if sig.Algorithm != AlgorithmECDSASHA256 {
+		return fmt.Errorf("unsupported signature algorithm '%s'", sig.Algorithm)
+	}
+	cert, err := trust.VerifyCertificate(sig.SignerMSPID, []byte(sig.Certificate), sig.SignedAt)
+	if err != nil {
+		return fmt.Errorf("invalid signer certificate: %w", err)
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("signer certificate does not hold an ECDSA key")
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	if sig.Digest != base64.StdEncoding.EncodeToString(digest[:]) {
+		return fmt.Errorf("document digest does not match the signed digest")
+	}
+	if !ecdsa.VerifyASN1(publicKey, digest[:], value) {
+		return fmt.Errorf("signature does not match the document")
+	}
+	return nil
+}
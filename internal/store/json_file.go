@@ -0,0 +1,69 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONFile persists a single value as a JSON document on disk.
+// Writes go to a temporary file that is renamed over the target, so a crash never leaves a half-written file.
+type JSONFile[T any] struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewJSONFile creates a new JSONFile for the given path. The file is created on the first Save.
+func NewJSONFile[T any](path string) *JSONFile[T] {
+	return &JSONFile[T]{path: path}
+}
+
+// Path returns the location of the backing file.
+func (f *JSONFile[T]) Path() string {
+	return f.path
+}
+
+// Load reads the stored value. A missing file yields the zero value of T and no error.
+func (f *JSONFile[T]) Load() (T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var v T
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return v, nil
+	}
+	if err != nil {
+		return v, fmt.Errorf("failed to read %s: %w", f.path, err)
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("failed to parse %s: %w", f.path, err)
+	}
+	return v, nil
+}
+
+// Save replaces the stored value.
+func (f *JSONFile[T]) Save(v T) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", f.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", f.path, err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", f.path, err)
+	}
+	return nil
+}
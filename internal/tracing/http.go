@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware traces every request in a server span named after its route pattern, e.g. "POST /transfers/accept",
+// continuing the trace of the traceparent header when there is one. It must run after the request ID middleware.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer.Start(ctx, req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(req.URL.Path),
+				RequestIDKey.String(c.Response().Header().Get(echo.HeaderXRequestID)),
+			),
+		)
+		defer span.End()
+		c.SetRequest(req.WithContext(ctx))
+
+		// Errors are written here rather than by Echo afterwards, so that the span has the status of the response.
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+		status := c.Response().Status
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+// Package tracing sets up OpenTelemetry tracing of the API. A request is traced from the Echo middleware through
+// the gateway acquisition of AuthMiddleware and the ledger transactions of the services down to the phases of each
+// Fabric transaction: evaluate, or endorse, submit and commit status. W3C trace context is read from incoming
+// requests, so that spans join the trace of the caller.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultServiceName is the service name of the spans unless OTEL_SERVICE_NAME is set.
+const DefaultServiceName = "medtrace-api"
+
+// Exporters accepted by Setup, named as for OTEL_TRACES_EXPORTER.
+const (
+	ExporterOTLP    = "otlp"    // OTLP over gRPC, configured by the standard OTEL_EXPORTER_OTLP_* variables
+	ExporterConsole = "console" // Spans printed to stdout, for local use
+	ExporterNone    = "none"    // No spans are recorded, but trace context is still propagated
+)
+
+// Attributes of the spans of the API.
+const (
+	OrgKey       = attribute.Key("medtrace.org")
+	RequestIDKey = attribute.Key("medtrace.request_id")
+	ChaincodeKey = attribute.Key("fabric.chaincode")
+	FunctionKey  = attribute.Key("fabric.function")
+	TxIDKey      = attribute.Key("fabric.tx_id")
+	PhaseKey     = attribute.Key("fabric.phase")
+)
+
+var tracer = otel.Tracer("github.com/AryaJayadi/MedTrace_api/internal/tracing")
+
+// Setup installs the global tracer provider exporting spans with the given exporter, and the W3C trace context
+// and baggage propagators. The returned function flushes the spans not yet exported and stops the exporter.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		spanExporter, err = otlptracegrpc.New(ctx)
+	case ExporterConsole:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q: must be %s, %s or %s", exporter, ExporterOTLP, ExporterConsole, ExporterNone)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", exporter, err)
+	}
+
+	// Attributes from OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(DefaultServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("describe trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(spanExporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Fail marks a span as failed with err.
+func Fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
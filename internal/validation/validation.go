@@ -0,0 +1,207 @@
+// Package validation evaluates the `validate` tags of request DTOs when Echo binds a request,
+// and reports failures as field-level problems in the response envelope.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/gs1"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// Errors lists every field of a request that failed validation.
+type Errors []response.FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, f := range e {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validator implements echo.Validator with the tag rules of go-playground/validator and the custom rules of this API:
+//   - gtin: a GTIN-8, -12, -13 or -14 with a valid check digit
+//   - sscc: an 18-digit SSCC with a valid check digit
+//   - exactly one of drugsID, range and pattern in a bulk serialization request
+//   - an AcceptDeadline after the TransferDate of a transfer
+type Validator struct {
+	validate *validator.Validate
+}
+
+// New creates a Validator that names fields by their JSON names.
+func New() *Validator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	v.RegisterValidation("gtin", func(fl validator.FieldLevel) bool {
+		_, err := gs1.NormalizeGTIN(fl.Field().String())
+		return err == nil
+	})
+	v.RegisterValidation("sscc", func(fl validator.FieldLevel) bool {
+		return gs1.ValidateSSCC(fl.Field().String()) == nil
+	})
+	v.RegisterStructValidation(validateBulkCreateDrug, drug.BulkCreateDrugRequest{})
+	v.RegisterStructValidation(validateCreateTransfer, transfer.CreateTransferRequest{})
+	v.RegisterStructValidation(validateCreateBatchTransfer, transfer.CreateBatchTransferRequest{})
+	return &Validator{validate: v}
+}
+
+// Validate checks a bound request. Values other than structs are not validated.
+func (v *Validator) Validate(i interface{}) error {
+	t := reflect.TypeOf(i)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	err := v.validate.Struct(i)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+	problems := make(Errors, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		problems = append(problems, toFieldError(fe))
+	}
+	return problems
+}
+
+// Binder binds requests like echo.DefaultBinder, then validates them with the server's validator,
+// so that every c.Bind call also enforces the DTO rules.
+type Binder struct {
+	echo.DefaultBinder
+}
+
+// Bind binds the request into i and validates it.
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+	if c.Echo().Validator == nil {
+		return nil
+	}
+	return c.Validate(i)
+}
+
+// BindError describes an error returned by c.Bind: validation failures list the offending fields,
+// anything else is reported as a malformed payload.
+func BindError(err error) *response.ErrorInfo {
+	var problems Errors
+	if errors.As(err, &problems) {
+		return &response.ErrorInfo{Code: 400, ErrorCode: response.CodeValidationFailed, Message: "Invalid request: " + problems.Error(), Fields: problems}
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return &response.ErrorInfo{Code: 400, Message: fmt.Sprintf("Invalid request payload: %v", httpErr.Message)}
+	}
+	return &response.ErrorInfo{Code: 400, Message: "Invalid request payload: " + err.Error()}
+}
+
+func validateBulkCreateDrug(sl validator.StructLevel) {
+	req := sl.Current().Interface().(drug.BulkCreateDrugRequest)
+	set := 0
+	if len(req.DrugsID) > 0 {
+		set++
+	}
+	if req.Range != nil {
+		set++
+	}
+	if req.Pattern != nil {
+		set++
+	}
+	if set != 1 {
+		sl.ReportError(req.DrugsID, "drugsID", "DrugsID", "exactly_one_of", "drugsID range pattern")
+	}
+}
+
+func validateCreateTransfer(sl validator.StructLevel) {
+	req := sl.Current().Interface().(transfer.CreateTransferRequest)
+	if req.AcceptDeadline != nil && req.TransferDate != nil && !req.AcceptDeadline.After(*req.TransferDate) {
+		sl.ReportError(req.AcceptDeadline, "AcceptDeadline", "AcceptDeadline", "gtfield", "TransferDate")
+	}
+}
+
+func validateCreateBatchTransfer(sl validator.StructLevel) {
+	req := sl.Current().Interface().(transfer.CreateBatchTransferRequest)
+	if req.AcceptDeadline != nil && req.TransferDate != nil && !req.AcceptDeadline.After(*req.TransferDate) {
+		sl.ReportError(req.AcceptDeadline, "AcceptDeadline", "AcceptDeadline", "gtfield", "TransferDate")
+	}
+}
+
+// toFieldError turns a validator error into the problem reported to the client.
+// The field path drops the name of the request struct, e.g. "Items[0].Quantity".
+func toFieldError(fe validator.FieldError) response.FieldError {
+	field := fe.Namespace()
+	if _, rest, ok := strings.Cut(field, "."); ok {
+		field = rest
+	}
+	message := field + " " + describe(fe)
+	if fe.Tag() == "exactly_one_of" {
+		message = "Exactly one of " + strings.ReplaceAll(fe.Param(), " ", ", ") + " must be set"
+	}
+	return response.FieldError{Field: field, Rule: fe.Tag(), Message: message}
+}
+
+func describe(fe validator.FieldError) string {
+	param := fe.Param()
+	collection := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Array || fe.Kind() == reflect.Map
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required when " + param + " is not set"
+	case "required_without_all":
+		return "is required when none of " + strings.ReplaceAll(param, " ", ", ") + " are set"
+	case "required_with":
+		return "is required when " + param + " is set"
+	case "excluded_with":
+		return "must not be set together with " + param
+	case "min":
+		if collection {
+			return "must have at least " + param + " item(s)"
+		}
+		return "must be at least " + param
+	case "max":
+		if collection {
+			return "must have at most " + param + " item(s)"
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lte":
+		return "must be at most " + param
+	case "gtfield":
+		return "must be after " + param
+	case "gtefield":
+		return "must not be before " + param
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(param, " ", ", ")
+	case "unique":
+		return "must not contain duplicates"
+	case "gtin":
+		return "must be a GTIN-8, -12, -13 or -14 with a valid check digit"
+	case "sscc":
+		return "must be an 18-digit SSCC with a valid check digit"
+	}
+	return "fails the " + fe.Tag() + " rule"
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateBatch creates a batch manufactured by the caller.
+func (c *Client) CreateBatch(ctx context.Context, req *CreateBatchRequest) (*Batch, error) {
+	return value[Batch](ctx, c, http.MethodPost, "/batches", req)
+}
+
+// GetBatch fetches a batch.
+func (c *Client) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	return value[Batch](ctx, c, http.MethodGet, pathf("/batches/%s", id), nil)
+}
+
+// ListBatches lists every batch.
+func (c *Client) ListBatches() Collection[Batch] {
+	return collection[Batch](c, "/batches")
+}
+
+// UpdateBatch updates the details of a batch.
+func (c *Client) UpdateBatch(ctx context.Context, id string, req *UpdateBatchRequest) (*Batch, error) {
+	return value[Batch](ctx, c, http.MethodPatch, pathf("/batches/%s", id), req)
+}
+
+// BatchExists reports whether a batch exists.
+func (c *Client) BatchExists(ctx context.Context, id string) (bool, error) {
+	exists, err := value[bool](ctx, c, http.MethodGet, pathf("/batches/%s/exists", id), nil)
+	if err != nil {
+		return false, err
+	}
+	return *exists, nil
+}
+
+// ListBatchHistory lists the key history of a batch.
+func (c *Client) ListBatchHistory(id string) Collection[HistoryBatch] {
+	return collection[HistoryBatch](c, pathf("/batches/%s/history", id))
+}
+
+// ListBatchChanges lists the field changes between the versions of a batch.
+func (c *Client) ListBatchChanges(id string) Collection[HistoryChange] {
+	return collection[HistoryChange](c, pathf("/batches/%s/history/changes", id))
+}
+
+// GetBatchProvenance fetches the chain of custody of the drugs of a batch.
+func (c *Client) GetBatchProvenance(ctx context.Context, id string) (*Provenance, error) {
+	return value[Provenance](ctx, c, http.MethodGet, pathf("/batches/%s/provenance", id), nil)
+}
@@ -0,0 +1,271 @@
+// Package client is a Go client for the MedTrace REST API. It uses the API's own entity and DTO types,
+// re-exported by this package, so callers do not need to declare them again.
+//
+//	c := client.New("http://localhost:8080")
+//	if _, err := c.Login(ctx, "Org1", password); err != nil { ... }
+//	for batch, err := range c.ListBatches().Iter(ctx, 100) { ... }
+//
+// The client keeps the access and refresh tokens of its organization and refreshes the access token through
+// /refresh when it is about to expire or has been rejected. Every POST, PUT, PATCH and DELETE request carries an
+// Idempotency-Key, so a request retried after a token refresh is processed at most once. Failed requests return
+// an *Error describing the API's error envelope. Requests carry the W3C trace context of their context through the
+// global OpenTelemetry propagator, so that the API's spans join the caller's trace.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/idempotency"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// DefaultRefreshMargin is how long before its expiry an access token is refreshed.
+const DefaultRefreshMargin = 30 * time.Second
+
+// Client calls the MedTrace API as one organization. It is safe for concurrent use.
+type Client struct {
+	BaseURL    string       // URL of the API, e.g. "http://localhost:8080"
+	HTTPClient *http.Client // Client used for requests; http.DefaultClient when nil
+	// RefreshMargin is how long before its expiry the access token is refreshed before a request.
+	RefreshMargin time.Duration
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	refreshMu    sync.Mutex // Serializes token refreshes
+}
+
+// New creates a Client for the API at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient, RefreshMargin: DefaultRefreshMargin}
+}
+
+// Login exchanges an organization's credentials for tokens, which the client uses from then on.
+func (c *Client) Login(ctx context.Context, orgID, password string) (*LoginResponse, error) {
+	var resp response.BaseValueResponse[LoginResponse]
+	if err := c.send(ctx, http.MethodPost, "/login", nil, auth.PayloadLogin{Organization: orgID, Password: password}, &resp, "", ""); err != nil {
+		return nil, err
+	}
+	if resp.Value == nil {
+		return nil, fmt.Errorf("login response has no tokens")
+	}
+	c.SetTokens(resp.Value.AccessToken, resp.Value.RefreshToken)
+	return resp.Value, nil
+}
+
+// Logout logs out and forgets the client's tokens.
+func (c *Client) Logout(ctx context.Context) error {
+	err := c.Do(ctx, http.MethodPost, "/logout", nil, nil, nil)
+	c.SetTokens("", "")
+	return err
+}
+
+// SetTokens sets the tokens the client authenticates with, e.g. ones saved from an earlier Login.
+func (c *Client) SetTokens(accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken, c.refreshToken = accessToken, refreshToken
+}
+
+// Tokens returns the current access and refresh tokens.
+func (c *Client) Tokens() (accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken, c.refreshToken
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context whose unsafe requests carry key as their Idempotency-Key, so that a request
+// repeated with the same key, e.g. after a crash, is processed only once. Without it every call gets a random key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// Do sends a request to the API and decodes its JSON response into out, unless out is nil.
+// It is used by the typed methods of Client and can call endpoints that have none, such as labels and EPCIS documents.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	key := ""
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		key, _ = ctx.Value(idempotencyKeyContextKey{}).(string)
+		if key == "" {
+			key = newIdempotencyKey()
+		}
+	}
+
+	token, err := c.freshAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.send(ctx, method, path, query, in, out, token, key)
+	var apiErr *Error
+	if token == "" || !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+		return err
+	}
+	// The access token was rejected, e.g. it expired early or the server restarted with another secret.
+	if _, refreshToken := c.Tokens(); refreshToken == "" {
+		return err
+	}
+	if refreshErr := c.refresh(ctx, token); refreshErr != nil {
+		return err
+	}
+	token, _ = c.Tokens()
+	return c.send(ctx, method, path, query, in, out, token, key)
+}
+
+// send makes one request with the given access token and idempotency key, which may be empty.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, in, out any, token, key string) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if key != "" {
+		req.Header.Set(idempotency.HeaderKey, key)
+	}
+	// Requests join the caller's trace, if any.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp, data)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// freshAccessToken returns the access token, refreshing it first when it expires within RefreshMargin.
+func (c *Client) freshAccessToken(ctx context.Context) (string, error) {
+	token, refreshToken := c.Tokens()
+	if token == "" || refreshToken == "" {
+		return token, nil
+	}
+	if exp, ok := tokenExpiry(token); !ok || time.Until(exp) > c.RefreshMargin {
+		return token, nil
+	}
+	if err := c.refresh(ctx, token); err != nil {
+		return "", err
+	}
+	token, _ = c.Tokens()
+	return token, nil
+}
+
+// refresh replaces the access token stale with a new one from /refresh. Concurrent callers holding the same
+// stale token share one refresh.
+func (c *Client) refresh(ctx context.Context, stale string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	token, refreshToken := c.Tokens()
+	if token != stale {
+		return nil
+	}
+	var resp response.BaseValueResponse[RefreshTokenResponse]
+	if err := c.send(ctx, http.MethodPost, "/refresh", nil, auth.PayloadRefreshToken{RefreshToken: refreshToken}, &resp, "", ""); err != nil {
+		return fmt.Errorf("refresh access token: %w", err)
+	}
+	if resp.Value == nil || resp.Value.AccessToken == "" {
+		return fmt.Errorf("refresh access token: response has no token")
+	}
+	c.mu.Lock()
+	c.accessToken = resp.Value.AccessToken
+	c.mu.Unlock()
+	return nil
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying it; only the server can verify its tokens.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// value sends a request whose response is a BaseValueResponse and returns its value.
+func value[T any](ctx context.Context, c *Client, method, path string, in any) (*T, error) {
+	var resp response.BaseValueResponse[T]
+	if err := c.Do(ctx, method, path, nil, in, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Value == nil {
+		return nil, fmt.Errorf("response of %s %s has no value", method, path)
+	}
+	return resp.Value, nil
+}
+
+// pathf builds a request path, escaping each argument as a path segment.
+func pathf(format string, args ...string) string {
+	escaped := make([]any, len(args))
+	for i, a := range args {
+		escaped[i] = url.PathEscape(a)
+	}
+	return fmt.Sprintf(format, escaped...)
+}
@@ -0,0 +1,294 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/idempotency"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+// fakeAPI is a minimal MedTrace API: login, refresh, a paginated batch list and drug creation.
+type fakeAPI struct {
+	t       *testing.T
+	batches []*Batch
+
+	mu        sync.Mutex
+	token     string   // Access token the server accepts
+	refreshes int      // Calls of /refresh
+	keys      []string // Idempotency-Key of every POST /drugs, in order
+	pages     []string // Query of every GET /batches, in order
+}
+
+// jwt returns an unsigned token expiring at exp; the client only reads its exp claim.
+func jwt(exp time.Time) string {
+	enc := base64.RawURLEncoding
+	payload := fmt.Sprintf(`{"exp":%d}`, exp.Unix())
+	return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *Client) {
+	api := &fakeAPI{t: t}
+	for i := 0; i < 7; i++ {
+		api.batches = append(api.batches, &Batch{ID: "BATCH-" + strconv.Itoa(i)})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", api.login)
+	mux.HandleFunc("POST /refresh", api.refresh)
+	mux.HandleFunc("GET /batches", api.authenticated(api.listBatches))
+	mux.HandleFunc("POST /drugs", api.authenticated(api.createDrug))
+	mux.HandleFunc("GET /drugs/{id}", api.authenticated(api.getDrug))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return api, New(server.URL)
+}
+
+func (api *fakeAPI) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		api.t.Errorf("encode response: %v", err)
+	}
+}
+
+func (api *fakeAPI) writeError(w http.ResponseWriter, status int, errorCode, message string) {
+	w.Header().Set("X-Request-ID", "req-1")
+	api.writeJSON(w, status, response.BaseResponse{Error: &response.ErrorInfo{Code: status, ErrorCode: errorCode, Message: message}})
+}
+
+func (api *fakeAPI) issue(exp time.Time) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.token = jwt(exp)
+	return api.token
+}
+
+func (api *fakeAPI) login(w http.ResponseWriter, r *http.Request) {
+	var req auth.PayloadLogin
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password != "secret" {
+		api.writeError(w, http.StatusUnauthorized, CodeUnauthorized, "Invalid credentials")
+		return
+	}
+	token := api.issue(time.Now().Add(time.Hour))
+	api.writeJSON(w, http.StatusOK, response.SuccessValueResponse(LoginResponse{AccessToken: token, RefreshToken: "refresh-1", OrgID: req.Organization}))
+}
+
+func (api *fakeAPI) refresh(w http.ResponseWriter, r *http.Request) {
+	var req auth.PayloadRefreshToken
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken != "refresh-1" {
+		api.writeError(w, http.StatusUnauthorized, CodeUnauthorized, "Invalid refresh token")
+		return
+	}
+	api.mu.Lock()
+	api.refreshes++
+	api.mu.Unlock()
+	// Tokens differ by their expiry, so each refresh gets a later one.
+	token := api.issue(time.Now().Add(time.Hour + time.Duration(api.refreshes)*time.Second))
+	api.writeJSON(w, http.StatusOK, response.SuccessValueResponse(RefreshTokenResponse{AccessToken: token}))
+}
+
+func (api *fakeAPI) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		token := api.token
+		api.mu.Unlock()
+		if token == "" || r.Header.Get("Authorization") != "Bearer "+token {
+			api.writeError(w, http.StatusUnauthorized, CodeUnauthorized, "Invalid or expired token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (api *fakeAPI) listBatches(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	api.pages = append(api.pages, r.URL.RawQuery)
+	api.mu.Unlock()
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	end := min(offset+limit, len(api.batches))
+	api.writeJSON(w, http.StatusOK, response.BaseListResponse[Batch]{
+		Success: true,
+		List:    api.batches[offset:end],
+		Page:    &response.Page{Offset: offset, Limit: limit, Total: len(api.batches)},
+	})
+}
+
+func (api *fakeAPI) createDrug(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	api.keys = append(api.keys, r.Header.Get(idempotency.HeaderKey))
+	api.mu.Unlock()
+	api.writeJSON(w, http.StatusCreated, response.SuccessValueResponse("DRUG-1"))
+}
+
+func (api *fakeAPI) getDrug(w http.ResponseWriter, r *http.Request) {
+	switch r.PathValue("id") {
+	case "missing":
+		api.writeError(w, http.StatusNotFound, CodeNotFound, "Drug missing not found")
+	default:
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream unavailable")
+	}
+}
+
+func TestLogin(t *testing.T) {
+	api, c := newFakeAPI(t)
+	ctx := context.Background()
+
+	if _, err := c.Login(ctx, "Org1", "wrong"); ErrorCode(err) != CodeUnauthorized {
+		t.Fatalf("Login with a wrong password: got %v, want %s", err, CodeUnauthorized)
+	}
+	resp, err := c.Login(ctx, "Org1", "secret")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.OrgID != "Org1" {
+		t.Errorf("OrgID = %q, want Org1", resp.OrgID)
+	}
+	access, refresh := c.Tokens()
+	if access != api.token || refresh != "refresh-1" {
+		t.Errorf("Tokens() = %q, %q; want the tokens of the login response", access, refresh)
+	}
+	if _, err := c.CreateDrug(ctx, &CreateDrugRequest{}); err != nil {
+		t.Fatalf("CreateDrug after Login: %v", err)
+	}
+}
+
+func TestRefreshBeforeExpiry(t *testing.T) {
+	api, c := newFakeAPI(t)
+	ctx := context.Background()
+	c.SetTokens(api.issue(time.Now().Add(10*time.Second)), "refresh-1")
+
+	if _, err := c.ListBatches().All(ctx); err != nil {
+		t.Fatalf("ListBatches: %v", err)
+	}
+	if api.refreshes != 1 {
+		t.Errorf("%d refreshes, want 1 for a token expiring within RefreshMargin", api.refreshes)
+	}
+	if access, _ := c.Tokens(); access != api.token {
+		t.Errorf("client kept the stale access token")
+	}
+
+	if _, err := c.ListBatches().All(ctx); err != nil {
+		t.Fatalf("ListBatches: %v", err)
+	}
+	if api.refreshes != 1 {
+		t.Errorf("%d refreshes, want no refresh of a fresh token", api.refreshes)
+	}
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	api, c := newFakeAPI(t)
+	ctx := context.Background()
+	api.issue(time.Now().Add(time.Hour))
+	// The client's token looks valid for another hour, but the server no longer accepts it.
+	c.SetTokens(jwt(time.Now().Add(time.Hour+time.Minute)), "refresh-1")
+
+	if _, err := c.CreateDrug(ctx, &CreateDrugRequest{}); err != nil {
+		t.Fatalf("CreateDrug: %v", err)
+	}
+	if api.refreshes != 1 {
+		t.Errorf("%d refreshes, want 1 after a 401", api.refreshes)
+	}
+	if len(api.keys) != 1 || api.keys[0] == "" {
+		t.Fatalf("Idempotency-Key of the retried request: %q", api.keys)
+	}
+
+	c.SetTokens("", "refresh-1")
+	if _, err := c.CreateDrug(ctx, &CreateDrugRequest{}); ErrorCode(err) != CodeUnauthorized {
+		t.Errorf("CreateDrug without an access token: got %v, want %s", err, CodeUnauthorized)
+	}
+}
+
+func TestIterPaginates(t *testing.T) {
+	api, c := newFakeAPI(t)
+	c.SetTokens(api.issue(time.Now().Add(time.Hour)), "refresh-1")
+
+	var ids []string
+	for batch, err := range c.ListBatches().Iter(context.Background(), 3) {
+		if err != nil {
+			t.Fatalf("Iter: %v", err)
+		}
+		ids = append(ids, batch.ID)
+	}
+	if len(ids) != len(api.batches) {
+		t.Fatalf("Iter yielded %d batches, want %d", len(ids), len(api.batches))
+	}
+	for i, id := range ids {
+		if id != api.batches[i].ID {
+			t.Errorf("batch %d = %s, want %s", i, id, api.batches[i].ID)
+		}
+	}
+	want := []string{"limit=3&offset=0", "limit=3&offset=3", "limit=3&offset=6"}
+	if fmt.Sprint(api.pages) != fmt.Sprint(want) {
+		t.Errorf("pages requested = %v, want %v", api.pages, want)
+	}
+
+	api.pages = nil
+	for range c.ListBatches().Iter(context.Background(), 3) {
+		break
+	}
+	if len(api.pages) != 1 {
+		t.Errorf("Iter fetched %d pages after the caller stopped on the first item, want 1", len(api.pages))
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	api, c := newFakeAPI(t)
+	ctx := context.Background()
+	c.SetTokens(api.issue(time.Now().Add(time.Hour)), "refresh-1")
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.CreateDrug(ctx, &CreateDrugRequest{}); err != nil {
+			t.Fatalf("CreateDrug: %v", err)
+		}
+	}
+	if _, err := c.CreateDrug(WithIdempotencyKey(ctx, "order-42"), &CreateDrugRequest{}); err != nil {
+		t.Fatalf("CreateDrug: %v", err)
+	}
+	if len(api.keys) != 3 {
+		t.Fatalf("%d requests, want 3", len(api.keys))
+	}
+	if api.keys[0] == "" || api.keys[0] == api.keys[1] {
+		t.Errorf("generated keys %q and %q should be set and distinct", api.keys[0], api.keys[1])
+	}
+	if api.keys[2] != "order-42" {
+		t.Errorf("key = %q, want the key of the context", api.keys[2])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	api, c := newFakeAPI(t)
+	ctx := context.Background()
+	c.SetTokens(api.issue(time.Now().Add(time.Hour)), "refresh-1")
+
+	_, err := c.GetDrug(ctx, "missing")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetDrug: got %v (%T), want *Error", err, err)
+	}
+	if apiErr.Code != http.StatusNotFound || apiErr.ErrorCode != CodeNotFound || apiErr.Message != "Drug missing not found" || apiErr.RequestID != "req-1" {
+		t.Errorf("error = %+v", apiErr)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false", err)
+	}
+
+	_, err = c.GetDrug(ctx, "proxy")
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetDrug: got %v (%T), want *Error", err, err)
+	}
+	if apiErr.Code != http.StatusBadGateway || apiErr.ErrorCode != CodeInternal || apiErr.Message != "upstream unavailable" {
+		t.Errorf("error of a response without envelope = %+v", apiErr)
+	}
+}
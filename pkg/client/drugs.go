@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateDrug creates a drug of a batch and returns its ID.
+func (c *Client) CreateDrug(ctx context.Context, req *CreateDrugRequest) (string, error) {
+	id, err := value[string](ctx, c, http.MethodPost, "/drugs", req)
+	if err != nil {
+		return "", err
+	}
+	return *id, nil
+}
+
+// CreateDrugsBulk starts a background job creating many drugs of a batch. Follow it with GetBulkJob.
+func (c *Client) CreateDrugsBulk(ctx context.Context, req *BulkCreateDrugRequest) (*SerializationJob, error) {
+	return value[SerializationJob](ctx, c, http.MethodPost, "/drugs/bulk", req)
+}
+
+// GetBulkJob fetches the progress of a bulk serialization job.
+func (c *Client) GetBulkJob(ctx context.Context, jobID string) (*SerializationJob, error) {
+	return value[SerializationJob](ctx, c, http.MethodGet, pathf("/drugs/bulk/%s", jobID), nil)
+}
+
+// ResumeBulkJob resumes a bulk serialization job that stopped before completing.
+func (c *Client) ResumeBulkJob(ctx context.Context, jobID string) (*SerializationJob, error) {
+	return value[SerializationJob](ctx, c, http.MethodPost, pathf("/drugs/bulk/%s/resume", jobID), nil)
+}
+
+// GetDrug fetches a drug by ID, bracketed GS1 element string or Digital Link URI.
+func (c *Client) GetDrug(ctx context.Context, drugID string) (*Drug, error) {
+	return value[Drug](ctx, c, http.MethodGet, pathf("/drugs/%s", drugID), nil)
+}
+
+// ListMyDrugs lists the drugs owned by the caller.
+func (c *Client) ListMyDrugs() Collection[Drug] {
+	return collection[Drug](c, "/drugs/my")
+}
+
+// ListMyAvailableDrugs lists the caller's drugs that are not part of a pending transfer.
+func (c *Client) ListMyAvailableDrugs() Collection[Drug] {
+	return collection[Drug](c, "/drugs/my/available")
+}
+
+// ListDrugsByBatch lists the drugs of a batch.
+func (c *Client) ListDrugsByBatch(batchID string) Collection[Drug] {
+	return collection[Drug](c, pathf("/drugs/batch/%s", batchID))
+}
+
+// ListDrugsByTransfer lists the drugs of a transfer.
+func (c *Client) ListDrugsByTransfer(transferID string) Collection[Drug] {
+	return collection[Drug](c, pathf("/drugs/transfer/%s", transferID))
+}
+
+// ListDrugHistory lists the key history of a drug.
+func (c *Client) ListDrugHistory(drugID string) Collection[HistoryDrug] {
+	return collection[HistoryDrug](c, pathf("/drugs/history/%s", drugID))
+}
+
+// ListDrugChanges lists the field changes between the versions of a drug.
+func (c *Client) ListDrugChanges(drugID string) Collection[HistoryChange] {
+	return collection[HistoryChange](c, pathf("/drugs/history/%s/changes", drugID))
+}
+
+// GetVerifiableDrugHistory fetches the history of a drug with the proof of every transaction behind it,
+// to be checked offline with the verifier package.
+func (c *Client) GetVerifiableDrugHistory(ctx context.Context, drugID string) (*VerifiableHistory, error) {
+	return value[VerifiableHistory](ctx, c, http.MethodGet, pathf("/drugs/history/%s/verification", drugID), nil)
+}
+
+// GetDrugProvenance fetches the chain of custody of a drug.
+func (c *Client) GetDrugProvenance(ctx context.Context, drugID string) (*Provenance, error) {
+	return value[Provenance](ctx, c, http.MethodGet, pathf("/drugs/%s/provenance", drugID), nil)
+}
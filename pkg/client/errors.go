@@ -0,0 +1,76 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+// Error is a request the API refused or failed, as described by its error envelope: the HTTP status (Code),
+// a stable ErrorCode, a message, the fields that failed validation and the ID of the request.
+type Error = response.ErrorInfo
+
+// FieldError is a validation problem with one field of a request.
+type FieldError = response.FieldError
+
+// Stable error codes of Error.ErrorCode.
+const (
+	CodeBadRequest           = response.CodeBadRequest
+	CodeValidationFailed     = response.CodeValidationFailed
+	CodeUnauthorized         = response.CodeUnauthorized
+	CodeForbidden            = response.CodeForbidden
+	CodeNotFound             = response.CodeNotFound
+	CodeMethodNotAllowed     = response.CodeMethodNotAllowed
+	CodeConflict             = response.CodeConflict
+	CodeRequestTooLarge      = response.CodeRequestTooLarge
+	CodeUnsupportedMediaType = response.CodeUnsupportedMediaType
+	CodeTooManyRequests      = response.CodeTooManyRequests
+	CodeInternal             = response.CodeInternal
+	CodeUnavailable          = response.CodeUnavailable
+)
+
+// ErrorCode returns the stable error code of an error returned by the client, or "" when the request
+// did not reach the API or its response could not be read.
+func ErrorCode(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is the API's answer that a resource does not exist.
+func IsNotFound(err error) bool {
+	return ErrorCode(err) == CodeNotFound
+}
+
+// decodeError reads the error envelope of a failed response. Responses that are not an envelope,
+// e.g. from a proxy in front of the API, are described by their status.
+func decodeError(resp *http.Response, data []byte) error {
+	var envelope response.BaseResponse
+	if err := json.Unmarshal(data, &envelope); err == nil && envelope.Error != nil {
+		if envelope.Error.Code == 0 {
+			envelope.Error.Code = resp.StatusCode
+		}
+		if envelope.Error.ErrorCode == "" {
+			envelope.Error.ErrorCode = response.DefaultErrorCode(resp.StatusCode)
+		}
+		if envelope.Error.RequestID == "" {
+			envelope.Error.RequestID = resp.Header.Get("X-Request-ID")
+		}
+		return envelope.Error
+	}
+	message := strings.TrimSpace(string(data))
+	if message == "" || len(message) > 512 {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &Error{
+		Code:      resp.StatusCode,
+		ErrorCode: response.DefaultErrorCode(resp.StatusCode),
+		Message:   message,
+		RequestID: resp.Header.Get("X-Request-ID"),
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+)
+
+// DefaultPageSize is the page size Iter uses when given none.
+const DefaultPageSize = 100
+
+// Collection is a list endpoint of the API. The whole list can be fetched at once with All,
+// a page at a time with Page, or iterated over with Iter, which fetches the pages as it goes.
+type Collection[T any] struct {
+	client *Client
+	path   string
+}
+
+func collection[T any](c *Client, path string) Collection[T] {
+	return Collection[T]{client: c, path: path}
+}
+
+// PageOf is one page of a list.
+type PageOf[T any] struct {
+	Items []*T
+	Page
+}
+
+// Next returns the offset of the page after this one, or false when this is the last page.
+func (p *PageOf[T]) Next() (int, bool) {
+	next := p.Offset + len(p.Items)
+	return next, len(p.Items) > 0 && next < p.Total
+}
+
+// All fetches the whole list in one request.
+func (l Collection[T]) All(ctx context.Context) ([]*T, error) {
+	var resp response.BaseListResponse[T]
+	if err := l.client.Do(ctx, http.MethodGet, l.path, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.List, nil
+}
+
+// Page fetches at most limit items starting at offset.
+func (l Collection[T]) Page(ctx context.Context, offset, limit int) (*PageOf[T], error) {
+	query := url.Values{"offset": {strconv.Itoa(offset)}, "limit": {strconv.Itoa(limit)}}
+	var resp response.BaseListResponse[T]
+	if err := l.client.Do(ctx, http.MethodGet, l.path, query, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Page == nil {
+		return nil, fmt.Errorf("response of GET %s is not paginated", l.path)
+	}
+	return &PageOf[T]{Items: resp.List, Page: *resp.Page}, nil
+}
+
+// Iter iterates over the whole list, fetching pages of pageSize items (DefaultPageSize when 0) as needed.
+// It stops after the first error, which it yields with a nil item.
+func (l Collection[T]) Iter(ctx context.Context, pageSize int) iter.Seq2[*T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(*T, error) bool) {
+		offset := 0
+		for {
+			page, err := l.Page(ctx, offset, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			next, ok := page.Next()
+			if !ok {
+				return
+			}
+			offset = next
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetOrganization fetches an organization.
+func (c *Client) GetOrganization(ctx context.Context, id string) (*Organization, error) {
+	return value[Organization](ctx, c, http.MethodGet, pathf("/organizations/%s", id), nil)
+}
+
+// ListOrganizations lists every organization.
+func (c *Client) ListOrganizations() Collection[Organization] {
+	return collection[Organization](c, "/organizations")
+}
+
+// ListOrganizationHistory lists the key history of an organization.
+func (c *Client) ListOrganizationHistory(id string) Collection[HistoryOrganization] {
+	return collection[HistoryOrganization](c, pathf("/organizations/%s/history", id))
+}
+
+// ListOrganizationChanges lists the field changes between the versions of an organization.
+func (c *Client) ListOrganizationChanges(id string) Collection[HistoryChange] {
+	return collection[HistoryChange](c, pathf("/organizations/%s/history/changes", id))
+}
+
+// RegisterOrganization registers a new organization. The caller must be an administrator.
+func (c *Client) RegisterOrganization(ctx context.Context, req *RegisterOrganizationRequest) (*Organization, error) {
+	return value[Organization](ctx, c, http.MethodPost, "/organizations", req)
+}
+
+// UpdateOrganization updates the profile of an organization. The caller must be an administrator.
+func (c *Client) UpdateOrganization(ctx context.Context, id string, req *UpdateOrganizationRequest) (*Organization, error) {
+	return value[Organization](ctx, c, http.MethodPatch, pathf("/organizations/%s", id), req)
+}
+
+// DeactivateOrganization deactivates an organization. The caller must be an administrator.
+func (c *Client) DeactivateOrganization(ctx context.Context, id string) (*Organization, error) {
+	return value[Organization](ctx, c, http.MethodPost, pathf("/organizations/%s/deactivate", id), nil)
+}
+
+// ListPartners lists the caller's trading partners.
+func (c *Client) ListPartners() Collection[TradingPartner] {
+	return collection[TradingPartner](c, "/partners")
+}
+
+// GetPartner fetches one of the caller's trading partners.
+func (c *Client) GetPartner(ctx context.Context, partnerID string) (*TradingPartner, error) {
+	return value[TradingPartner](ctx, c, http.MethodGet, pathf("/partners/%s", partnerID), nil)
+}
+
+// UpsertPartner adds a trading partner or updates its license and status.
+func (c *Client) UpsertPartner(ctx context.Context, partnerID string, req *UpsertPartnerRequest) (*TradingPartner, error) {
+	return value[TradingPartner](ctx, c, http.MethodPut, pathf("/partners/%s", partnerID), req)
+}
+
+// RemovePartner removes a trading partner.
+func (c *Client) RemovePartner(ctx context.Context, partnerID string) (*TradingPartner, error) {
+	return value[TradingPartner](ctx, c, http.MethodDelete, pathf("/partners/%s", partnerID), nil)
+}
+
+// InitLedger writes initial data to the ledger: seed, or the server's own seed data when seed is nil.
+// Only administrators of a server in development mode may initialize the ledger.
+func (c *Client) InitLedger(ctx context.Context, seed *Seed) (*LedgerInitRecord, error) {
+	var body any
+	if seed != nil {
+		body = seed
+	}
+	return value[LedgerInitRecord](ctx, c, http.MethodPost, "/ledger/init", body)
+}
+
+// ListLedgerInitAudit lists the requests to initialize the ledger, most recent first.
+func (c *Client) ListLedgerInitAudit() Collection[LedgerInitRecord] {
+	return collection[LedgerInitRecord](c, "/ledger/init/audit")
+}
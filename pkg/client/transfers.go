@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateTransfer offers drugs to a receiver.
+func (c *Client) CreateTransfer(ctx context.Context, req *CreateTransferRequest) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodPost, "/transfers", req)
+}
+
+// CreateTransferByBatch offers quantities of batches or drugs to a receiver, letting the API pick the units.
+func (c *Client) CreateTransferByBatch(ctx context.Context, req *CreateBatchTransferRequest) (*TransferAllocation, error) {
+	return value[TransferAllocation](ctx, c, http.MethodPost, "/transfers/batch", req)
+}
+
+// GetTransfer fetches a transfer.
+func (c *Client) GetTransfer(ctx context.Context, id string) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodGet, pathf("/transfers/%s", id), nil)
+}
+
+// ListMyTransfers lists the transfers the caller sent or received.
+func (c *Client) ListMyTransfers() Collection[Transfer] {
+	return collection[Transfer](c, "/transfers/my")
+}
+
+// ListMyOutgoingTransfers lists the transfers the caller sent.
+func (c *Client) ListMyOutgoingTransfers() Collection[Transfer] {
+	return collection[Transfer](c, "/transfers/my/outgoing")
+}
+
+// ListMyIncomingTransfers lists the transfers the caller received.
+func (c *Client) ListMyIncomingTransfers() Collection[Transfer] {
+	return collection[Transfer](c, "/transfers/my/incoming")
+}
+
+// AcceptTransfer accepts every drug of a transfer.
+func (c *Client) AcceptTransfer(ctx context.Context, req *ProcessTransferRequest) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodPost, "/transfers/accept", req)
+}
+
+// AcceptTransferPartial accepts some drugs of a transfer and reports a discrepancy for the others.
+func (c *Client) AcceptTransferPartial(ctx context.Context, req *PartialAcceptTransferRequest) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodPost, "/transfers/accept/partial", req)
+}
+
+// RejectTransfer rejects a transfer.
+func (c *Client) RejectTransfer(ctx context.Context, req *ProcessTransferRequest) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodPost, "/transfers/reject", req)
+}
+
+// CancelTransfer cancels a pending transfer sent by the caller.
+func (c *Client) CancelTransfer(ctx context.Context, id string) (*Transfer, error) {
+	return value[Transfer](ctx, c, http.MethodPost, pathf("/transfers/%s/cancel", id), nil)
+}
+
+// GetTransferDiscrepancy fetches the discrepancy report of a partially accepted transfer.
+func (c *Client) GetTransferDiscrepancy(ctx context.Context, id string) (*TransferDiscrepancy, error) {
+	return value[TransferDiscrepancy](ctx, c, http.MethodGet, pathf("/transfers/%s/discrepancy", id), nil)
+}
+
+// ListTransferHistory lists the key history of a transfer.
+func (c *Client) ListTransferHistory(id string) Collection[HistoryTransfer] {
+	return collection[HistoryTransfer](c, pathf("/transfers/%s/history", id))
+}
+
+// ListTransferChanges lists the field changes between the versions of a transfer.
+func (c *Client) ListTransferChanges(id string) Collection[HistoryChange] {
+	return collection[HistoryChange](c, pathf("/transfers/%s/history/changes", id))
+}
+
+// GetTransferProvenance fetches the chain of custody of the drugs of a transfer.
+func (c *Client) GetTransferProvenance(ctx context.Context, id string) (*Provenance, error) {
+	return value[Provenance](ctx, c, http.MethodGet, pathf("/transfers/%s/provenance", id), nil)
+}
+
+// GetTransferT3 fetches the T3 documents of a transfer: its transaction information, history and statement.
+// The sender's first call issues them; until then the receiver gets an *Error with Code 409.
+func (c *Client) GetTransferT3(ctx context.Context, id string) (*T3Document, error) {
+	return value[T3Document](ctx, c, http.MethodGet, pathf("/transfers/%s/t3", id), nil)
+}
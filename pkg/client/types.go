@@ -0,0 +1,83 @@
+package client
+
+import (
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/auth"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/batch"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/drug"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/ledger"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/organization"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/partner"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/dto/transfer"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/entity"
+	"github.com/AryaJayadi/MedTrace_api/internal/models/response"
+	"github.com/AryaJayadi/MedTrace_api/internal/utils"
+)
+
+// Resources returned by the API.
+type (
+	Organization        = entity.Organization
+	Batch               = entity.Batch
+	Drug                = entity.Drug
+	Transfer            = entity.Transfer
+	TransferAllocation  = entity.TransferAllocation
+	AllocatedBatch      = entity.AllocatedBatch
+	TransferDiscrepancy = entity.TransferDiscrepancy
+	DiscrepancyItem     = entity.DiscrepancyItem
+	TradingPartner      = entity.TradingPartner
+	SerializationJob    = entity.SerializationJob
+	SerializationChunk  = entity.SerializationChunk
+	LedgerInitRecord    = entity.LedgerInitRecord
+	Provenance          = entity.Provenance
+	T3Document          = entity.T3Document
+
+	HistoryOrganization = entity.HistoryOrganization
+	HistoryBatch        = entity.HistoryBatch
+	HistoryDrug         = entity.HistoryDrug
+	HistoryTransfer     = entity.HistoryTransfer
+	HistoryChange       = entity.HistoryChange
+	FieldChange         = entity.FieldChange
+	VerifiableHistory   = entity.VerifiableHistory
+
+	// OptionalTime is a time that is null in JSON when unset.
+	OptionalTime = utils.OptionalTime
+	// Page locates a page of a list within the whole list.
+	Page = response.Page
+)
+
+// Requests sent to the API.
+type (
+	RegisterOrganizationRequest  = organization.RegisterOrganization
+	UpdateOrganizationRequest    = organization.UpdateOrganization
+	CreateBatchRequest           = batch.CreateBatch
+	UpdateBatchRequest           = batch.UpdateBatch
+	CreateDrugRequest            = drug.CreateDrugRequest
+	BulkCreateDrugRequest        = drug.BulkCreateDrugRequest
+	SerialRange                  = drug.SerialRange
+	SerialPattern                = drug.SerialPattern
+	CreateTransferRequest        = transfer.CreateTransferRequest
+	CreateBatchTransferRequest   = transfer.CreateBatchTransferRequest
+	BatchAllocation              = transfer.BatchAllocation
+	ProcessTransferRequest       = transfer.ProcessTransferRequest
+	PartialAcceptTransferRequest = transfer.PartialAcceptTransferRequest
+	DrugDiscrepancy              = transfer.DrugDiscrepancy
+	UpsertPartnerRequest         = partner.UpsertPartner
+	Seed                         = ledger.Seed
+	SeedBatch                    = ledger.SeedBatch
+	SeedDrugs                    = ledger.SeedDrugs
+
+	LoginResponse        = auth.LoginResponseData
+	RefreshTokenResponse = auth.RefreshTokenResponseData
+)
+
+// Allocation strategies of CreateBatchTransferRequest.Strategy.
+const (
+	AllocationFEFO = transfer.AllocationFEFO
+	AllocationFIFO = transfer.AllocationFIFO
+)
+
+// Discrepancy types of DrugDiscrepancy.Type.
+const (
+	DiscrepancyMissing = transfer.DiscrepancyMissing
+	DiscrepancyDamaged = transfer.DiscrepancyDamaged
+	DiscrepancySurplus = transfer.DiscrepancySurplus
+)
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: medtrace/v1/batch.proto
+
+package medtracev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BatchService_CreateBatch_FullMethodName = "/medtrace.v1.BatchService/CreateBatch"
+	BatchService_GetBatch_FullMethodName    = "/medtrace.v1.BatchService/GetBatch"
+	BatchService_ListBatches_FullMethodName = "/medtrace.v1.BatchService/ListBatches"
+	BatchService_UpdateBatch_FullMethodName = "/medtrace.v1.BatchService/UpdateBatch"
+	BatchService_BatchExists_FullMethodName = "/medtrace.v1.BatchService/BatchExists"
+)
+
+// BatchServiceClient is the client API for BatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BatchService manages production batches of the calling manufacturer.
+type BatchServiceClient interface {
+	// CreateBatch creates a batch together with its drugs.
+	CreateBatch(ctx context.Context, in *CreateBatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	ListBatches(ctx context.Context, in *ListBatchesRequest, opts ...grpc.CallOption) (*ListBatchesResponse, error)
+	UpdateBatch(ctx context.Context, in *UpdateBatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	BatchExists(ctx context.Context, in *BatchExistsRequest, opts ...grpc.CallOption) (*BatchExistsResponse, error)
+}
+
+type batchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBatchServiceClient(cc grpc.ClientConnInterface) BatchServiceClient {
+	return &batchServiceClient{cc}
+}
+
+func (c *batchServiceClient) CreateBatch(ctx context.Context, in *CreateBatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, BatchService_CreateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchServiceClient) GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, BatchService_GetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchServiceClient) ListBatches(ctx context.Context, in *ListBatchesRequest, opts ...grpc.CallOption) (*ListBatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBatchesResponse)
+	err := c.cc.Invoke(ctx, BatchService_ListBatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchServiceClient) UpdateBatch(ctx context.Context, in *UpdateBatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, BatchService_UpdateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *batchServiceClient) BatchExists(ctx context.Context, in *BatchExistsRequest, opts ...grpc.CallOption) (*BatchExistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchExistsResponse)
+	err := c.cc.Invoke(ctx, BatchService_BatchExists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BatchServiceServer is the server API for BatchService service.
+// All implementations must embed UnimplementedBatchServiceServer
+// for forward compatibility.
+//
+// BatchService manages production batches of the calling manufacturer.
+type BatchServiceServer interface {
+	// CreateBatch creates a batch together with its drugs.
+	CreateBatch(context.Context, *CreateBatchRequest) (*Batch, error)
+	GetBatch(context.Context, *GetBatchRequest) (*Batch, error)
+	ListBatches(context.Context, *ListBatchesRequest) (*ListBatchesResponse, error)
+	UpdateBatch(context.Context, *UpdateBatchRequest) (*Batch, error)
+	BatchExists(context.Context, *BatchExistsRequest) (*BatchExistsResponse, error)
+	mustEmbedUnimplementedBatchServiceServer()
+}
+
+// UnimplementedBatchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBatchServiceServer struct{}
+
+func (UnimplementedBatchServiceServer) CreateBatch(context.Context, *CreateBatchRequest) (*Batch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatch not implemented")
+}
+func (UnimplementedBatchServiceServer) GetBatch(context.Context, *GetBatchRequest) (*Batch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedBatchServiceServer) ListBatches(context.Context, *ListBatchesRequest) (*ListBatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBatches not implemented")
+}
+func (UnimplementedBatchServiceServer) UpdateBatch(context.Context, *UpdateBatchRequest) (*Batch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBatch not implemented")
+}
+func (UnimplementedBatchServiceServer) BatchExists(context.Context, *BatchExistsRequest) (*BatchExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchExists not implemented")
+}
+func (UnimplementedBatchServiceServer) mustEmbedUnimplementedBatchServiceServer() {}
+func (UnimplementedBatchServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeBatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BatchServiceServer will
+// result in compilation errors.
+type UnsafeBatchServiceServer interface {
+	mustEmbedUnimplementedBatchServiceServer()
+}
+
+func RegisterBatchServiceServer(s grpc.ServiceRegistrar, srv BatchServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBatchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BatchService_ServiceDesc, srv)
+}
+
+func _BatchService_CreateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServiceServer).CreateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchService_CreateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServiceServer).CreateBatch(ctx, req.(*CreateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BatchService_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServiceServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchService_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServiceServer).GetBatch(ctx, req.(*GetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BatchService_ListBatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServiceServer).ListBatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchService_ListBatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServiceServer).ListBatches(ctx, req.(*ListBatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BatchService_UpdateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServiceServer).UpdateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchService_UpdateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServiceServer).UpdateBatch(ctx, req.(*UpdateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BatchService_BatchExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServiceServer).BatchExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchService_BatchExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServiceServer).BatchExists(ctx, req.(*BatchExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BatchService_ServiceDesc is the grpc.ServiceDesc for BatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "medtrace.v1.BatchService",
+	HandlerType: (*BatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBatch",
+			Handler:    _BatchService_CreateBatch_Handler,
+		},
+		{
+			MethodName: "GetBatch",
+			Handler:    _BatchService_GetBatch_Handler,
+		},
+		{
+			MethodName: "ListBatches",
+			Handler:    _BatchService_ListBatches_Handler,
+		},
+		{
+			MethodName: "UpdateBatch",
+			Handler:    _BatchService_UpdateBatch_Handler,
+		},
+		{
+			MethodName: "BatchExists",
+			Handler:    _BatchService_BatchExists_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "medtrace/v1/batch.proto",
+}
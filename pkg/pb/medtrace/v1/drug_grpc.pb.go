@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: medtrace/v1/drug.proto
+
+package medtracev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DrugService_CreateDrug_FullMethodName          = "/medtrace.v1.DrugService/CreateDrug"
+	DrugService_GetDrug_FullMethodName             = "/medtrace.v1.DrugService/GetDrug"
+	DrugService_ListMyDrugs_FullMethodName         = "/medtrace.v1.DrugService/ListMyDrugs"
+	DrugService_ListDrugsByBatch_FullMethodName    = "/medtrace.v1.DrugService/ListDrugsByBatch"
+	DrugService_ListDrugsByTransfer_FullMethodName = "/medtrace.v1.DrugService/ListDrugsByTransfer"
+)
+
+// DrugServiceClient is the client API for DrugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DrugService manages serialized drugs. Lists are streamed one drug per message.
+type DrugServiceClient interface {
+	CreateDrug(ctx context.Context, in *CreateDrugRequest, opts ...grpc.CallOption) (*CreateDrugResponse, error)
+	GetDrug(ctx context.Context, in *GetDrugRequest, opts ...grpc.CallOption) (*Drug, error)
+	// ListMyDrugs streams the drugs owned by the caller.
+	ListMyDrugs(ctx context.Context, in *ListMyDrugsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error)
+	ListDrugsByBatch(ctx context.Context, in *ListDrugsByBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error)
+	ListDrugsByTransfer(ctx context.Context, in *ListDrugsByTransferRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error)
+}
+
+type drugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDrugServiceClient(cc grpc.ClientConnInterface) DrugServiceClient {
+	return &drugServiceClient{cc}
+}
+
+func (c *drugServiceClient) CreateDrug(ctx context.Context, in *CreateDrugRequest, opts ...grpc.CallOption) (*CreateDrugResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateDrugResponse)
+	err := c.cc.Invoke(ctx, DrugService_CreateDrug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drugServiceClient) GetDrug(ctx context.Context, in *GetDrugRequest, opts ...grpc.CallOption) (*Drug, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Drug)
+	err := c.cc.Invoke(ctx, DrugService_GetDrug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drugServiceClient) ListMyDrugs(ctx context.Context, in *ListMyDrugsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DrugService_ServiceDesc.Streams[0], DrugService_ListMyDrugs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListMyDrugsRequest, Drug]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListMyDrugsClient = grpc.ServerStreamingClient[Drug]
+
+func (c *drugServiceClient) ListDrugsByBatch(ctx context.Context, in *ListDrugsByBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DrugService_ServiceDesc.Streams[1], DrugService_ListDrugsByBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDrugsByBatchRequest, Drug]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListDrugsByBatchClient = grpc.ServerStreamingClient[Drug]
+
+func (c *drugServiceClient) ListDrugsByTransfer(ctx context.Context, in *ListDrugsByTransferRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Drug], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DrugService_ServiceDesc.Streams[2], DrugService_ListDrugsByTransfer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDrugsByTransferRequest, Drug]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListDrugsByTransferClient = grpc.ServerStreamingClient[Drug]
+
+// DrugServiceServer is the server API for DrugService service.
+// All implementations must embed UnimplementedDrugServiceServer
+// for forward compatibility.
+//
+// DrugService manages serialized drugs. Lists are streamed one drug per message.
+type DrugServiceServer interface {
+	CreateDrug(context.Context, *CreateDrugRequest) (*CreateDrugResponse, error)
+	GetDrug(context.Context, *GetDrugRequest) (*Drug, error)
+	// ListMyDrugs streams the drugs owned by the caller.
+	ListMyDrugs(*ListMyDrugsRequest, grpc.ServerStreamingServer[Drug]) error
+	ListDrugsByBatch(*ListDrugsByBatchRequest, grpc.ServerStreamingServer[Drug]) error
+	ListDrugsByTransfer(*ListDrugsByTransferRequest, grpc.ServerStreamingServer[Drug]) error
+	mustEmbedUnimplementedDrugServiceServer()
+}
+
+// UnimplementedDrugServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDrugServiceServer struct{}
+
+func (UnimplementedDrugServiceServer) CreateDrug(context.Context, *CreateDrugRequest) (*CreateDrugResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDrug not implemented")
+}
+func (UnimplementedDrugServiceServer) GetDrug(context.Context, *GetDrugRequest) (*Drug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDrug not implemented")
+}
+func (UnimplementedDrugServiceServer) ListMyDrugs(*ListMyDrugsRequest, grpc.ServerStreamingServer[Drug]) error {
+	return status.Errorf(codes.Unimplemented, "method ListMyDrugs not implemented")
+}
+func (UnimplementedDrugServiceServer) ListDrugsByBatch(*ListDrugsByBatchRequest, grpc.ServerStreamingServer[Drug]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDrugsByBatch not implemented")
+}
+func (UnimplementedDrugServiceServer) ListDrugsByTransfer(*ListDrugsByTransferRequest, grpc.ServerStreamingServer[Drug]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDrugsByTransfer not implemented")
+}
+func (UnimplementedDrugServiceServer) mustEmbedUnimplementedDrugServiceServer() {}
+func (UnimplementedDrugServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeDrugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DrugServiceServer will
+// result in compilation errors.
+type UnsafeDrugServiceServer interface {
+	mustEmbedUnimplementedDrugServiceServer()
+}
+
+func RegisterDrugServiceServer(s grpc.ServiceRegistrar, srv DrugServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDrugServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DrugService_ServiceDesc, srv)
+}
+
+func _DrugService_CreateDrug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDrugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrugServiceServer).CreateDrug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DrugService_CreateDrug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrugServiceServer).CreateDrug(ctx, req.(*CreateDrugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DrugService_GetDrug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDrugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrugServiceServer).GetDrug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DrugService_GetDrug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrugServiceServer).GetDrug(ctx, req.(*GetDrugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DrugService_ListMyDrugs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMyDrugsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DrugServiceServer).ListMyDrugs(m, &grpc.GenericServerStream[ListMyDrugsRequest, Drug]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListMyDrugsServer = grpc.ServerStreamingServer[Drug]
+
+func _DrugService_ListDrugsByBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDrugsByBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DrugServiceServer).ListDrugsByBatch(m, &grpc.GenericServerStream[ListDrugsByBatchRequest, Drug]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListDrugsByBatchServer = grpc.ServerStreamingServer[Drug]
+
+func _DrugService_ListDrugsByTransfer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDrugsByTransferRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DrugServiceServer).ListDrugsByTransfer(m, &grpc.GenericServerStream[ListDrugsByTransferRequest, Drug]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DrugService_ListDrugsByTransferServer = grpc.ServerStreamingServer[Drug]
+
+// DrugService_ServiceDesc is the grpc.ServiceDesc for DrugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DrugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "medtrace.v1.DrugService",
+	HandlerType: (*DrugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDrug",
+			Handler:    _DrugService_CreateDrug_Handler,
+		},
+		{
+			MethodName: "GetDrug",
+			Handler:    _DrugService_GetDrug_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListMyDrugs",
+			Handler:       _DrugService_ListMyDrugs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDrugsByBatch",
+			Handler:       _DrugService_ListDrugsByBatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDrugsByTransfer",
+			Handler:       _DrugService_ListDrugsByTransfer_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "medtrace/v1/drug.proto",
+}
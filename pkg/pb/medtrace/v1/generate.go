@@ -0,0 +1,5 @@
+// Package medtracev1 contains the messages and gRPC client and server stubs of the MedTrace gRPC API,
+// generated from the definitions in proto/medtrace/v1.
+package medtracev1
+
+//go:generate protoc -I ../../../../proto --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative medtrace/v1/organization.proto medtrace/v1/batch.proto medtrace/v1/drug.proto medtrace/v1/transfer.proto medtrace/v1/history.proto
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: medtrace/v1/history.proto
+
+package medtracev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HistoryService_GetOrganizationHistory_FullMethodName = "/medtrace.v1.HistoryService/GetOrganizationHistory"
+	HistoryService_GetBatchHistory_FullMethodName        = "/medtrace.v1.HistoryService/GetBatchHistory"
+	HistoryService_GetDrugHistory_FullMethodName         = "/medtrace.v1.HistoryService/GetDrugHistory"
+	HistoryService_GetTransferHistory_FullMethodName     = "/medtrace.v1.HistoryService/GetTransferHistory"
+	HistoryService_GetChanges_FullMethodName             = "/medtrace.v1.HistoryService/GetChanges"
+)
+
+// HistoryServiceClient is the client API for HistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HistoryService reads the ledger history of records, oldest version first.
+type HistoryServiceClient interface {
+	GetOrganizationHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*OrganizationHistory, error)
+	GetBatchHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*BatchHistory, error)
+	GetDrugHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*DrugHistory, error)
+	GetTransferHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*TransferHistory, error)
+	// GetChanges lists the fields each transaction changed in a record.
+	GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error)
+}
+
+type historyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHistoryServiceClient(cc grpc.ClientConnInterface) HistoryServiceClient {
+	return &historyServiceClient{cc}
+}
+
+func (c *historyServiceClient) GetOrganizationHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*OrganizationHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationHistory)
+	err := c.cc.Invoke(ctx, HistoryService_GetOrganizationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetBatchHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*BatchHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchHistory)
+	err := c.cc.Invoke(ctx, HistoryService_GetBatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetDrugHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*DrugHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrugHistory)
+	err := c.cc.Invoke(ctx, HistoryService_GetDrugHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetTransferHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*TransferHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferHistory)
+	err := c.cc.Invoke(ctx, HistoryService_GetTransferHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChangesResponse)
+	err := c.cc.Invoke(ctx, HistoryService_GetChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HistoryServiceServer is the server API for HistoryService service.
+// All implementations must embed UnimplementedHistoryServiceServer
+// for forward compatibility.
+//
+// HistoryService reads the ledger history of records, oldest version first.
+type HistoryServiceServer interface {
+	GetOrganizationHistory(context.Context, *GetHistoryRequest) (*OrganizationHistory, error)
+	GetBatchHistory(context.Context, *GetHistoryRequest) (*BatchHistory, error)
+	GetDrugHistory(context.Context, *GetHistoryRequest) (*DrugHistory, error)
+	GetTransferHistory(context.Context, *GetHistoryRequest) (*TransferHistory, error)
+	// GetChanges lists the fields each transaction changed in a record.
+	GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error)
+	mustEmbedUnimplementedHistoryServiceServer()
+}
+
+// UnimplementedHistoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHistoryServiceServer struct{}
+
+func (UnimplementedHistoryServiceServer) GetOrganizationHistory(context.Context, *GetHistoryRequest) (*OrganizationHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganizationHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetBatchHistory(context.Context, *GetHistoryRequest) (*BatchHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetDrugHistory(context.Context, *GetHistoryRequest) (*DrugHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDrugHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetTransferHistory(context.Context, *GetHistoryRequest) (*TransferHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChanges not implemented")
+}
+func (UnimplementedHistoryServiceServer) mustEmbedUnimplementedHistoryServiceServer() {}
+func (UnimplementedHistoryServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeHistoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HistoryServiceServer will
+// result in compilation errors.
+type UnsafeHistoryServiceServer interface {
+	mustEmbedUnimplementedHistoryServiceServer()
+}
+
+func RegisterHistoryServiceServer(s grpc.ServiceRegistrar, srv HistoryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHistoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HistoryService_ServiceDesc, srv)
+}
+
+func _HistoryService_GetOrganizationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetOrganizationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetOrganizationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetOrganizationHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetBatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetBatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetBatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetBatchHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetDrugHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetDrugHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetDrugHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetDrugHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetTransferHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetTransferHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetTransferHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetTransferHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetChanges(ctx, req.(*GetChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HistoryService_ServiceDesc is the grpc.ServiceDesc for HistoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HistoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "medtrace.v1.HistoryService",
+	HandlerType: (*HistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOrganizationHistory",
+			Handler:    _HistoryService_GetOrganizationHistory_Handler,
+		},
+		{
+			MethodName: "GetBatchHistory",
+			Handler:    _HistoryService_GetBatchHistory_Handler,
+		},
+		{
+			MethodName: "GetDrugHistory",
+			Handler:    _HistoryService_GetDrugHistory_Handler,
+		},
+		{
+			MethodName: "GetTransferHistory",
+			Handler:    _HistoryService_GetTransferHistory_Handler,
+		},
+		{
+			MethodName: "GetChanges",
+			Handler:    _HistoryService_GetChanges_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "medtrace/v1/history.proto",
+}
@@ -0,0 +1,183 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// BlockProof is the block holding a transaction. The data hash in its header covers every envelope of the block
+// and the ordering service signs the header, so an envelope found in Data was ordered in block Number.
+// The transactions filter is written by the committing peer after ordering and is not signed: it is the
+// validation result of the peer the API server read the block from.
+// Data discloses every transaction ordered in the block, not only the proven one, so a proof must only be given
+// to callers allowed to read the whole channel.
+type BlockProof struct {
+	Number             uint64   `json:"Number"`
+	PreviousHash       []byte   `json:"PreviousHash"`
+	DataHash           []byte   `json:"DataHash"`
+	Data               [][]byte `json:"Data"`               // Every envelope of the block, in order
+	TxIndex            int      `json:"TxIndex"`            // Position of the transaction's envelope in Data
+	Signatures         []byte   `json:"Signatures"`         // Marshalled common.Metadata at the SIGNATURES index
+	TransactionsFilter []byte   `json:"TransactionsFilter"` // One peer.TxValidationCode per envelope in Data
+}
+
+// NewBlockProof extracts the proof of transaction txID from a block, as returned by qscc GetBlockByTxID.
+func NewBlockProof(block *common.Block, txID string) (*BlockProof, error) {
+	metadata := block.GetMetadata().GetMetadata()
+	if len(metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil, fmt.Errorf("block %d has no transactions filter", block.GetHeader().GetNumber())
+	}
+	proof := &BlockProof{
+		Number:             block.GetHeader().GetNumber(),
+		PreviousHash:       block.GetHeader().GetPreviousHash(),
+		DataHash:           block.GetHeader().GetDataHash(),
+		Data:               block.GetData().GetData(),
+		TxIndex:            -1,
+		Signatures:         metadata[common.BlockMetadataIndex_SIGNATURES],
+		TransactionsFilter: metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER],
+	}
+	for i, data := range proof.Data {
+		if id, err := envelopeTxID(data); err == nil && id == txID {
+			proof.TxIndex = i
+			break
+		}
+	}
+	if proof.TxIndex < 0 {
+		return nil, fmt.Errorf("block %d does not hold transaction %s", proof.Number, txID)
+	}
+	return proof, nil
+}
+
+// Envelope returns the envelope of the proven transaction.
+func (b *BlockProof) Envelope() []byte {
+	if b.TxIndex < 0 || b.TxIndex >= len(b.Data) {
+		return nil
+	}
+	return b.Data[b.TxIndex]
+}
+
+// ValidationCode returns the validation code the committing peer recorded for the proven transaction.
+func (b *BlockProof) ValidationCode() peer.TxValidationCode {
+	if b.TxIndex < 0 || b.TxIndex >= len(b.TransactionsFilter) {
+		return peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+	return peer.TxValidationCode(b.TransactionsFilter[b.TxIndex])
+}
+
+// verifyBlock checks that envelope is part of the block, that the block was signed by the ordering service
+// and that the committing peer marked the transaction valid.
+func (v *Verifier) verifyBlock(b *BlockProof, envelope []byte, at time.Time) error {
+	if b == nil {
+		return fmt.Errorf("proof carries no block")
+	}
+	dataHash := sha256.Sum256(bytes.Join(b.Data, nil))
+	if !bytes.Equal(dataHash[:], b.DataHash) {
+		return fmt.Errorf("data hash of block %d does not match its envelopes", b.Number)
+	}
+	if !bytes.Equal(b.Envelope(), envelope) {
+		return fmt.Errorf("block %d does not hold the envelope at index %d", b.Number, b.TxIndex)
+	}
+	if err := v.verifyOrdererSignature(b, at); err != nil {
+		return err
+	}
+	if len(b.TransactionsFilter) != len(b.Data) {
+		return fmt.Errorf("transactions filter of block %d has %d entries for %d envelopes", b.Number, len(b.TransactionsFilter), len(b.Data))
+	}
+	if code := b.ValidationCode(); code != peer.TxValidationCode_VALID {
+		return fmt.Errorf("transaction was not validated by the committing peers: %s", code)
+	}
+	return nil
+}
+
+// verifyOrdererSignature checks that a member of one of the ordering service's MSPs signed the block header.
+func (v *Verifier) verifyOrdererSignature(b *BlockProof, at time.Time) error {
+	if len(v.OrdererMSPs) == 0 {
+		return fmt.Errorf("no orderer MSP configured to check the signature of block %d", b.Number)
+	}
+	var metadata common.Metadata
+	if err := proto.Unmarshal(b.Signatures, &metadata); err != nil {
+		return fmt.Errorf("invalid signatures of block %d: %w", b.Number, err)
+	}
+	header, err := headerBytes(b)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, sig := range metadata.GetSignatures() {
+		var signatureHeader common.SignatureHeader
+		if err := proto.Unmarshal(sig.GetSignatureHeader(), &signatureHeader); err != nil || len(signatureHeader.GetCreator()) == 0 {
+			failures = append(failures, "signature without creator")
+			continue
+		}
+		signer, err := parseIdentity(signatureHeader.GetCreator())
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("invalid signer: %v", err))
+			continue
+		}
+		if !v.isOrderer(signer.MSPID) {
+			failures = append(failures, fmt.Sprintf("signed by %s, which is not an orderer MSP", signer.MSPID))
+			continue
+		}
+		signed := make([]byte, 0, len(metadata.GetValue())+len(sig.GetSignatureHeader())+len(header))
+		signed = append(signed, metadata.GetValue()...)
+		signed = append(signed, sig.GetSignatureHeader()...)
+		signed = append(signed, header...)
+		if err := v.verifySignature(signer, signed, sig.GetSignature(), at); err != nil {
+			failures = append(failures, fmt.Sprintf("signature by %s: %v", signer.MSPID, err))
+			continue
+		}
+		return nil
+	}
+	if len(failures) == 0 {
+		return fmt.Errorf("block %d is not signed", b.Number)
+	}
+	return fmt.Errorf("block %d is not signed by the ordering service: %v", b.Number, failures)
+}
+
+func (v *Verifier) isOrderer(mspID string) bool {
+	for _, id := range v.OrdererMSPs {
+		if id == mspID {
+			return true
+		}
+	}
+	return false
+}
+
+// headerBytes is the ASN.1 encoding of a block header that the ordering service signs.
+func headerBytes(b *BlockProof) ([]byte, error) {
+	data, err := asn1.Marshal(struct {
+		Number       *big.Int
+		PreviousHash []byte
+		DataHash     []byte
+	}{new(big.Int).SetUint64(b.Number), b.PreviousHash, b.DataHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode header of block %d: %w", b.Number, err)
+	}
+	return data, nil
+}
+
+// envelopeTxID reads the transaction ID from the channel header of a marshalled envelope.
+func envelopeTxID(data []byte) (string, error) {
+	var envelope common.Envelope
+	if err := proto.Unmarshal(data, &envelope); err != nil {
+		return "", err
+	}
+	var payload common.Payload
+	if err := proto.Unmarshal(envelope.GetPayload(), &payload); err != nil {
+		return "", err
+	}
+	var channelHeader common.ChannelHeader
+	if err := proto.Unmarshal(payload.GetHeader().GetChannelHeader(), &channelHeader); err != nil {
+		return "", err
+	}
+	return channelHeader.GetTxId(), nil
+}
@@ -0,0 +1,158 @@
+package verifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// Identity is a Fabric MSP identity: the MSP ID and the PEM certificate of a member.
+type Identity struct {
+	MSPID       string
+	Certificate []byte
+}
+
+// SignedEndorsement is an endorsement together with the exact bytes the endorser signed.
+type SignedEndorsement struct {
+	Endorser  Identity
+	Signature []byte
+	Signed    []byte // Proposal response payload followed by the serialized endorser identity
+}
+
+// Write is a key written by a transaction in one chaincode namespace.
+type Write struct {
+	Namespace string
+	Key       string
+	Value     []byte
+	IsDelete  bool
+}
+
+// Transaction is the content of an endorser transaction envelope that matters for verifying a history entry.
+type Transaction struct {
+	TxID         string
+	ChannelID    string
+	Timestamp    time.Time
+	Creator      Identity
+	Nonce        []byte
+	Payload      []byte // Signed by the creator
+	Signature    []byte
+	Chaincodes   []string
+	Endorsements []SignedEndorsement
+	Writes       []Write
+
+	creator []byte // Serialized creator identity as signed
+}
+
+// ParseEnvelope decodes a marshalled common.Envelope holding an endorser transaction.
+func ParseEnvelope(data []byte) (*Transaction, error) {
+	var envelope common.Envelope
+	if err := proto.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid envelope: %w", err)
+	}
+	var payload common.Payload
+	if err := proto.Unmarshal(envelope.GetPayload(), &payload); err != nil {
+		return nil, fmt.Errorf("invalid envelope payload: %w", err)
+	}
+	if payload.GetHeader() == nil {
+		return nil, fmt.Errorf("envelope payload has no header")
+	}
+	var channelHeader common.ChannelHeader
+	if err := proto.Unmarshal(payload.GetHeader().GetChannelHeader(), &channelHeader); err != nil {
+		return nil, fmt.Errorf("invalid channel header: %w", err)
+	}
+	if common.HeaderType(channelHeader.GetType()) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, fmt.Errorf("envelope is a %s, not an endorser transaction", common.HeaderType(channelHeader.GetType()))
+	}
+	var signatureHeader common.SignatureHeader
+	if err := proto.Unmarshal(payload.GetHeader().GetSignatureHeader(), &signatureHeader); err != nil {
+		return nil, fmt.Errorf("invalid signature header: %w", err)
+	}
+	creator, err := parseIdentity(signatureHeader.GetCreator())
+	if err != nil {
+		return nil, fmt.Errorf("invalid creator: %w", err)
+	}
+
+	tx := &Transaction{
+		TxID:      channelHeader.GetTxId(),
+		ChannelID: channelHeader.GetChannelId(),
+		Timestamp: channelHeader.GetTimestamp().AsTime(),
+		Creator:   creator,
+		Nonce:     signatureHeader.GetNonce(),
+		Payload:   envelope.GetPayload(),
+		Signature: envelope.GetSignature(),
+		creator:   signatureHeader.GetCreator(),
+	}
+
+	var transaction peer.Transaction
+	if err := proto.Unmarshal(payload.GetData(), &transaction); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	for i, action := range transaction.GetActions() {
+		var actionPayload peer.ChaincodeActionPayload
+		if err := proto.Unmarshal(action.GetPayload(), &actionPayload); err != nil {
+			return nil, fmt.Errorf("invalid payload of action %d: %w", i, err)
+		}
+		endorsed := actionPayload.GetAction()
+		if endorsed == nil {
+			return nil, fmt.Errorf("action %d is not endorsed", i)
+		}
+		for j, e := range endorsed.GetEndorsements() {
+			endorser, err := parseIdentity(e.GetEndorser())
+			if err != nil {
+				return nil, fmt.Errorf("invalid endorser %d of action %d: %w", j, i, err)
+			}
+			signed := make([]byte, 0, len(endorsed.GetProposalResponsePayload())+len(e.GetEndorser()))
+			signed = append(signed, endorsed.GetProposalResponsePayload()...)
+			signed = append(signed, e.GetEndorser()...)
+			tx.Endorsements = append(tx.Endorsements, SignedEndorsement{Endorser: endorser, Signature: e.GetSignature(), Signed: signed})
+		}
+
+		var responsePayload peer.ProposalResponsePayload
+		if err := proto.Unmarshal(endorsed.GetProposalResponsePayload(), &responsePayload); err != nil {
+			return nil, fmt.Errorf("invalid proposal response of action %d: %w", i, err)
+		}
+		var chaincodeAction peer.ChaincodeAction
+		if err := proto.Unmarshal(responsePayload.GetExtension(), &chaincodeAction); err != nil {
+			return nil, fmt.Errorf("invalid chaincode action %d: %w", i, err)
+		}
+		tx.Chaincodes = append(tx.Chaincodes, chaincodeAction.GetChaincodeId().GetName())
+
+		var readWriteSet rwset.TxReadWriteSet
+		if err := proto.Unmarshal(chaincodeAction.GetResults(), &readWriteSet); err != nil {
+			return nil, fmt.Errorf("invalid read-write set of action %d: %w", i, err)
+		}
+		for _, ns := range readWriteSet.GetNsRwset() {
+			var kv kvrwset.KVRWSet
+			if err := proto.Unmarshal(ns.GetRwset(), &kv); err != nil {
+				return nil, fmt.Errorf("invalid read-write set of namespace %s: %w", ns.GetNamespace(), err)
+			}
+			for _, w := range kv.GetWrites() {
+				tx.Writes = append(tx.Writes, Write{Namespace: ns.GetNamespace(), Key: w.GetKey(), Value: w.GetValue(), IsDelete: w.GetIsDelete()})
+			}
+		}
+	}
+	return tx, nil
+}
+
+// ComputedTxID is the transaction ID Fabric derives from the nonce and creator of the transaction.
+// A transaction ID that differs from it was not produced by the creator.
+func (tx *Transaction) ComputedTxID() string {
+	digest := sha256.Sum256(append(append([]byte{}, tx.Nonce...), tx.creator...))
+	return hex.EncodeToString(digest[:])
+}
+
+func parseIdentity(data []byte) (Identity, error) {
+	var id msp.SerializedIdentity
+	if err := proto.Unmarshal(data, &id); err != nil {
+		return Identity{}, err
+	}
+	return Identity{MSPID: id.GetMspid(), Certificate: id.GetIdBytes()}, nil
+}
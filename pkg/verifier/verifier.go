@@ -0,0 +1,298 @@
+// Package verifier checks key history served by the MedTrace API against the Fabric transactions behind it,
+// without trusting the API server. Each history entry carries the transaction envelope it was read from and
+// the block holding it; the verifier checks the creator and endorsement signatures against the certificate
+// authorities of the organizations' MSPs, that the endorsed write set contains exactly the record the API
+// returned, that the envelope is part of a block signed by the ordering service, and that the block's
+// transactions filter marks the transaction valid.
+package verifier
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// ValidationValid is the validation code of transactions the committing peers accepted.
+const ValidationValid = "VALID"
+
+// History is the verifiable key history of one ledger key.
+type History struct {
+	Channel   string  `json:"Channel"`
+	Chaincode string  `json:"Chaincode"`
+	Key       string  `json:"Key"`
+	Entries   []Entry `json:"Entries"`
+}
+
+// Entry is one version of the key and the proof of the transaction that wrote it.
+type Entry struct {
+	TxID      string          `json:"TxID"`
+	Timestamp time.Time       `json:"Timestamp"`
+	IsDelete  bool            `json:"IsDelete"`
+	Record    json.RawMessage `json:"Record"`
+	Proof     Proof           `json:"Proof"`
+}
+
+// Proof locates a transaction on the ledger and carries its signed envelope and the block holding it.
+// Block number, validation code and endorsements are repeated for display; the verifier only trusts
+// the envelope and the signed block.
+type Proof struct {
+	BlockNumber    uint64        `json:"BlockNumber"`
+	ValidationCode string        `json:"ValidationCode"`
+	Envelope       []byte        `json:"Envelope"`
+	Endorsements   []Endorsement `json:"Endorsements"`
+	Block          *BlockProof   `json:"Block"`
+}
+
+// Endorsement is the signature of one endorsing peer over the transaction's results.
+type Endorsement struct {
+	MSPID       string `json:"MSPID"`
+	Certificate string `json:"Certificate"`
+	Signature   []byte `json:"Signature"`
+}
+
+// EndorsementsOf lists the endorsements of a parsed transaction.
+func EndorsementsOf(tx *Transaction) []Endorsement {
+	endorsements := make([]Endorsement, 0, len(tx.Endorsements))
+	for _, e := range tx.Endorsements {
+		endorsements = append(endorsements, Endorsement{MSPID: e.Endorser.MSPID, Certificate: string(e.Endorser.Certificate), Signature: e.Signature})
+	}
+	return endorsements
+}
+
+// MSP holds the certificate authorities of one organization.
+type MSP struct {
+	Roots         *x509.CertPool
+	Intermediates *x509.CertPool
+}
+
+// TrustStore maps MSP IDs to the certificate authorities their members' certificates must chain to.
+type TrustStore map[string]MSP
+
+// LoadMSP reads the cacerts and intermediatecerts directories of a local MSP folder, as generated by cryptogen
+// or the Fabric CA client, e.g. organizations/peerOrganizations/org1.medtrace.com/msp.
+func (t TrustStore) LoadMSP(mspID, dir string) error {
+	roots, err := loadPool(filepath.Join(dir, "cacerts"))
+	if err != nil {
+		return err
+	}
+	if roots == nil {
+		return fmt.Errorf("no CA certificates in %s", filepath.Join(dir, "cacerts"))
+	}
+	intermediates, err := loadPool(filepath.Join(dir, "intermediatecerts"))
+	if err != nil {
+		return err
+	}
+	if intermediates == nil {
+		intermediates = x509.NewCertPool()
+	}
+	t[mspID] = MSP{Roots: roots, Intermediates: intermediates}
+	return nil
+}
+
+// VerifyCertificate parses a PEM certificate and checks that it chains to the certificate authorities of the
+// MSP and was valid at the given time.
+func (t TrustStore) VerifyCertificate(mspID string, certPEM []byte, at time.Time) (*x509.Certificate, error) {
+	msp, ok := t[mspID]
+	if !ok {
+		return nil, fmt.Errorf("unknown MSP %s", mspID)
+	}
+	cert, err := identity.CertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         msp.Roots,
+		Intermediates: msp.Intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("certificate is not issued by %s: %w", mspID, err)
+	}
+	return cert, nil
+}
+
+func loadPool(dir string) (*x509.CertPool, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var pool *x509.CertPool
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name(), err)
+		}
+		cert, err := identity.CertificateFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate %s: %w", filepath.Join(dir, f.Name()), err)
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AddCert(cert)
+	}
+	return pool, nil
+}
+
+// Verifier checks histories against a trust store.
+type Verifier struct {
+	Trust TrustStore
+	// MinEndorsements is the number of distinct MSPs that must have endorsed each transaction.
+	// It should match the chaincode endorsement policy; values below 1 are treated as 1.
+	MinEndorsements int
+	// OrdererMSPs lists the MSPs of the ordering service, whose certificate authorities must be in Trust.
+	// Every block must be signed by a member of one of them.
+	OrdererMSPs []string
+}
+
+// EntryResult is the outcome of verifying one history entry.
+type EntryResult struct {
+	TxID        string   `json:"TxID"`
+	BlockNumber uint64   `json:"BlockNumber"`
+	Creator     string   `json:"Creator"`
+	Endorsers   []string `json:"Endorsers"`
+	Error       string   `json:"Error,omitempty"`
+}
+
+// Report is the outcome of verifying a history.
+type Report struct {
+	Key     string        `json:"Key"`
+	Valid   bool          `json:"Valid"`
+	Entries []EntryResult `json:"Entries"`
+}
+
+// Verify checks every entry of a history and reports which ones could not be verified.
+func (v *Verifier) Verify(h History) Report {
+	report := Report{Key: h.Key, Valid: true, Entries: make([]EntryResult, 0, len(h.Entries))}
+	for _, entry := range h.Entries {
+		result := v.verifyEntry(h, entry)
+		if result.Error != "" {
+			report.Valid = false
+		}
+		report.Entries = append(report.Entries, result)
+	}
+	return report
+}
+
+func (v *Verifier) verifyEntry(h History, entry Entry) EntryResult {
+	result := EntryResult{TxID: entry.TxID, BlockNumber: entry.Proof.BlockNumber, Endorsers: []string{}}
+	fail := func(format string, args ...any) EntryResult {
+		result.Error = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	tx, err := ParseEnvelope(entry.Proof.Envelope)
+	if err != nil {
+		return fail("%v", err)
+	}
+	result.Creator = tx.Creator.MSPID
+
+	if tx.TxID != entry.TxID {
+		return fail("envelope holds transaction %s", tx.TxID)
+	}
+	if tx.ComputedTxID() != tx.TxID {
+		return fail("transaction ID does not match the nonce and creator of the envelope")
+	}
+	if h.Channel != "" && tx.ChannelID != h.Channel {
+		return fail("transaction belongs to channel %s", tx.ChannelID)
+	}
+	if !entry.Timestamp.IsZero() && !tx.Timestamp.Equal(entry.Timestamp) {
+		return fail("entry timestamp %s differs from transaction timestamp %s", entry.Timestamp.Format(time.RFC3339Nano), tx.Timestamp.Format(time.RFC3339Nano))
+	}
+
+	if err := v.verifyBlock(entry.Proof.Block, entry.Proof.Envelope, tx.Timestamp); err != nil {
+		return fail("%v", err)
+	}
+	if entry.Proof.Block.Number != entry.Proof.BlockNumber {
+		return fail("transaction is in block %d, not %d", entry.Proof.Block.Number, entry.Proof.BlockNumber)
+	}
+
+	if err := v.verifySignature(tx.Creator, tx.Payload, tx.Signature, tx.Timestamp); err != nil {
+		return fail("creator signature: %v", err)
+	}
+
+	endorsers := make(map[string]bool)
+	for _, e := range tx.Endorsements {
+		if err := v.verifySignature(e.Endorser, e.Signed, e.Signature, tx.Timestamp); err != nil {
+			return fail("endorsement by %s: %v", e.Endorser.MSPID, err)
+		}
+		endorsers[e.Endorser.MSPID] = true
+	}
+	for mspID := range endorsers {
+		result.Endorsers = append(result.Endorsers, mspID)
+	}
+	sort.Strings(result.Endorsers)
+	required := v.MinEndorsements
+	if required < 1 {
+		required = 1
+	}
+	if len(endorsers) < required {
+		return fail("endorsed by %d organization(s), %d required", len(endorsers), required)
+	}
+
+	if err := matchWrite(tx, h.Chaincode, h.Key, entry); err != nil {
+		return fail("%v", err)
+	}
+	return result
+}
+
+// verifySignature checks that id is a member of its MSP at the time of the transaction and signed data.
+func (v *Verifier) verifySignature(id Identity, data, signature []byte, at time.Time) error {
+	cert, err := v.Trust.VerifyCertificate(id.MSPID, id.Certificate, at)
+	if err != nil {
+		return err
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("certificate does not hold an ECDSA key")
+	}
+	digest := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(publicKey, digest[:], signature) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+// matchWrite checks that the transaction wrote the entry's record to the key, comparing JSON values
+// rather than bytes so that field order and whitespace do not matter.
+func matchWrite(tx *Transaction, chaincode, key string, entry Entry) error {
+	for _, w := range tx.Writes {
+		if w.Key != key || (chaincode != "" && w.Namespace != chaincode) {
+			continue
+		}
+		if w.IsDelete != entry.IsDelete {
+			return fmt.Errorf("transaction delete flag of %s is %t", key, w.IsDelete)
+		}
+		if entry.IsDelete {
+			return nil
+		}
+		var written, served any
+		if err := json.Unmarshal(w.Value, &written); err != nil {
+			return fmt.Errorf("written value of %s is not JSON: %w", key, err)
+		}
+		if err := json.Unmarshal(entry.Record, &served); err != nil {
+			return fmt.Errorf("record is not JSON: %w", err)
+		}
+		if !reflect.DeepEqual(written, served) {
+			return fmt.Errorf("record differs from the value the transaction wrote to %s", key)
+		}
+		return nil
+	}
+	return fmt.Errorf("transaction did not write %s", key)
+}
@@ -0,0 +1,340 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testChannel   = "medtrace"
+	testChaincode = "medtrace_cc"
+	testKey       = "DRUG-1"
+	testRecord    = `{"ID":"DRUG-1","OwnerID":"Org1"}`
+)
+
+var testTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// member is an enrolled identity of an MSP: its serialized identity and signing key.
+type member struct {
+	serialized []byte
+	key        *ecdsa.PrivateKey
+}
+
+func (m member) sign(t *testing.T, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, m.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func mustMarshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newMSP creates a certificate authority for mspID, adds it to trust and enrolls one member.
+func newMSP(t *testing.T, trust TrustStore, mspID string) member {
+	t.Helper()
+	validity := func(serial int64, cn string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn, Organization: []string{mspID}},
+			NotBefore:    testTime.Add(-time.Hour),
+			NotAfter:     testTime.Add(24 * time.Hour),
+		}
+	}
+	caKey := newKey(t)
+	caTemplate := validity(1, "ca."+mspID)
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	trust[mspID] = MSP{Roots: roots, Intermediates: x509.NewCertPool()}
+
+	key := newKey(t)
+	template := validity(2, "member."+mspID)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return member{serialized: mustMarshal(t, &msp.SerializedIdentity{Mspid: mspID, IdBytes: certPEM}), key: key}
+}
+
+// newEnvelope builds an endorser transaction by creator, endorsed by endorsers, writing value to key.
+func newEnvelope(t *testing.T, creator member, endorsers []member, key, value string) (string, []byte) {
+	t.Helper()
+	nonce := []byte("nonce-" + key + value)
+	digest := sha256.Sum256(append(append([]byte{}, nonce...), creator.serialized...))
+	txID := hex.EncodeToString(digest[:])
+
+	kv := &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: key, Value: []byte(value)}}}
+	results := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset:   []*rwset.NsReadWriteSet{{Namespace: testChaincode, Rwset: mustMarshal(t, kv)}},
+	}
+	action := &peer.ChaincodeAction{ChaincodeId: &peer.ChaincodeID{Name: testChaincode}, Results: mustMarshal(t, results)}
+	responsePayload := mustMarshal(t, &peer.ProposalResponsePayload{Extension: mustMarshal(t, action)})
+
+	endorsed := &peer.ChaincodeEndorsedAction{ProposalResponsePayload: responsePayload}
+	for _, e := range endorsers {
+		signed := append(append([]byte{}, responsePayload...), e.serialized...)
+		endorsed.Endorsements = append(endorsed.Endorsements, &peer.Endorsement{Endorser: e.serialized, Signature: e.sign(t, signed)})
+	}
+	transaction := &peer.Transaction{Actions: []*peer.TransactionAction{{
+		Payload: mustMarshal(t, &peer.ChaincodeActionPayload{Action: endorsed}),
+	}}}
+
+	payload := mustMarshal(t, &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: mustMarshal(t, &common.ChannelHeader{
+				Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+				ChannelId: testChannel,
+				TxId:      txID,
+				Timestamp: timestamppb.New(testTime),
+			}),
+			SignatureHeader: mustMarshal(t, &common.SignatureHeader{Creator: creator.serialized, Nonce: nonce}),
+		},
+		Data: mustMarshal(t, transaction),
+	})
+	return txID, mustMarshal(t, &common.Envelope{Payload: payload, Signature: creator.sign(t, payload)})
+}
+
+// newBlock orders envelopes into block 7, signed by orderer, with every transaction marked valid.
+func newBlock(t *testing.T, orderer member, envelopes ...[]byte) *common.Block {
+	t.Helper()
+	dataHash := sha256.Sum256(bytes.Join(envelopes, nil))
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 7, PreviousHash: []byte("previous"), DataHash: dataHash[:]},
+		Data:   &common.BlockData{Data: envelopes},
+	}
+	header, err := headerBytes(&BlockProof{Number: 7, PreviousHash: block.Header.PreviousHash, DataHash: block.Header.DataHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := []byte("last config")
+	signatureHeader := mustMarshal(t, &common.SignatureHeader{Creator: orderer.serialized, Nonce: []byte("block-nonce")})
+	signed := append(append(append([]byte{}, value...), signatureHeader...), header...)
+	signatures := &common.Metadata{Value: value, Signatures: []*common.MetadataSignature{{
+		SignatureHeader: signatureHeader,
+		Signature:       orderer.sign(t, signed),
+	}}}
+
+	filter := make([]byte, len(envelopes))
+	for i := range filter {
+		filter[i] = byte(peer.TxValidationCode_VALID)
+	}
+	block.Metadata = &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))}
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = mustMarshal(t, signatures)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = filter
+	return block
+}
+
+// fixture is a history with one entry, endorsed by Org1MSP and Org2MSP and ordered after an unrelated transaction.
+func fixture(t *testing.T) (*Verifier, History) {
+	t.Helper()
+	trust := TrustStore{}
+	org1 := newMSP(t, trust, "Org1MSP")
+	org2 := newMSP(t, trust, "Org2MSP")
+	orderer := newMSP(t, trust, "OrdererMSP")
+
+	_, other := newEnvelope(t, org2, []member{org2}, "DRUG-2", `{"ID":"DRUG-2"}`)
+	txID, envelope := newEnvelope(t, org1, []member{org1, org2}, testKey, testRecord)
+	block := newBlock(t, orderer, other, envelope)
+	blockProof, err := NewBlockProof(block, txID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history := History{
+		Channel:   testChannel,
+		Chaincode: testChaincode,
+		Key:       testKey,
+		Entries: []Entry{{
+			TxID:      txID,
+			Timestamp: testTime,
+			Record:    json.RawMessage(testRecord),
+			Proof: Proof{
+				BlockNumber:    blockProof.Number,
+				ValidationCode: blockProof.ValidationCode().String(),
+				Envelope:       blockProof.Envelope(),
+				Block:          blockProof,
+			},
+		}},
+	}
+	return &Verifier{Trust: trust, MinEndorsements: 2, OrdererMSPs: []string{"OrdererMSP"}}, history
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(v *Verifier, h *History)
+		err    string // Substring of the entry error; empty if the history verifies
+	}{
+		{
+			name:   "valid",
+			modify: func(v *Verifier, h *History) {},
+		},
+		{
+			name: "record with reordered fields",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Record = json.RawMessage(`{"OwnerID":"Org1", "ID":"DRUG-1"}`)
+			},
+		},
+		{
+			name: "tampered record",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Record = json.RawMessage(`{"ID":"DRUG-1","OwnerID":"Org3"}`)
+			},
+			err: "record differs",
+		},
+		{
+			name: "other key",
+			modify: func(v *Verifier, h *History) {
+				h.Key = "DRUG-2"
+			},
+			err: "did not write DRUG-2",
+		},
+		{
+			name: "wrong data hash",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Proof.Block.DataHash = bytes.Repeat([]byte{1}, sha256.Size)
+			},
+			err: "data hash of block 7",
+		},
+		{
+			name: "envelope missing from the block",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Proof.Block.TxIndex = 0
+			},
+			err: "does not hold the envelope",
+		},
+		{
+			name: "missing orderer signature",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Proof.Block.Signatures = mustMarshal(t, &common.Metadata{Value: []byte("last config")})
+			},
+			err: "block 7 is not signed",
+		},
+		{
+			name: "orderer MSP not configured",
+			modify: func(v *Verifier, h *History) {
+				v.OrdererMSPs = []string{"Org1MSP"}
+			},
+			err: "not an orderer MSP",
+		},
+		{
+			name: "invalidated transaction",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Proof.Block.TransactionsFilter[1] = byte(peer.TxValidationCode_MVCC_READ_CONFLICT)
+			},
+			err: "MVCC_READ_CONFLICT",
+		},
+		{
+			name: "displayed block number",
+			modify: func(v *Verifier, h *History) {
+				h.Entries[0].Proof.BlockNumber = 8
+			},
+			err: "not 8",
+		},
+		{
+			name: "more endorsements required than given",
+			modify: func(v *Verifier, h *History) {
+				v.MinEndorsements = 3
+			},
+			err: "endorsed by 2 organization(s), 3 required",
+		},
+		{
+			name: "unknown endorser MSP",
+			modify: func(v *Verifier, h *History) {
+				delete(v.Trust, "Org2MSP")
+			},
+			err: "unknown MSP Org2MSP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, h := fixture(t)
+			tt.modify(v, &h)
+			report := v.Verify(h)
+			if len(report.Entries) != 1 {
+				t.Fatalf("%d entry results, want 1", len(report.Entries))
+			}
+			result := report.Entries[0]
+			if tt.err == "" {
+				if !report.Valid || result.Error != "" {
+					t.Fatalf("Verify: %s", result.Error)
+				}
+				if result.Creator != "Org1MSP" || strings.Join(result.Endorsers, ",") != "Org1MSP,Org2MSP" {
+					t.Errorf("creator %s, endorsers %v", result.Creator, result.Endorsers)
+				}
+				return
+			}
+			if report.Valid || !strings.Contains(result.Error, tt.err) {
+				t.Errorf("Verify error = %q, want one containing %q", result.Error, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewBlockProof(t *testing.T) {
+	_, h := fixture(t)
+	proof := h.Entries[0].Proof.Block
+	if proof.TxIndex != 1 || len(proof.Data) != 2 {
+		t.Errorf("TxIndex %d of %d envelopes, want 1 of 2", proof.TxIndex, len(proof.Data))
+	}
+	block := &common.Block{
+		Header:   &common.BlockHeader{Number: proof.Number},
+		Data:     &common.BlockData{Data: proof.Data},
+		Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+	}
+	if _, err := NewBlockProof(block, "unknown"); err == nil {
+		t.Error("NewBlockProof of a transaction the block does not hold: want an error")
+	}
+}